@@ -0,0 +1,182 @@
+package admin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+// Directory resolves role and license IDs to their details. Roles and the
+// licenses of each organization are listed once and cached, so expanding
+// many people costs a few requests in total. A Directory is safe for
+// concurrent use.
+type Directory struct {
+	roles    *RolesService
+	licenses *LicensesService
+
+	mu           sync.Mutex
+	roleByID     map[string]*Role
+	rolesLoaded  bool
+	licenseByID  map[string]*License
+	loadedOrgIDs map[string]bool
+}
+
+func NewDirectory(roles *RolesService, licenses *LicensesService) *Directory {
+	return &Directory{
+		roles:        roles,
+		licenses:     licenses,
+		roleByID:     make(map[string]*Role),
+		licenseByID:  make(map[string]*License),
+		loadedOrgIDs: make(map[string]bool),
+	}
+}
+
+// PersonAccess is a person together with their resolved roles and licenses.
+type PersonAccess struct {
+	Person   *messaging.Person
+	Roles    []*Role
+	Licenses []*License
+}
+
+// RoleNames returns the names of the person's roles. Roles that no longer
+// exist are reported by ID.
+func (a *PersonAccess) RoleNames() []string {
+	names := make([]string, 0, len(a.Roles))
+	for _, role := range a.Roles {
+		names = append(names, cmp.Or(role.Name, role.ID))
+	}
+	return names
+}
+
+// LicenseNames returns the names of the person's licenses. Licenses that no
+// longer exist are reported by ID.
+func (a *PersonAccess) LicenseNames() []string {
+	names := make([]string, 0, len(a.Licenses))
+	for _, license := range a.Licenses {
+		names = append(names, cmp.Or(license.Name, license.ID))
+	}
+	return names
+}
+
+// ExpandPerson resolves the role and license IDs of person.
+func (d *Directory) ExpandPerson(ctx context.Context, person *messaging.Person) (*PersonAccess, error) {
+	if person == nil {
+		return nil, core.InvalidParameter("Directory.ExpandPerson", "person")
+	}
+
+	access := &PersonAccess{Person: person}
+
+	for _, roleID := range person.Roles {
+		role, err := d.Role(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		access.Roles = append(access.Roles, role)
+	}
+
+	for _, licenseID := range person.Licenses {
+		license, err := d.License(ctx, person.OrgID, licenseID)
+		if err != nil {
+			return nil, err
+		}
+		access.Licenses = append(access.Licenses, license)
+	}
+
+	return access, nil
+}
+
+// Role returns the role with roleID. A role that does not exist is returned
+// with only its ID set.
+func (d *Directory) Role(ctx context.Context, roleID string) (*Role, error) {
+	if roleID == "" {
+		return nil, core.InvalidParameter("Directory.Role", "roleID")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.rolesLoaded {
+		roles, err := d.roles.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, role := range roles {
+			d.roleByID[role.ID] = role
+		}
+		d.rolesLoaded = true
+	}
+
+	if role, ok := d.roleByID[roleID]; ok {
+		return role, nil
+	}
+
+	role, err := d.roles.Get(ctx, roleID)
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		role = &Role{ID: roleID}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get role %s: %w", roleID, err)
+	}
+
+	d.roleByID[roleID] = role
+	return role, nil
+}
+
+// License returns the license with licenseID, listing the licenses of orgID
+// on first use. An empty orgID means the caller's organization. A license
+// that does not exist is returned with only its ID set.
+func (d *Directory) License(ctx context.Context, orgID, licenseID string) (*License, error) {
+	if licenseID == "" {
+		return nil, core.InvalidParameter("Directory.License", "licenseID")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if license, ok := d.licenseByID[licenseID]; ok {
+		return license, nil
+	}
+
+	if !d.loadedOrgIDs[orgID] {
+		licenses, err := d.licenses.List(ctx, &LicenseListOptions{OrgID: orgID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list licenses: %w", err)
+		}
+		for _, license := range licenses {
+			d.licenseByID[license.ID] = license
+		}
+		d.loadedOrgIDs[orgID] = true
+
+		if license, ok := d.licenseByID[licenseID]; ok {
+			return license, nil
+		}
+	}
+
+	license, err := d.licenses.Get(ctx, licenseID)
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		license = &License{ID: licenseID}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get license %s: %w", licenseID, err)
+	}
+
+	d.licenseByID[licenseID] = license
+	return license, nil
+}
+
+// Reset drops the cached roles and licenses, for example after licenses
+// were purchased or roles changed.
+func (d *Directory) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clear(d.roleByID)
+	clear(d.licenseByID)
+	clear(d.loadedOrgIDs)
+	d.rolesLoaded = false
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+func newAdminTestSession(t *testing.T, routes map[string]any) (*core.RestSession, map[string]int) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	}), calls
+}
+
+func TestDirectoryExpandPerson(t *testing.T) {
+	session, calls := newAdminTestSession(t, map[string]any{
+		"/roles": map[string]any{"items": []*Role{
+			{ID: "r1", Name: "Full Administrator"},
+			{ID: "r2", Name: "Compliance Officer"},
+		}},
+		"/licenses": map[string]any{"items": []*License{
+			{ID: "l1", Name: "Messaging"},
+			{ID: "l2", Name: "Meeting - Webex Enterprise Edition"},
+		}},
+	})
+	directory := NewDirectory(NewRolesService(session), NewLicensesService(session))
+
+	people := []*messaging.Person{
+		{ID: "p1", Roles: []string{"r2"}, Licenses: []string{"l1", "l2"}},
+		{ID: "p2", Roles: []string{"r1", "gone"}, Licenses: []string{"l1"}},
+	}
+
+	var expanded []*PersonAccess
+	for _, person := range people {
+		access, err := directory.ExpandPerson(context.Background(), person)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expanded = append(expanded, access)
+	}
+
+	if names := expanded[0].LicenseNames(); !slices.Equal(names, []string{"Messaging", "Meeting - Webex Enterprise Edition"}) {
+		t.Errorf("unexpected license names %v", names)
+	}
+
+	if names := expanded[1].RoleNames(); !slices.Equal(names, []string{"Full Administrator", "gone"}) {
+		t.Errorf("expected a deleted role to fall back to its ID, got %v", names)
+	}
+
+	if calls["/roles"] != 1 || calls["/licenses"] != 1 || calls["/roles/gone"] != 1 {
+		t.Errorf("expected each list to be fetched once, got %v", calls)
+	}
+}
@@ -0,0 +1,12 @@
+// Package admin provides access to the Webex organization administration API.
+// It includes services for organizations, roles and licenses, and a directory
+// that resolves a person's role and license IDs to names.
+
+package admin
+
+// This file serves as the package documentation for the admin package.
+// All types and services are defined in their respective files:
+// - organizations.go: Organizations service.
+// - roles.go: Roles service.
+// - licenses.go: Licenses service and license utilization report.
+// - directory.go: Cached expansion of a person's roles and licenses.
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+type License struct {
+	ID                   string `json:"id,omitempty"`
+	Name                 string `json:"name,omitempty"`
+	TotalUnits           int    `json:"totalUnits,omitempty"`
+	ConsumedUnits        int    `json:"consumedUnits,omitempty"`
+	ConsumedByUsers      int    `json:"consumedByUsers,omitempty"`
+	ConsumedByWorkspaces int    `json:"consumedByWorkspaces,omitempty"`
+	SubscriptionID       string `json:"subscriptionId,omitempty"`
+	SiteURL              string `json:"siteUrl,omitempty"`
+	SiteType             string `json:"siteType,omitempty"`
+}
+
+type LicensesService struct {
+	session *core.RestSession
+}
+
+func NewLicensesService(session *core.RestSession) *LicensesService {
+	return &LicensesService{
+		session: session,
+	}
+}
+
+type LicenseListOptions struct {
+	// OrgID lists the licenses of this organization instead of the
+	// caller's own.
+	OrgID string
+}
+
+func (s *LicensesService) List(ctx context.Context, opts *LicenseListOptions) ([]*License, error) {
+	ctx = core.WithOperation(ctx, "admin.Licenses.List")
+
+	var response struct {
+		Items []*License `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "licenses", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+func (s *LicensesService) Get(ctx context.Context, licenseID string) (*License, error) {
+	ctx = core.WithOperation(ctx, "admin.Licenses.Get")
+
+	if licenseID == "" {
+		return nil, core.InvalidParameter("LicensesService.Get", "licenseID")
+	}
+
+	var license License
+	if err := s.session.Get(ctx, "licenses/"+licenseID, nil, &license); err != nil {
+		return nil, err
+	}
+
+	return &license, nil
+}
+
+func (opts *LicenseListOptions) params() url.Values {
+	params := url.Values{}
+	if opts != nil && opts.OrgID != "" {
+		params.Set("orgId", opts.OrgID)
+	}
+
+	return params
+}
+
+// LicenseUsage is one line of a license utilization report.
+type LicenseUsage struct {
+	License *License
+
+	// Consumed and Total are the assigned and purchased units.
+	Consumed int
+	Total    int
+}
+
+// Available returns the units that can still be assigned. It is negative
+// when the license is over-assigned.
+func (u *LicenseUsage) Available() int {
+	return u.Total - u.Consumed
+}
+
+// Utilization returns the consumed fraction of the license, or 0 for a
+// license without units.
+func (u *LicenseUsage) Utilization() float64 {
+	if u.Total == 0 {
+		return 0
+	}
+	return float64(u.Consumed) / float64(u.Total)
+}
+
+// Utilization reports consumed against total units for every license of the
+// organization, in the order the API lists them.
+func (s *LicensesService) Utilization(ctx context.Context, opts *LicenseListOptions) ([]*LicenseUsage, error) {
+	licenses, err := s.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]*LicenseUsage, 0, len(licenses))
+	for _, license := range licenses {
+		report = append(report, &LicenseUsage{
+			License:  license,
+			Consumed: license.ConsumedUnits,
+			Total:    license.TotalUnits,
+		})
+	}
+
+	return report, nil
+}
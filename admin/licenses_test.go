@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLicensesServiceUtilization(t *testing.T) {
+	session, _ := newAdminTestSession(t, map[string]any{
+		"/licenses": map[string]any{"items": []*License{
+			{ID: "l1", Name: "Messaging", TotalUnits: 200, ConsumedUnits: 150},
+			{ID: "l2", Name: "Calling", TotalUnits: 10, ConsumedUnits: 12},
+			{ID: "l3", Name: "Trial"},
+		}},
+	})
+
+	report, err := NewLicensesService(session).Utilization(context.Background(), &LicenseListOptions{OrgID: "org-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report) != 3 {
+		t.Fatalf("expected 3 licenses, got %d", len(report))
+	}
+
+	if usage := report[0]; usage.Available() != 50 || usage.Utilization() != 0.75 {
+		t.Errorf("unexpected usage for %s: available %d, utilization %v", usage.License.Name, usage.Available(), usage.Utilization())
+	}
+
+	if usage := report[1]; usage.Available() != -2 {
+		t.Errorf("expected an over-assigned license, got available %d", usage.Available())
+	}
+
+	if usage := report[2]; usage.Utilization() != 0 {
+		t.Errorf("expected no utilization without units, got %v", usage.Utilization())
+	}
+}
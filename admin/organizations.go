@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+type Organization struct {
+	ID          string    `json:"id,omitempty"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Created     time.Time `json:"created,omitempty"`
+}
+
+type OrganizationsService struct {
+	session *core.RestSession
+}
+
+func NewOrganizationsService(session *core.RestSession) *OrganizationsService {
+	return &OrganizationsService{
+		session: session,
+	}
+}
+
+// List returns the organizations the caller can administer.
+func (s *OrganizationsService) List(ctx context.Context) ([]*Organization, error) {
+	ctx = core.WithOperation(ctx, "admin.Organizations.List")
+
+	var response struct {
+		Items []*Organization `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "organizations", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+func (s *OrganizationsService) Get(ctx context.Context, orgID string) (*Organization, error) {
+	ctx = core.WithOperation(ctx, "admin.Organizations.Get")
+
+	if orgID == "" {
+		return nil, core.InvalidParameter("OrganizationsService.Get", "orgID")
+	}
+
+	var org Organization
+	if err := s.session.Get(ctx, "organizations/"+orgID, nil, &org); err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
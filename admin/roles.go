@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// Role is an administrator role that can be assigned to a person.
+type Role struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type RolesService struct {
+	session *core.RestSession
+}
+
+func NewRolesService(session *core.RestSession) *RolesService {
+	return &RolesService{
+		session: session,
+	}
+}
+
+func (s *RolesService) List(ctx context.Context) ([]*Role, error) {
+	ctx = core.WithOperation(ctx, "admin.Roles.List")
+
+	var response struct {
+		Items []*Role `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "roles", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+func (s *RolesService) Get(ctx context.Context, roleID string) (*Role, error) {
+	ctx = core.WithOperation(ctx, "admin.Roles.Get")
+
+	if roleID == "" {
+		return nil, core.InvalidParameter("RolesService.Get", "roleID")
+	}
+
+	var role Role
+	if err := s.session.Get(ctx, "roles/"+roleID, nil, &role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
@@ -0,0 +1,225 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+// DefaultConversationTTL is how long an idle conversation is kept unless
+// Options.ConversationTTL says otherwise.
+const DefaultConversationTTL = 24 * time.Hour
+
+type Options struct {
+	// Prefix, when set, must precede command names, for example "!". Slash
+	// style names such as "/deploy" are always accepted.
+	Prefix string
+
+	// DisableHelp disables the generated help command.
+	DisableHelp bool
+
+	// NotFound handles messages that match no command. When nil they are
+	// ignored.
+	NotFound HandlerFunc
+
+	// Webhook configures the handler used by ServeHTTP, for example the
+	// webhook secrets.
+	Webhook *webhook.Options
+
+	// ConversationTTL is how long the state of a room is kept after its last
+	// message. Defaults to DefaultConversationTTL; negative keeps it forever.
+	ConversationTTL time.Duration
+}
+
+// Bot receives messages:created notifications, fetches the message, strips
+// the bot mention and runs the matching command.
+type Bot struct {
+	client   *webexgosdk.Client
+	options  Options
+	handler  *webhook.Handler
+	commands []*Command
+	mws      []Middleware
+
+	me   *messaging.Person
+	meMu sync.Mutex
+
+	conversations map[string]*Conversation
+	convMu        sync.Mutex
+}
+
+func New(client *webexgosdk.Client, opts *Options) *Bot {
+	b := &Bot{
+		client:        client,
+		conversations: make(map[string]*Conversation),
+	}
+
+	if opts != nil {
+		b.options = *opts
+	}
+
+	if b.options.ConversationTTL == 0 {
+		b.options.ConversationTTL = DefaultConversationTTL
+	}
+
+	b.handler = webhook.NewHandler(b.options.Webhook)
+	b.Register(b.handler)
+
+	if !b.options.DisableHelp {
+		b.Command(&Command{
+			Name:        "help",
+			Description: "Show available commands",
+			Handler:     b.help,
+		})
+	}
+
+	return b
+}
+
+// Client returns the client the bot uses to call Webex.
+func (b *Bot) Client() *webexgosdk.Client {
+	return b.client
+}
+
+// Register routes messages:created notifications received by h to the bot,
+// for bots that share a webhook handler with other notifications.
+func (b *Bot) Register(h *webhook.Handler) {
+	h.OnMessageCreated(b.HandleMessage)
+}
+
+// ServeHTTP receives webhook notifications for the bot.
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.handler.ServeHTTP(w, r)
+}
+
+// Use adds middlewares that wrap every command. The first middleware is the
+// outermost.
+func (b *Bot) Use(middlewares ...Middleware) {
+	b.mws = append(b.mws, middlewares...)
+}
+
+// HandleMessage handles a messages:created notification. Messages sent by
+// the bot itself are ignored.
+func (b *Bot) HandleMessage(ctx context.Context, event *webhook.MessageCreatedEvent) error {
+	me, err := b.Me(ctx)
+	if err != nil {
+		return err
+	}
+
+	if event.Message.PersonID == me.ID {
+		return nil
+	}
+
+	message, err := b.client.Messaging.Messages.Get(ctx, event.Message.ID)
+	if err != nil {
+		return err
+	}
+
+	return b.Dispatch(ctx, message)
+}
+
+// Dispatch runs the command matching message.
+func (b *Bot) Dispatch(ctx context.Context, message *messaging.Message) error {
+	me, err := b.Me(ctx)
+	if err != nil {
+		return err
+	}
+
+	if message.PersonID == me.ID {
+		return nil
+	}
+
+	req := &Request{
+		Message:      message,
+		Text:         stripMention(message.Text, me),
+		Conversation: b.Conversation(message.RoomID),
+		bot:          b,
+	}
+
+	cmd := b.match(req)
+	if cmd == nil {
+		if b.options.NotFound == nil {
+			return nil
+		}
+		return b.chain(nil, b.options.NotFound)(ctx, req)
+	}
+
+	req.Command = cmd
+	return b.chain(cmd.Middlewares, cmd.Handler)(ctx, req)
+}
+
+func (b *Bot) chain(middlewares []Middleware, handler HandlerFunc) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	for i := len(b.mws) - 1; i >= 0; i-- {
+		handler = b.mws[i](handler)
+	}
+	return handler
+}
+
+// Me returns the bot's own person, fetched once with PeopleService.GetMe.
+func (b *Bot) Me(ctx context.Context) (*messaging.Person, error) {
+	b.meMu.Lock()
+	defer b.meMu.Unlock()
+
+	if b.me != nil {
+		return b.me, nil
+	}
+
+	me, err := b.client.Messaging.People.GetMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b.me = me
+	return me, nil
+}
+
+// Conversation returns the conversation state of a room. Conversations idle
+// for longer than ConversationTTL are dropped, so a room that has been quiet
+// for that long starts afresh.
+func (b *Bot) Conversation(roomID string) *Conversation {
+	now := time.Now()
+
+	b.convMu.Lock()
+	defer b.convMu.Unlock()
+
+	if ttl := b.options.ConversationTTL; ttl > 0 {
+		for id, conv := range b.conversations {
+			if now.Sub(conv.lastUsed) > ttl {
+				delete(b.conversations, id)
+			}
+		}
+	}
+
+	conv, ok := b.conversations[roomID]
+	if !ok {
+		conv = newConversation(roomID)
+		b.conversations[roomID] = conv
+	}
+	conv.lastUsed = now
+	return conv
+}
+
+// stripMention removes the bot's name that Webex puts in front of the text
+// of group room messages that mention the bot.
+func stripMention(text string, me *messaging.Person) string {
+	text = strings.TrimSpace(text)
+	for _, name := range []string{me.DisplayName, me.NickName, me.FirstName} {
+		if name == "" || len(text) < len(name) || !strings.EqualFold(text[:len(name)], name) {
+			continue
+		}
+
+		rest := text[len(name):]
+		if rest == "" || rest[0] == ' ' || rest[0] == ',' || rest[0] == ':' {
+			return strings.TrimSpace(strings.TrimLeft(rest, ",:"))
+		}
+	}
+	return text
+}
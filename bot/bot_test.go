@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+// fakeWebex serves the people/me, messages/{id} and messages endpoints.
+type fakeWebex struct {
+	mu       sync.Mutex
+	messages map[string]*messaging.Message
+	sent     []*messaging.MessageCreateRequest
+}
+
+func (f *fakeWebex) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/people/me":
+		json.NewEncoder(w).Encode(&messaging.Person{ID: "bot-1", DisplayName: "Deploy Bot"})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/messages/"):
+		message, ok := f.messages[strings.TrimPrefix(r.URL.Path, "/messages/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(message)
+	case r.Method == http.MethodPost && r.URL.Path == "/messages":
+		var req messaging.MessageCreateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.sent = append(f.sent, &req)
+		json.NewEncoder(w).Encode(&messaging.Message{ID: "reply", RoomID: req.RoomID, ParentID: req.ParentID})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestBot(t *testing.T, opts *Options, messages ...*messaging.Message) (*Bot, *fakeWebex) {
+	t.Helper()
+
+	fake := &fakeWebex{messages: make(map[string]*messaging.Message)}
+	for _, message := range messages {
+		fake.messages[message.ID] = message
+	}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := webexgosdk.NewClient("test-token", webexgosdk.ClientOptions{BaseURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return New(client, opts), fake
+}
+
+func notify(t *testing.T, b *Bot, messageID, personID string) {
+	t.Helper()
+
+	body := `{"resource":"messages","event":"created","data":{"id":"` + messageID + `","roomId":"room-1","personId":"` + personID + `"}}`
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestBotRoutesCommandAndRepliesInThread(t *testing.T) {
+	b, fake := newTestBot(t, nil,
+		&messaging.Message{ID: "m1", RoomID: "room-1", PersonID: "alice", Text: `Deploy Bot deploy api "release 2"`},
+		&messaging.Message{ID: "m2", RoomID: "room-1", PersonID: "alice", ParentID: "m1", Text: "/deploy web"},
+		&messaging.Message{ID: "m3", RoomID: "room-1", PersonID: "bot-1", Text: "deploy self"},
+	)
+
+	var args [][]string
+	b.Command(&Command{
+		Name:  "deploy",
+		Usage: "<service> [version]",
+		Handler: func(ctx context.Context, req *Request) error {
+			args = append(args, req.Args)
+			_, err := req.Reply(ctx, "deploying "+req.Arg(0))
+			return err
+		},
+	})
+
+	notify(t, b, "m1", "alice")
+	notify(t, b, "m2", "alice")
+	notify(t, b, "m3", "bot-1")
+
+	if len(args) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(args))
+	}
+
+	if len(args[0]) != 2 || args[0][0] != "api" || args[0][1] != "release 2" {
+		t.Errorf("unexpected args: %q", args[0])
+	}
+
+	if len(fake.sent) != 2 {
+		t.Fatalf("expected 2 replies, got %d", len(fake.sent))
+	}
+
+	for _, sent := range fake.sent {
+		if sent.RoomID != "room-1" || sent.ParentID != "m1" {
+			t.Errorf("expected reply in thread m1 of room-1, got %+v", sent)
+		}
+	}
+}
+
+func TestBotPrefixRegexpHelpAndConversation(t *testing.T) {
+	b, fake := newTestBot(t, &Options{Prefix: "!"},
+		&messaging.Message{ID: "m1", RoomID: "room-1", PersonID: "alice", Text: "status"},
+		&messaging.Message{ID: "m2", RoomID: "room-1", PersonID: "alice", Text: "ticket ABC-123 please"},
+		&messaging.Message{ID: "m3", RoomID: "room-1", PersonID: "alice", Text: "!help"},
+	)
+
+	b.Handle("status", "Show status", func(ctx context.Context, req *Request) error {
+		t.Error("command without prefix must not match")
+		return nil
+	})
+	b.HandleRegexp(regexp.MustCompile(`([A-Z]+-\d+)`), func(ctx context.Context, req *Request) error {
+		req.Conversation.Set("ticket", req.Matches[1])
+		return nil
+	})
+
+	notify(t, b, "m1", "alice")
+	notify(t, b, "m2", "alice")
+	notify(t, b, "m3", "alice")
+
+	if ticket, _ := b.Conversation("room-1").Get("ticket"); ticket != "ABC-123" {
+		t.Errorf("expected ticket ABC-123 in conversation, got %v", ticket)
+	}
+
+	if len(fake.sent) != 1 || !strings.Contains(fake.sent[0].Markdown, "`!status`: Show status") {
+		t.Errorf("expected help reply, got %+v", fake.sent)
+	}
+}
+
+func TestBotExpiresIdleConversations(t *testing.T) {
+	b, _ := newTestBot(t, &Options{ConversationTTL: 20 * time.Millisecond})
+
+	b.Conversation("room-1").Set("step", 1)
+	b.Conversation("room-2").Set("step", 1)
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := b.Conversation("room-2").Get("step"); ok {
+		t.Error("expected the idle conversation to start afresh")
+	}
+
+	b.convMu.Lock()
+	defer b.convMu.Unlock()
+	if _, ok := b.conversations["room-1"]; ok || len(b.conversations) != 1 {
+		t.Errorf("expected only room-2 to be kept, got %d conversations", len(b.conversations))
+	}
+}
+
+func TestAllowEmailDomains(t *testing.T) {
+	b, fake := newTestBot(t, nil,
+		&messaging.Message{ID: "m1", RoomID: "room-1", PersonID: "alice", PersonEmail: "alice@example.com", Text: "secret"},
+		&messaging.Message{ID: "m2", RoomID: "room-1", PersonID: "eve", PersonEmail: "eve@evil.test", Text: "secret"},
+	)
+
+	var ran []string
+	b.Command(&Command{
+		Name:        "secret",
+		Middlewares: []Middleware{AllowEmailDomains("Example.com")},
+		Handler: func(ctx context.Context, req *Request) error {
+			ran = append(ran, req.Message.PersonID)
+			return nil
+		},
+	})
+
+	notify(t, b, "m1", "alice")
+	notify(t, b, "m2", "eve")
+
+	if len(ran) != 1 || ran[0] != "alice" {
+		t.Errorf("expected only alice to run the command, got %v", ran)
+	}
+
+	if len(fake.sent) != 1 || fake.sent[0].Text != DefaultUnauthorizedReply {
+		t.Errorf("expected unauthorized reply, got %+v", fake.sent)
+	}
+}
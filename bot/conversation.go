@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+// Conversation holds state for a room across messages, for example the step
+// of a multi-message dialog. It is safe for concurrent use.
+type Conversation struct {
+	RoomID string
+
+	values map[string]any
+	mu     sync.Mutex
+
+	// lastUsed is guarded by the bot's conversation lock.
+	lastUsed time.Time
+}
+
+func newConversation(roomID string) *Conversation {
+	return &Conversation{
+		RoomID: roomID,
+		values: make(map[string]any),
+	}
+}
+
+func (c *Conversation) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *Conversation) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = value
+}
+
+func (c *Conversation) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.values, key)
+}
+
+// Clear removes all state of the conversation.
+func (c *Conversation) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.values)
+}
@@ -0,0 +1,14 @@
+// Package bot builds Webex bots on top of messages webhooks.
+// It includes a command router with prefix, slash-style and regular expression
+// matching, argument parsing, generated help, self-message filtering,
+// per-room conversation state, threaded reply helpers and middleware.
+
+package bot
+
+// This file serves as the package documentation for the bot package.
+// All types are defined in their respective files:
+// - bot.go: Bot, options and message handling.
+// - router.go: Commands, matching and help generation.
+// - request.go: Request, argument parsing and reply helpers.
+// - conversation.go: Per-room conversation state.
+// - middleware.go: Middleware and authorization by email domain.
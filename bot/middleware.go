@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"context"
+	"strings"
+)
+
+// Middleware wraps a command handler.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// DefaultUnauthorizedReply is sent by AllowEmailDomains to rejected senders.
+const DefaultUnauthorizedReply = "Sorry, you are not allowed to use this command."
+
+// AllowEmailDomains only lets senders whose email address is in one of
+// domains run commands. Other senders get DefaultUnauthorizedReply.
+func AllowEmailDomains(domains ...string) Middleware {
+	allowed := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		allowed[strings.ToLower(strings.TrimPrefix(domain, "@"))] = true
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) error {
+			_, domain, ok := strings.Cut(req.Message.PersonEmail, "@")
+			if !ok || !allowed[strings.ToLower(domain)] {
+				_, err := req.Reply(ctx, DefaultUnauthorizedReply)
+				return err
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+// Request is a message routed to a command.
+type Request struct {
+	// Message is the full message that triggered the command.
+	Message *messaging.Message
+
+	// Text is the message text with the bot mention removed.
+	Text string
+
+	// Command is the matched command; nil for NotFound.
+	Command *Command
+
+	// Args are the arguments following the command name. Quoted arguments
+	// may contain spaces. For pattern commands Args splits the whole text.
+	Args []string
+
+	// Matches are the submatches of a pattern command.
+	Matches []string
+
+	// Conversation is the state of the message's room.
+	Conversation *Conversation
+
+	bot *Bot
+}
+
+// Bot returns the bot handling the request.
+func (r *Request) Bot() *Bot {
+	return r.bot
+}
+
+// Arg returns the i-th argument, or "" when there are fewer arguments.
+func (r *Request) Arg(i int) string {
+	if i < 0 || i >= len(r.Args) {
+		return ""
+	}
+	return r.Args[i]
+}
+
+// Reply posts text in the thread of the request message.
+func (r *Request) Reply(ctx context.Context, text string) (*messaging.Message, error) {
+	return r.Send(ctx, &messaging.MessageCreateRequest{Text: text})
+}
+
+// ReplyMarkdown posts markdown in the thread of the request message.
+func (r *Request) ReplyMarkdown(ctx context.Context, markdown string) (*messaging.Message, error) {
+	return r.Send(ctx, &messaging.MessageCreateRequest{Markdown: markdown})
+}
+
+// Send posts msg in the thread of the request message. RoomID and ParentID
+// default to the request's room and thread.
+func (r *Request) Send(ctx context.Context, msg *messaging.MessageCreateRequest) (*messaging.Message, error) {
+	reply := *msg
+	if reply.RoomID == "" && reply.ToPersonID == "" && reply.ToPersonEmail == "" {
+		reply.RoomID = r.Message.RoomID
+	}
+
+	if reply.ParentID == "" && reply.RoomID == r.Message.RoomID {
+		reply.ParentID = r.threadID()
+	}
+
+	return r.bot.client.Messaging.Messages.Create(ctx, &reply)
+}
+
+// threadID returns the ID of the thread's root message. Webex threads are
+// one level deep, so replies to a reply go to its parent.
+func (r *Request) threadID() string {
+	if r.Message.ParentID != "" {
+		return r.Message.ParentID
+	}
+	return r.Message.ID
+}
+
+// splitArgs splits s on white space. Single or double quotes group words
+// into one argument.
+func splitArgs(s string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		quote   rune
+		inArg   bool
+	)
+
+	for _, c := range s {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote = c
+			inArg = true
+		case unicode.IsSpace(c):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
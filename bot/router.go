@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// HandlerFunc handles a command. A returned error is reported to the webhook
+// handler, which answers 500 so that Webex redelivers the notification.
+type HandlerFunc func(ctx context.Context, req *Request) error
+
+// Command is a bot command. It matches either by Name and Aliases, against
+// the first word of the message, or by Pattern, against the whole message.
+type Command struct {
+	Name    string
+	Aliases []string
+
+	// Pattern matches the whole message text. Its submatches are available
+	// in Request.Matches.
+	Pattern *regexp.Regexp
+
+	// Usage describes the arguments, for example "<service> [version]".
+	Usage       string
+	Description string
+
+	// Hidden leaves the command out of the generated help.
+	Hidden bool
+
+	// Middlewares wrap this command only, inside the bot's middlewares.
+	Middlewares []Middleware
+
+	Handler HandlerFunc
+}
+
+// Command registers cmd. Commands are matched in registration order.
+func (b *Bot) Command(cmd *Command) {
+	if cmd == nil || cmd.Handler == nil || (cmd.Name == "" && cmd.Pattern == nil) {
+		panic("bot: command requires a handler and a name or pattern")
+	}
+
+	b.commands = append(b.commands, cmd)
+}
+
+// Handle registers handler for the command name.
+func (b *Bot) Handle(name, description string, handler HandlerFunc) {
+	b.Command(&Command{Name: name, Description: description, Handler: handler})
+}
+
+// HandleRegexp registers handler for messages matching pattern.
+func (b *Bot) HandleRegexp(pattern *regexp.Regexp, handler HandlerFunc) {
+	b.Command(&Command{Pattern: pattern, Handler: handler})
+}
+
+// match finds the command for req and fills in its arguments.
+func (b *Bot) match(req *Request) *Command {
+	name, rest, hasName := b.splitCommand(req.Text)
+
+	for _, cmd := range b.commands {
+		if cmd.Pattern != nil {
+			if matches := cmd.Pattern.FindStringSubmatch(req.Text); matches != nil {
+				req.Matches = matches
+				req.Args = splitArgs(req.Text)
+				return cmd
+			}
+			continue
+		}
+
+		if hasName && cmd.matchesName(name) {
+			req.Args = splitArgs(rest)
+			return cmd
+		}
+	}
+	return nil
+}
+
+// splitCommand splits text into the command name and the remaining text. A
+// configured prefix and a leading slash are removed from the name.
+func (b *Bot) splitCommand(text string) (name, rest string, ok bool) {
+	if b.options.Prefix != "" && !strings.HasPrefix(text, "/") {
+		if !strings.HasPrefix(text, b.options.Prefix) {
+			return "", "", false
+		}
+		text = text[len(b.options.Prefix):]
+	}
+
+	text = strings.TrimPrefix(text, "/")
+	name, rest, _ = strings.Cut(strings.TrimSpace(text), " ")
+	if name == "" {
+		return "", "", false
+	}
+	return name, strings.TrimSpace(rest), true
+}
+
+func (c *Command) matchesName(name string) bool {
+	if c.Name == "" {
+		return false
+	}
+
+	if strings.EqualFold(c.Name, name) {
+		return true
+	}
+
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Help returns the generated help text in markdown.
+func (b *Bot) Help() string {
+	var sb strings.Builder
+	sb.WriteString("Available commands:\n")
+
+	for _, cmd := range b.commands {
+		if cmd.Hidden || cmd.Name == "" {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "- `%s%s", b.options.Prefix, cmd.Name)
+		if cmd.Usage != "" {
+			sb.WriteString(" " + cmd.Usage)
+		}
+		sb.WriteString("`")
+
+		if cmd.Description != "" {
+			sb.WriteString(": " + cmd.Description)
+		}
+
+		if len(cmd.Aliases) > 0 {
+			fmt.Fprintf(&sb, " (aliases: %s)", strings.Join(cmd.Aliases, ", "))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (b *Bot) help(ctx context.Context, req *Request) error {
+	_, err := req.ReplyMarkdown(ctx, b.Help())
+	return err
+}
@@ -2,6 +2,7 @@ package calling
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -10,28 +11,71 @@ import (
 )
 
 type CallHistoryRecord struct {
-	ID              string      `json:"id,omitempty"`
-	Name            string      `json:"name,omitempty"`
-	Number          string      `json:"number,omitempty"`
-	Type            string      `json:"type,omitempty"`
-	Direction       string      `json:"direction,omitempty"`
-	Duration        int         `json:"duration,omitempty"`
-	StartTime       time.Time   `json:"startTime,omitempty"`
-	AnswerTime      time.Time   `json:"answerTime,omitempty"`
-	EndTime         time.Time   `json:"endTime,omitempty"`
-	IsCallback      bool        `json:"isCallback,omitempty"`
-	CallbackNumber  string      `json:"callbackNumber,omitempty"`
-	CallSessionID   string      `json:"callSessionId,omitempty"`
-	LocalCallID     string      `json:"localCallId,omitempty"`
-	RemoteCallID    string      `json:"remoteCallId,omitempty"`
-	UserType        string      `json:"userType,omitempty"`
-	UserID          string      `json:"userId,omitempty"`
-	OrgID           string      `json:"orgId,omitempty"`
-	IsAnswered      bool        `json:"isAnswered,omitempty"`
-	IsInternational bool        `json:"isInternational,omitempty"`
-	OriginalReason  *CallReason `json:"originalReason,omitempty"`
-	RedirectReason  *CallReason `json:"redirectReason,omitempty"`
-	ReleasedParty   string      `json:"releasedParty,omitempty"`
+	ID              string          `json:"id,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	Number          string          `json:"number,omitempty"`
+	Type            CallHistoryType `json:"type,omitempty"`
+	Direction       CallDirection   `json:"direction,omitempty"`
+	Duration        int             `json:"duration,omitempty"`
+	StartTime       time.Time       `json:"startTime,omitempty"`
+	AnswerTime      time.Time       `json:"answerTime,omitempty"`
+	EndTime         time.Time       `json:"endTime,omitempty"`
+	IsCallback      bool            `json:"isCallback,omitempty"`
+	CallbackNumber  string          `json:"callbackNumber,omitempty"`
+	CallSessionID   string          `json:"callSessionId,omitempty"`
+	LocalCallID     string          `json:"localCallId,omitempty"`
+	RemoteCallID    string          `json:"remoteCallId,omitempty"`
+	UserType        string          `json:"userType,omitempty"`
+	UserID          string          `json:"userId,omitempty"`
+	OrgID           string          `json:"orgId,omitempty"`
+	IsAnswered      bool            `json:"isAnswered,omitempty"`
+	IsInternational bool            `json:"isInternational,omitempty"`
+	OriginalReason  *CallReason     `json:"originalReason,omitempty"`
+	RedirectReason  *CallReason     `json:"redirectReason,omitempty"`
+	ReleasedParty   string          `json:"releasedParty,omitempty"`
+}
+
+// CallHistoryType is the kind of a call history record.
+type CallHistoryType string
+
+const (
+	CallHistoryTypePlaced   CallHistoryType = "placed"
+	CallHistoryTypeMissed   CallHistoryType = "missed"
+	CallHistoryTypeReceived CallHistoryType = "received"
+)
+
+// IsValid reports whether t is a known call history type.
+func (t CallHistoryType) IsValid() bool {
+	switch t {
+	case CallHistoryTypePlaced, CallHistoryTypeMissed, CallHistoryTypeReceived:
+		return true
+	}
+	return false
+}
+
+func (t CallHistoryType) String() string {
+	return string(t)
+}
+
+// CallDirection is the direction of a call relative to the user.
+type CallDirection string
+
+const (
+	CallDirectionIncoming CallDirection = "incoming"
+	CallDirectionOutgoing CallDirection = "outgoing"
+)
+
+// IsValid reports whether d is a known call direction.
+func (d CallDirection) IsValid() bool {
+	switch d {
+	case CallDirectionIncoming, CallDirectionOutgoing:
+		return true
+	}
+	return false
+}
+
+func (d CallDirection) String() string {
+	return string(d)
 }
 
 type CallReason struct {
@@ -50,35 +94,71 @@ func NewCallHistoryService(session *core.RestSession) *CallHistoryService {
 }
 
 type CallHistoryListOptions struct {
-	Type string
+	Type CallHistoryType
 	Max  int
 }
 
 func (s *CallHistoryService) List(ctx context.Context, opts *CallHistoryListOptions) ([]*CallHistoryRecord, error) {
-	params := url.Values{}
-	if opts != nil {
-		if opts.Type != "" {
-			params.Set("type", opts.Type)
-		}
-		if opts.Max > 0 {
-			params.Set("max", strconv.Itoa(opts.Max))
-		}
+	ctx = core.WithOperation(ctx, "calling.CallHistory.List")
+
+	if err := opts.validate("CallHistoryService.List"); err != nil {
+		return nil, err
 	}
 
 	var response struct {
 		Items []*CallHistoryRecord `json:"items"`
 	}
 
-	if err := s.session.Get(ctx, "telephony/calls/history", params, &response); err != nil {
+	if err := s.session.Get(ctx, "telephony/calls/history", opts.params(), &response); err != nil {
 		return nil, err
 	}
 
 	return response.Items, nil
 }
 
+// ListPages returns a pager over the caller's call history.
+func (s *CallHistoryService) ListPages(opts *CallHistoryListOptions) (*core.Pager[CallHistoryRecord], error) {
+	if err := opts.validate("CallHistoryService.ListPages"); err != nil {
+		return nil, err
+	}
+
+	return core.NewPager[CallHistoryRecord](s.session, "calling.CallHistory.ListPages", "telephony/calls/history", opts.params()), nil
+}
+
+// ListAll iterates over the caller's call history, following pagination links.
+func (s *CallHistoryService) ListAll(ctx context.Context, opts *CallHistoryListOptions) iter.Seq2[*CallHistoryRecord, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[CallHistoryRecord](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *CallHistoryListOptions) validate(method string) error {
+	if opts != nil && opts.Type != "" && !opts.Type.IsValid() {
+		return core.InvalidParameter(method, "opts.Type")
+	}
+	return nil
+}
+
+func (opts *CallHistoryListOptions) params() url.Values {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Type != "" {
+			params.Set("type", string(opts.Type))
+		}
+		if opts.Max > 0 {
+			params.Set("max", strconv.Itoa(opts.Max))
+		}
+	}
+
+	return params
+}
+
 func (s *CallHistoryService) ListPlacedCalls(ctx context.Context, max int) ([]*CallHistoryRecord, error) {
 	opts := &CallHistoryListOptions{
-		Type: "placed",
+		Type: CallHistoryTypePlaced,
 	}
 
 	if max > 0 {
@@ -90,7 +170,7 @@ func (s *CallHistoryService) ListPlacedCalls(ctx context.Context, max int) ([]*C
 
 func (s *CallHistoryService) ListMissedCalls(ctx context.Context, max int) ([]*CallHistoryRecord, error) {
 	opts := &CallHistoryListOptions{
-		Type: "missed",
+		Type: CallHistoryTypeMissed,
 	}
 
 	if max > 0 {
@@ -102,7 +182,7 @@ func (s *CallHistoryService) ListMissedCalls(ctx context.Context, max int) ([]*C
 
 func (s *CallHistoryService) ListReceivedCalls(ctx context.Context, max int) ([]*CallHistoryRecord, error) {
 	opts := &CallHistoryListOptions{
-		Type: "received",
+		Type: CallHistoryTypeReceived,
 	}
 
 	if max > 0 {
@@ -113,5 +193,7 @@ func (s *CallHistoryService) ListReceivedCalls(ctx context.Context, max int) ([]
 }
 
 func (s *CallHistoryService) DeleteAllHistory(ctx context.Context) error {
+	ctx = core.WithOperation(ctx, "calling.CallHistory.DeleteAllHistory")
+
 	return s.session.Delete(ctx, "telephony/calls/history")
 }
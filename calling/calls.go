@@ -8,19 +8,66 @@ import (
 )
 
 type Call struct {
-	ID             string       `json:"id,omitempty"`
-	CallID         string       `json:"callId,omitempty"`
-	CallSessionID  string       `json:"callSessionId,omitempty"`
-	Personality    string       `json:"personality,omitempty"`
-	State          string       `json:"state,omitempty"`
-	RemoteParty    *RemoteParty `json:"remoteParty,omitempty"`
-	Appearance     int          `json:"appearance,omitempty"`
-	Created        time.Time    `json:"created,omitempty"`
-	Connected      time.Time    `json:"connected,omitempty"`
-	Duration       int          `json:"duration,omitempty"`
-	Held           bool         `json:"held,omitempty"`
-	RedirectReason string       `json:"redirectReason,omitempty"`
-	RecordingState string       `json:"recordingState,omitempty"`
+	ID             string          `json:"id,omitempty"`
+	CallID         string          `json:"callId,omitempty"`
+	CallSessionID  string          `json:"callSessionId,omitempty"`
+	Personality    CallPersonality `json:"personality,omitempty"`
+	State          CallState       `json:"state,omitempty"`
+	RemoteParty    *RemoteParty    `json:"remoteParty,omitempty"`
+	Appearance     int             `json:"appearance,omitempty"`
+	Created        time.Time       `json:"created,omitempty"`
+	Connected      time.Time       `json:"connected,omitempty"`
+	Duration       int             `json:"duration,omitempty"`
+	Held           bool            `json:"held,omitempty"`
+	RedirectReason string          `json:"redirectReason,omitempty"`
+	RecordingState string          `json:"recordingState,omitempty"`
+}
+
+// CallState is the state of an active call.
+type CallState string
+
+const (
+	CallStateConnecting   CallState = "connecting"
+	CallStateAlerting     CallState = "alerting"
+	CallStateConnected    CallState = "connected"
+	CallStateHeld         CallState = "held"
+	CallStateRemoteHeld   CallState = "remoteHeld"
+	CallStateDisconnected CallState = "disconnected"
+)
+
+// IsValid reports whether s is a known call state.
+func (s CallState) IsValid() bool {
+	switch s {
+	case CallStateConnecting, CallStateAlerting, CallStateConnected, CallStateHeld, CallStateRemoteHeld, CallStateDisconnected:
+		return true
+	}
+	return false
+}
+
+func (s CallState) String() string {
+	return string(s)
+}
+
+// CallPersonality is the user's role in a call.
+type CallPersonality string
+
+const (
+	CallPersonalityOriginator  CallPersonality = "originator"
+	CallPersonalityTerminator  CallPersonality = "terminator"
+	CallPersonalityClickToDial CallPersonality = "clickToDial"
+)
+
+// IsValid reports whether p is a known call personality.
+func (p CallPersonality) IsValid() bool {
+	switch p {
+	case CallPersonalityOriginator, CallPersonalityTerminator, CallPersonalityClickToDial:
+		return true
+	}
+	return false
+}
+
+func (p CallPersonality) String() string {
+	return string(p)
 }
 
 type RemoteParty struct {
@@ -52,8 +99,10 @@ type DialResponse struct {
 }
 
 func (s *CallsService) Dial(ctx context.Context, req *DialRequest) (*DialResponse, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.Dial")
+
 	if req == nil || req.Destination == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("CallsService.Dial", "req.Destination")
 	}
 
 	var response DialResponse
@@ -69,8 +118,10 @@ type CallIDRequest struct {
 }
 
 func (s *CallsService) Answer(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Answer")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Answer", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -78,8 +129,10 @@ func (s *CallsService) Answer(ctx context.Context, callID string) error {
 }
 
 func (s *CallsService) Reject(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Reject")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Reject", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -87,8 +140,10 @@ func (s *CallsService) Reject(ctx context.Context, callID string) error {
 }
 
 func (s *CallsService) Hold(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Hold")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Hold", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -96,8 +151,10 @@ func (s *CallsService) Hold(ctx context.Context, callID string) error {
 }
 
 func (s *CallsService) Resume(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Resume")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Resume", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -105,8 +162,10 @@ func (s *CallsService) Resume(ctx context.Context, callID string) error {
 }
 
 func (s *CallsService) Hangup(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Hangup")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Hangup", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -121,8 +180,13 @@ type TransferRequest struct {
 }
 
 func (s *CallsService) Transfer(ctx context.Context, callID string, destination string) error {
-	if callID == "" || destination == "" {
-		return core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "calling.Calls.Transfer")
+
+	switch {
+	case callID == "":
+		return core.InvalidParameter("CallsService.Transfer", "callID")
+	case destination == "":
+		return core.InvalidParameter("CallsService.Transfer", "destination")
 	}
 
 	req := &TransferRequest{CallID: callID, Destination: destination}
@@ -130,8 +194,13 @@ func (s *CallsService) Transfer(ctx context.Context, callID string, destination
 }
 
 func (s *CallsService) ConsultTransfer(ctx context.Context, callID1, callID2 string) error {
-	if callID1 == "" || callID2 == "" {
-		return core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "calling.Calls.ConsultTransfer")
+
+	switch {
+	case callID1 == "":
+		return core.InvalidParameter("CallsService.ConsultTransfer", "callID1")
+	case callID2 == "":
+		return core.InvalidParameter("CallsService.ConsultTransfer", "callID2")
 	}
 
 	req := &TransferRequest{CallID1: callID1, CallID2: callID2}
@@ -145,12 +214,14 @@ type DivertRequest struct {
 }
 
 func (s *CallsService) Divert(ctx context.Context, callID string, destination string, toVoicemail bool) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Divert")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Divert", "callID")
 	}
 
 	if !toVoicemail && destination == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Divert", "destination")
 	}
 
 	req := &DivertRequest{CallID: callID, Destination: destination, ToVoicemail: toVoicemail}
@@ -168,8 +239,10 @@ type ParkResponse struct {
 }
 
 func (s *CallsService) Park(ctx context.Context, callID string, destination string) (*ParkResponse, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.Park")
+
 	if callID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("CallsService.Park", "callID")
 	}
 
 	req := &ParkRequest{
@@ -195,8 +268,10 @@ type RetrieveResponse struct {
 }
 
 func (s *CallsService) Retrieve(ctx context.Context, destination string) (*RetrieveResponse, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.Retrieve")
+
 	if destination == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("CallsService.Retrieve", "destination")
 	}
 
 	req := &RetrieveRequest{Destination: destination}
@@ -218,8 +293,10 @@ type PickupResponse struct {
 }
 
 func (s *CallsService) Pickup(ctx context.Context, target string) (*PickupResponse, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.Pickup")
+
 	if target == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("CallsService.Pickup", "target")
 	}
 
 	req := &PickupRequest{Target: target}
@@ -241,8 +318,10 @@ type BargeInResponse struct {
 }
 
 func (s *CallsService) BargeIn(ctx context.Context, target string) (*BargeInResponse, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.BargeIn")
+
 	if target == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("CallsService.BargeIn", "target")
 	}
 
 	req := &BargeInRequest{Target: target}
@@ -255,8 +334,10 @@ func (s *CallsService) BargeIn(ctx context.Context, target string) (*BargeInResp
 }
 
 func (s *CallsService) StartRecording(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.StartRecording")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.StartRecording", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -264,8 +345,10 @@ func (s *CallsService) StartRecording(ctx context.Context, callID string) error
 }
 
 func (s *CallsService) StopRecording(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.StopRecording")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.StopRecording", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -273,8 +356,10 @@ func (s *CallsService) StopRecording(ctx context.Context, callID string) error {
 }
 
 func (s *CallsService) PauseRecording(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.PauseRecording")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.PauseRecording", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -282,8 +367,10 @@ func (s *CallsService) PauseRecording(ctx context.Context, callID string) error
 }
 
 func (s *CallsService) ResumeRecording(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.ResumeRecording")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.ResumeRecording", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -296,8 +383,13 @@ type TransmitDTMFRequest struct {
 }
 
 func (s *CallsService) TransmitDTMF(ctx context.Context, callID, dtmf string) error {
-	if callID == "" || dtmf == "" {
-		return core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "calling.Calls.TransmitDTMF")
+
+	switch {
+	case callID == "":
+		return core.InvalidParameter("CallsService.TransmitDTMF", "callID")
+	case dtmf == "":
+		return core.InvalidParameter("CallsService.TransmitDTMF", "dtmf")
 	}
 
 	req := &TransmitDTMFRequest{CallID: callID, DTMF: dtmf}
@@ -305,8 +397,10 @@ func (s *CallsService) TransmitDTMF(ctx context.Context, callID, dtmf string) er
 }
 
 func (s *CallsService) Push(ctx context.Context, callID string) error {
+	ctx = core.WithOperation(ctx, "calling.Calls.Push")
+
 	if callID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("CallsService.Push", "callID")
 	}
 
 	req := &CallIDRequest{CallID: callID}
@@ -314,6 +408,8 @@ func (s *CallsService) Push(ctx context.Context, callID string) error {
 }
 
 func (s *CallsService) ListCalls(ctx context.Context) ([]*Call, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.ListCalls")
+
 	var response struct {
 		Items []*Call `json:"items"`
 	}
@@ -325,8 +421,10 @@ func (s *CallsService) ListCalls(ctx context.Context) ([]*Call, error) {
 }
 
 func (s *CallsService) GetCallDetails(ctx context.Context, callID string) (*Call, error) {
+	ctx = core.WithOperation(ctx, "calling.Calls.GetCallDetails")
+
 	if callID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("CallsService.GetCallDetails", "callID")
 	}
 
 	var response Call
@@ -2,6 +2,7 @@ package calling
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -51,6 +52,33 @@ type VoicemailListOptions struct {
 }
 
 func (s *VoicemailService) List(ctx context.Context, opts *VoicemailListOptions) ([]*VoiceMessage, error) {
+	ctx = core.WithOperation(ctx, "calling.Voicemail.List")
+
+	var response struct {
+		Items []*VoiceMessage `json:"items"`
+	}
+	if err := s.session.Get(ctx, "telephony/voiceMessages", opts.params(), &response); err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// ListPages returns a pager over the caller's voicemail messages.
+func (s *VoicemailService) ListPages(opts *VoicemailListOptions) (*core.Pager[VoiceMessage], error) {
+	return core.NewPager[VoiceMessage](s.session, "calling.Voicemail.ListPages", "telephony/voiceMessages", opts.params()), nil
+}
+
+// ListAll iterates over the caller's voicemail messages, following pagination links.
+func (s *VoicemailService) ListAll(ctx context.Context, opts *VoicemailListOptions) iter.Seq2[*VoiceMessage, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[VoiceMessage](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *VoicemailListOptions) params() url.Values {
 	params := url.Values{}
 
 	if opts != nil {
@@ -59,16 +87,12 @@ func (s *VoicemailService) List(ctx context.Context, opts *VoicemailListOptions)
 		}
 	}
 
-	var response struct {
-		Items []*VoiceMessage `json:"items"`
-	}
-	if err := s.session.Get(ctx, "telephony/voiceMessages", params, &response); err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+	return params
 }
 
 func (s *VoicemailService) GetSummary(ctx context.Context) (*VoiceMessageSummary, error) {
+	ctx = core.WithOperation(ctx, "calling.Voicemail.GetSummary")
+
 	var summary VoiceMessageSummary
 	if err := s.session.Get(ctx, "telephony/voiceMessages/summary", nil, &summary); err != nil {
 		return nil, err
@@ -77,8 +101,10 @@ func (s *VoicemailService) GetSummary(ctx context.Context) (*VoiceMessageSummary
 }
 
 func (s *VoicemailService) MarkAsRead(ctx context.Context, messageID string) error {
+	ctx = core.WithOperation(ctx, "calling.Voicemail.MarkAsRead")
+
 	if messageID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("VoicemailService.MarkAsRead", "messageID")
 	}
 
 	req := map[string]bool{"read": true}
@@ -86,16 +112,20 @@ func (s *VoicemailService) MarkAsRead(ctx context.Context, messageID string) err
 }
 
 func (s *VoicemailService) MarkAsUnread(ctx context.Context, messageID string) error {
+	ctx = core.WithOperation(ctx, "calling.Voicemail.MarkAsUnread")
+
 	if messageID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("VoicemailService.MarkAsUnread", "messageID")
 	}
 	req := map[string]bool{"read": false}
 	return s.session.Put(ctx, "telephony/voiceMessages/"+messageID, req, nil)
 }
 
 func (s *VoicemailService) Delete(ctx context.Context, messageID string) error {
+	ctx = core.WithOperation(ctx, "calling.Voicemail.Delete")
+
 	if messageID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("VoicemailService.Delete", "messageID")
 	}
 	return s.session.Delete(ctx, "telephony/voiceMessages/"+messageID)
 }
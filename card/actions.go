@@ -0,0 +1,42 @@
+package card
+
+// Action is a card action such as Submit or OpenURL.
+type Action interface {
+	validate(v *validator)
+}
+
+// Submit sends the card inputs, merged with Data, as an attachment action.
+type Submit struct {
+	Title string `json:"title,omitempty"`
+	Data  any    `json:"data,omitempty"`
+	Style string `json:"style,omitempty"`
+}
+
+func (a Submit) MarshalJSON() ([]byte, error) {
+	type alias Submit
+	return marshalTyped("Action.Submit", alias(a))
+}
+
+func (a Submit) validate(v *validator) {
+	if _, ok := a.Data.(string); ok {
+		v.fail("Action.Submit data must be an object to be merged with the inputs")
+	}
+}
+
+// OpenURL opens URL in the browser.
+type OpenURL struct {
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+	Style string `json:"style,omitempty"`
+}
+
+func (a OpenURL) MarshalJSON() ([]byte, error) {
+	type alias OpenURL
+	return marshalTyped("Action.OpenUrl", alias(a))
+}
+
+func (a OpenURL) validate(v *validator) {
+	if a.URL == "" {
+		v.fail("Action.OpenUrl requires a url")
+	}
+}
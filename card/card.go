@@ -0,0 +1,157 @@
+package card
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+const (
+	// ContentType is the attachment content type of Adaptive Cards.
+	ContentType = "application/vnd.microsoft.card.adaptive"
+
+	Schema = "http://adaptivecards.io/schemas/adaptive-card.json"
+
+	// DefaultVersion is the newest schema version Webex renders.
+	DefaultVersion = "1.3"
+)
+
+var (
+	ErrInvalidCard = errors.New("invalid adaptive card")
+)
+
+// SupportedVersions lists the schema versions Webex renders.
+var SupportedVersions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+// Card is an Adaptive Card. Build it with New, Add and AddActions and send it
+// with Attachment.
+type Card struct {
+	Version      string
+	Body         []Element
+	Actions      []Action
+	FallbackText string
+	Speak        string
+}
+
+// New creates a card for the schema version, or DefaultVersion when version
+// is empty.
+func New(version string) *Card {
+	if version == "" {
+		version = DefaultVersion
+	}
+	return &Card{Version: version}
+}
+
+// Add appends elements to the card body.
+func (c *Card) Add(elements ...Element) *Card {
+	c.Body = append(c.Body, elements...)
+	return c
+}
+
+// AddActions appends actions shown at the bottom of the card.
+func (c *Card) AddActions(actions ...Action) *Card {
+	c.Actions = append(c.Actions, actions...)
+	return c
+}
+
+func (c *Card) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type         string    `json:"type"`
+		Schema       string    `json:"$schema"`
+		Version      string    `json:"version"`
+		Body         []Element `json:"body,omitempty"`
+		Actions      []Action  `json:"actions,omitempty"`
+		FallbackText string    `json:"fallbackText,omitempty"`
+		Speak        string    `json:"speak,omitempty"`
+	}{"AdaptiveCard", Schema, c.Version, c.Body, c.Actions, c.FallbackText, c.Speak})
+}
+
+// Validate checks that the schema version is supported, that every element
+// and action is available in that version and has its required fields, and
+// that input IDs are unique.
+func (c *Card) Validate() error {
+	minor, ok := parseVersion(c.Version)
+	if !ok {
+		return fmt.Errorf("%w: unsupported version %q, expected one of %s", ErrInvalidCard, c.Version, strings.Join(SupportedVersions, ", "))
+	}
+
+	v := &validator{minor: minor, ids: make(map[string]bool)}
+	for _, element := range c.Body {
+		element.validate(v)
+	}
+	for _, action := range c.Actions {
+		action.validate(v)
+	}
+
+	return v.err
+}
+
+// Attachment validates the card and wraps it in a message attachment.
+func (c *Card) Attachment() (messaging.Attachment, error) {
+	if err := c.Validate(); err != nil {
+		return messaging.Attachment{}, err
+	}
+
+	return messaging.Attachment{ContentType: ContentType, Content: c}, nil
+}
+
+func parseVersion(version string) (int, bool) {
+	for _, supported := range SupportedVersions {
+		if version == supported {
+			minor, err := strconv.Atoi(strings.TrimPrefix(version, "1."))
+			return minor, err == nil
+		}
+	}
+	return 0, false
+}
+
+// validator collects the first validation error of a card.
+type validator struct {
+	minor int
+	ids   map[string]bool
+	err   error
+}
+
+func (v *validator) fail(format string, args ...any) {
+	if v.err == nil {
+		v.err = fmt.Errorf("%w: %s", ErrInvalidCard, fmt.Sprintf(format, args...))
+	}
+}
+
+// require fails when the card version is older than 1.minor.
+func (v *validator) require(minor int, feature string) {
+	if v.minor < minor {
+		v.fail("%s requires version 1.%d", feature, minor)
+	}
+}
+
+func (v *validator) input(typ, id string) {
+	if id == "" {
+		v.fail("%s requires an id", typ)
+		return
+	}
+
+	if v.ids[id] {
+		v.fail("duplicate input id %q", id)
+	}
+	v.ids[id] = true
+}
+
+// marshalTyped encodes v, which must encode as a JSON object, with an
+// additional leading "type" property.
+func marshalTyped(typ string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []byte(`{"type":` + strconv.Quote(typ))
+	if len(data) > 2 {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
@@ -0,0 +1,100 @@
+package card
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCardAttachmentJSON(t *testing.T) {
+	c := New("").
+		Add(
+			TextBlock{Text: "Deploy", Weight: WeightBolder},
+			ColumnSet{Columns: []Column{{Width: "auto", Items: []Element{FactSet{Facts: []Fact{{Title: "Env", Value: "prod"}}}}}}},
+			InputChoiceSet{ID: "service", Label: "Service", Choices: []Choice{{Title: "API", Value: "api"}}},
+			InputDate{ID: "when"},
+		).
+		AddActions(Submit{Title: "Go", Data: map[string]string{"action": "deploy"}}, OpenURL{Title: "Docs", URL: "https://example.com"})
+
+	attachment, err := c.Attachment()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attachment.ContentType != ContentType {
+		t.Errorf("expected content type %s, got %s", ContentType, attachment.ContentType)
+	}
+
+	data, err := json.Marshal(attachment.Content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Type    string `json:"type"`
+		Version string `json:"version"`
+		Body    []struct {
+			Type    string `json:"type"`
+			ID      string `json:"id"`
+			Columns []struct {
+				Type  string `json:"type"`
+				Items []struct {
+					Type string `json:"type"`
+				} `json:"items"`
+			} `json:"columns"`
+		} `json:"body"`
+		Actions []struct {
+			Type string            `json:"type"`
+			Data map[string]string `json:"data"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Type != "AdaptiveCard" || got.Version != DefaultVersion {
+		t.Errorf("unexpected card header: %s", data)
+	}
+
+	wantBody := []string{"TextBlock", "ColumnSet", "Input.ChoiceSet", "Input.Date"}
+	if len(got.Body) != len(wantBody) {
+		t.Fatalf("unexpected body: %s", data)
+	}
+	for i, typ := range wantBody {
+		if got.Body[i].Type != typ {
+			t.Errorf("body %d: expected %s, got %s", i, typ, got.Body[i].Type)
+		}
+	}
+
+	if got.Body[1].Columns[0].Type != "Column" || got.Body[1].Columns[0].Items[0].Type != "FactSet" {
+		t.Errorf("unexpected column set: %s", data)
+	}
+
+	if len(got.Actions) != 2 || got.Actions[0].Type != "Action.Submit" || got.Actions[0].Data["action"] != "deploy" || got.Actions[1].Type != "Action.OpenUrl" {
+		t.Errorf("unexpected actions: %s", data)
+	}
+}
+
+func TestCardValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		card *Card
+	}{
+		{"unsupported version", New("1.5").Add(TextBlock{Text: "hi"})},
+		{"label before 1.3", New("1.2").Add(InputText{ID: "name", Label: "Name"})},
+		{"action set before 1.2", New("1.1").Add(ActionSet{Actions: []Action{Submit{}}})},
+		{"duplicate input id", New("").Add(InputText{ID: "a"}, InputDate{ID: "a"})},
+		{"missing input id", New("").Add(InputText{})},
+		{"empty choices", New("").Add(InputChoiceSet{ID: "c"})},
+		{"nested empty text", New("").Add(Container{Items: []Element{TextBlock{}}})},
+		{"open url without url", New("").AddActions(OpenURL{Title: "Docs"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.card.Validate(); !errors.Is(err, ErrInvalidCard) {
+				t.Errorf("expected ErrInvalidCard, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package card builds Adaptive Cards for Webex messages.
+// It includes typed body elements, inputs and actions, schema version
+// validation and conversion to a messaging.Attachment.
+
+package card
+
+// This file serves as the package documentation for the card package.
+// All types are defined in their respective files:
+// - card.go: Card, validation and attachment conversion.
+// - elements.go: Body elements and inputs.
+// - actions.go: Submit and OpenURL actions.
@@ -0,0 +1,252 @@
+package card
+
+// Element is a card body element such as a TextBlock or an input.
+type Element interface {
+	validate(v *validator)
+}
+
+const (
+	SizeSmall      = "small"
+	SizeDefault    = "default"
+	SizeMedium     = "medium"
+	SizeLarge      = "large"
+	SizeExtraLarge = "extraLarge"
+
+	WeightLighter = "lighter"
+	WeightDefault = "default"
+	WeightBolder  = "bolder"
+
+	ColorDefault   = "default"
+	ColorAccent    = "accent"
+	ColorGood      = "good"
+	ColorWarning   = "warning"
+	ColorAttention = "attention"
+
+	SpacingNone   = "none"
+	SpacingSmall  = "small"
+	SpacingMedium = "medium"
+	SpacingLarge  = "large"
+
+	ChoiceSetStyleCompact  = "compact"
+	ChoiceSetStyleExpanded = "expanded"
+)
+
+type TextBlock struct {
+	Text      string `json:"text"`
+	Size      string `json:"size,omitempty"`
+	Weight    string `json:"weight,omitempty"`
+	Color     string `json:"color,omitempty"`
+	IsSubtle  bool   `json:"isSubtle,omitempty"`
+	Wrap      bool   `json:"wrap,omitempty"`
+	MaxLines  int    `json:"maxLines,omitempty"`
+	Spacing   string `json:"spacing,omitempty"`
+	Separator bool   `json:"separator,omitempty"`
+	ID        string `json:"id,omitempty"`
+}
+
+func (e TextBlock) MarshalJSON() ([]byte, error) {
+	type alias TextBlock
+	return marshalTyped("TextBlock", alias(e))
+}
+
+func (e TextBlock) validate(v *validator) {
+	if e.Text == "" {
+		v.fail("TextBlock requires text")
+	}
+}
+
+type Image struct {
+	URL     string `json:"url"`
+	AltText string `json:"altText,omitempty"`
+	Size    string `json:"size,omitempty"`
+	Style   string `json:"style,omitempty"`
+	Spacing string `json:"spacing,omitempty"`
+}
+
+func (e Image) MarshalJSON() ([]byte, error) {
+	type alias Image
+	return marshalTyped("Image", alias(e))
+}
+
+func (e Image) validate(v *validator) {
+	if e.URL == "" {
+		v.fail("Image requires a url")
+	}
+}
+
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type FactSet struct {
+	Facts   []Fact `json:"facts"`
+	Spacing string `json:"spacing,omitempty"`
+}
+
+func (e FactSet) MarshalJSON() ([]byte, error) {
+	type alias FactSet
+	return marshalTyped("FactSet", alias(e))
+}
+
+func (e FactSet) validate(v *validator) {
+	if len(e.Facts) == 0 {
+		v.fail("FactSet requires at least one fact")
+	}
+}
+
+// Container groups elements.
+type Container struct {
+	Items   []Element `json:"items"`
+	Style   string    `json:"style,omitempty"`
+	Spacing string    `json:"spacing,omitempty"`
+}
+
+func (e Container) MarshalJSON() ([]byte, error) {
+	type alias Container
+	return marshalTyped("Container", alias(e))
+}
+
+func (e Container) validate(v *validator) {
+	for _, item := range e.Items {
+		item.validate(v)
+	}
+}
+
+// Column is a column of a ColumnSet. Width is "auto", "stretch" or a relative
+// weight such as "2".
+type Column struct {
+	Items   []Element `json:"items"`
+	Width   string    `json:"width,omitempty"`
+	Spacing string    `json:"spacing,omitempty"`
+}
+
+func (e Column) MarshalJSON() ([]byte, error) {
+	type alias Column
+	return marshalTyped("Column", alias(e))
+}
+
+type ColumnSet struct {
+	Columns []Column `json:"columns"`
+	Spacing string   `json:"spacing,omitempty"`
+}
+
+func (e ColumnSet) MarshalJSON() ([]byte, error) {
+	type alias ColumnSet
+	return marshalTyped("ColumnSet", alias(e))
+}
+
+func (e ColumnSet) validate(v *validator) {
+	if len(e.Columns) == 0 {
+		v.fail("ColumnSet requires at least one column")
+	}
+
+	for _, column := range e.Columns {
+		for _, item := range column.Items {
+			item.validate(v)
+		}
+	}
+}
+
+// ActionSet shows actions inside the card body.
+type ActionSet struct {
+	Actions []Action `json:"actions"`
+}
+
+func (e ActionSet) MarshalJSON() ([]byte, error) {
+	type alias ActionSet
+	return marshalTyped("ActionSet", alias(e))
+}
+
+func (e ActionSet) validate(v *validator) {
+	v.require(2, "ActionSet")
+	for _, action := range e.Actions {
+		action.validate(v)
+	}
+}
+
+// InputText is a text field. Label and IsRequired require version 1.3.
+type InputText struct {
+	ID           string `json:"id"`
+	Label        string `json:"label,omitempty"`
+	Placeholder  string `json:"placeholder,omitempty"`
+	Value        string `json:"value,omitempty"`
+	IsMultiline  bool   `json:"isMultiline,omitempty"`
+	MaxLength    int    `json:"maxLength,omitempty"`
+	IsRequired   bool   `json:"isRequired,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+func (e InputText) MarshalJSON() ([]byte, error) {
+	type alias InputText
+	return marshalTyped("Input.Text", alias(e))
+}
+
+func (e InputText) validate(v *validator) {
+	v.input("Input.Text", e.ID)
+	validateInputOptions(v, "Input.Text", e.Label, e.IsRequired, e.ErrorMessage)
+}
+
+type Choice struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// InputChoiceSet is a drop-down or radio list. Multiple selections are
+// submitted as a comma separated value.
+type InputChoiceSet struct {
+	ID            string   `json:"id"`
+	Label         string   `json:"label,omitempty"`
+	Choices       []Choice `json:"choices"`
+	Value         string   `json:"value,omitempty"`
+	Style         string   `json:"style,omitempty"`
+	IsMultiSelect bool     `json:"isMultiSelect,omitempty"`
+	Placeholder   string   `json:"placeholder,omitempty"`
+	IsRequired    bool     `json:"isRequired,omitempty"`
+	ErrorMessage  string   `json:"errorMessage,omitempty"`
+}
+
+func (e InputChoiceSet) MarshalJSON() ([]byte, error) {
+	type alias InputChoiceSet
+	return marshalTyped("Input.ChoiceSet", alias(e))
+}
+
+func (e InputChoiceSet) validate(v *validator) {
+	v.input("Input.ChoiceSet", e.ID)
+	if len(e.Choices) == 0 {
+		v.fail("Input.ChoiceSet %q requires at least one choice", e.ID)
+	}
+	validateInputOptions(v, "Input.ChoiceSet", e.Label, e.IsRequired, e.ErrorMessage)
+}
+
+// InputDate is a date picker. Dates use the YYYY-MM-DD format.
+type InputDate struct {
+	ID           string `json:"id"`
+	Label        string `json:"label,omitempty"`
+	Value        string `json:"value,omitempty"`
+	Min          string `json:"min,omitempty"`
+	Max          string `json:"max,omitempty"`
+	Placeholder  string `json:"placeholder,omitempty"`
+	IsRequired   bool   `json:"isRequired,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+func (e InputDate) MarshalJSON() ([]byte, error) {
+	type alias InputDate
+	return marshalTyped("Input.Date", alias(e))
+}
+
+func (e InputDate) validate(v *validator) {
+	v.input("Input.Date", e.ID)
+	validateInputOptions(v, "Input.Date", e.Label, e.IsRequired, e.ErrorMessage)
+}
+
+func validateInputOptions(v *validator, typ, label string, required bool, errorMessage string) {
+	if label != "" {
+		v.require(3, typ+" label")
+	}
+
+	if required || errorMessage != "" {
+		v.require(3, typ+" validation")
+	}
+}
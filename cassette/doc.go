@@ -0,0 +1,12 @@
+// Package cassette records Webex API interactions to cassette files and
+// replays them, so integration tests can run against real responses in CI
+// without network access or credentials. A Recorder plugs into a client as
+// a middleware and works in record, replay or record-missing mode.
+
+package cassette
+
+// This file serves as the package documentation for the cassette package.
+// All types are defined in their respective files:
+// - cassette.go: Cassette file format, loading and saving.
+// - recorder.go: Recorder middleware, modes, matching and scrubbing.
+// - yaml.go: Conversion between cassette JSON and YAML.
@@ -0,0 +1,502 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/rainuxhe/webexgosdk"
+)
+
+// Mode selects whether a Recorder talks to the network.
+type Mode int
+
+const (
+	// ModeReplay answers every request from the cassette and fails requests
+	// that were not recorded. It never touches the network.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends every request and records a new cassette, replacing
+	// any existing one.
+	ModeRecord
+
+	// ModeRecordMissing replays recorded requests and records the others.
+	ModeRecordMissing
+)
+
+// DefaultReplacement replaces scrubbed values.
+const DefaultReplacement = "REDACTED"
+
+// multipartBoundary replaces the random boundary of multipart bodies so
+// they match between runs.
+const multipartBoundary = "BOUNDARY"
+
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeRecordMissing:
+		return "record-missing"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// ParseMode parses "replay", "record" or "record-missing". The empty string
+// is ModeReplay, so CI runs without network unless asked otherwise.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "", "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "record-missing":
+		return ModeRecordMissing, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnknownMode, s)
+	}
+}
+
+type Options struct {
+	Mode Mode
+
+	// ScrubHeaders lists headers whose values are replaced in the cassette.
+	// Authorization is always scrubbed.
+	ScrubHeaders []string
+
+	// ScrubFields lists JSON body fields and query parameters whose values
+	// are replaced in the cassette, such as "emails" or "personEmail".
+	// Requests are scrubbed the same way before matching, so scrubbed
+	// requests still replay.
+	ScrubFields []string
+
+	// Replacement replaces scrubbed values. Defaults to DefaultReplacement.
+	Replacement string
+}
+
+// UnmatchedError is returned in replay mode for a request the cassette
+// has no unused interaction for.
+type UnmatchedError struct {
+	Cassette string
+	Method   string
+	URL      string
+	Body     string
+}
+
+func (e *UnmatchedError) Error() string {
+	msg := fmt.Sprintf("cassette %s has no unused interaction for %s %s", e.Cassette, e.Method, e.URL)
+	if e.Body != "" {
+		msg += " with body " + e.Body
+	}
+	return msg
+}
+
+// Recorder records HTTP interactions to a cassette file and replays them.
+// Install it on a client with Middleware and call Save when done.
+type Recorder struct {
+	path         string
+	mode         Mode
+	replacement  string
+	scrubHeaders map[string]bool
+	scrubFields  map[string]bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	dirty    bool
+}
+
+// New creates a recorder for the cassette at path, which must end in .json,
+// .yaml or .yml. Replay mode requires the cassette to exist.
+func New(path string, opts *Options) (*Recorder, error) {
+	if !isJSON(path) && !isYAML(path) {
+		return nil, ErrUnknownFormat
+	}
+
+	var options Options
+	if opts != nil {
+		options = *opts
+	}
+
+	r := &Recorder{
+		path:         path,
+		mode:         options.Mode,
+		replacement:  options.Replacement,
+		scrubHeaders: map[string]bool{"Authorization": true},
+		scrubFields:  make(map[string]bool),
+	}
+
+	if r.replacement == "" {
+		r.replacement = DefaultReplacement
+	}
+
+	for _, header := range options.ScrubHeaders {
+		r.scrubHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+
+	for _, field := range options.ScrubFields {
+		r.scrubFields[field] = true
+	}
+
+	switch r.mode {
+	case ModeRecord:
+		r.cassette = &Cassette{Version: Version}
+	case ModeReplay, ModeRecordMissing:
+		c, err := Load(path)
+		if r.mode == ModeRecordMissing && errors.Is(err, fs.ErrNotExist) {
+			c, err = &Cassette{Version: Version}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+	default:
+		return nil, fmt.Errorf("%w %v", ErrUnknownMode, r.mode)
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Mode returns the recorder's mode.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Middleware returns a middleware that records or replays every request
+// sent through it.
+func (r *Recorder) Middleware() webexgosdk.Middleware {
+	return func(next webexgosdk.Doer) webexgosdk.Doer {
+		return webexgosdk.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return r.do(next, req)
+		})
+	}
+}
+
+func (r *Recorder) do(next webexgosdk.Doer, req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := r.request(req, body)
+
+	if r.mode != ModeRecord {
+		if interaction := r.take(&recorded); interaction != nil {
+			return replay(interaction, req)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return nil, &UnmatchedError{
+			Cassette: r.path,
+			Method:   recorded.Method,
+			URL:      recorded.URL,
+			Body:     r.matchBody(&recorded),
+		}
+	}
+
+	resp, err := next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request:  recorded,
+		Response: r.response(resp, respBody),
+	})
+	r.used = append(r.used, true)
+	r.dirty = true
+
+	return resp, nil
+}
+
+// take returns the first unused interaction matching req and marks it used.
+func (r *Recorder) take(req *Request) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] && r.matches(&interaction.Request, req) {
+			r.used[i] = true
+			return interaction
+		}
+	}
+	return nil
+}
+
+// matches reports whether a recorded request matches req by method, path,
+// query and body.
+func (r *Recorder) matches(recorded, req *Request) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+
+	a, errA := url.Parse(recorded.URL)
+	b, errB := url.Parse(req.URL)
+	if errA != nil || errB != nil || a.Path != b.Path {
+		return false
+	}
+
+	if r.scrubQuery(a.Query()).Encode() != r.scrubQuery(b.Query()).Encode() {
+		return false
+	}
+
+	return r.matchBody(recorded) == r.matchBody(req)
+}
+
+// matchBody returns the canonical form of a request body for matching. JSON
+// is compared structurally after scrubbing.
+func (r *Recorder) matchBody(req *Request) string {
+	body, err := decodeBody(req.Body, req.BodyEncoding)
+	if err != nil {
+		return req.Body
+	}
+
+	if isJSONContent(req.Header.Get("Content-Type")) {
+		if canonical, ok := r.scrubJSON(body); ok {
+			return string(canonical)
+		}
+	}
+	return string(body)
+}
+
+// request returns the scrubbed cassette form of req.
+func (r *Recorder) request(req *http.Request, body []byte) Request {
+	header := r.scrubHeader(req.Header)
+	contentType := header.Get("Content-Type")
+
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		if boundary := params["boundary"]; boundary != "" {
+			body = bytes.ReplaceAll(body, []byte(boundary), []byte(multipartBoundary))
+			params["boundary"] = multipartBoundary
+			header.Set("Content-Type", mime.FormatMediaType(mediaType, params))
+		}
+	}
+
+	if isJSONContent(contentType) {
+		if scrubbed, ok := r.scrubJSON(body); ok {
+			body = scrubbed
+		}
+	}
+
+	recorded := Request{
+		Method: req.Method,
+		URL:    r.scrubURL(req.URL),
+		Header: header,
+	}
+	recorded.Body, recorded.BodyEncoding = encodeBody(body, contentType)
+	return recorded
+}
+
+// response returns the scrubbed cassette form of resp.
+func (r *Recorder) response(resp *http.Response, body []byte) Response {
+	header := r.scrubHeader(resp.Header)
+	contentType := header.Get("Content-Type")
+
+	// Bodies are reformatted, so the recorded length would be wrong.
+	header.Del("Content-Length")
+
+	if links := header.Values("Link"); len(links) > 0 {
+		header.Del("Link")
+		for _, link := range links {
+			header.Add("Link", r.scrubLink(link))
+		}
+	}
+
+	if isJSONContent(contentType) {
+		if scrubbed, ok := r.scrubJSON(body); ok {
+			body = scrubbed
+		}
+	}
+
+	recorded := Response{
+		StatusCode: resp.StatusCode,
+		Header:     header,
+	}
+	recorded.Body, recorded.BodyEncoding = encodeBody(body, contentType)
+	return recorded
+}
+
+// replay builds the response of a recorded interaction.
+func replay(interaction *Interaction, req *http.Request) (*http.Response, error) {
+	body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode recorded response: %w", err)
+	}
+
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	code := interaction.Response.StatusCode
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	if scrubbed == nil {
+		scrubbed = make(http.Header)
+	}
+
+	for key := range scrubbed {
+		if r.scrubHeaders[http.CanonicalHeaderKey(key)] {
+			scrubbed[key] = []string{r.replacement}
+		}
+	}
+	return scrubbed
+}
+
+func (r *Recorder) scrubQuery(query url.Values) url.Values {
+	for key, values := range query {
+		if r.scrubFields[key] {
+			for i := range values {
+				values[i] = r.replacement
+			}
+		}
+	}
+	return query
+}
+
+func (r *Recorder) scrubURL(u *url.URL) string {
+	scrubbed := *u
+	if len(r.scrubFields) > 0 && scrubbed.RawQuery != "" {
+		scrubbed.RawQuery = r.scrubQuery(u.Query()).Encode()
+	}
+	return scrubbed.String()
+}
+
+var linkURL = regexp.MustCompile(`<([^>]*)>`)
+
+// scrubLink scrubs the query of the URLs in a Link header, which repeat the
+// request's query parameters.
+func (r *Recorder) scrubLink(link string) string {
+	return linkURL.ReplaceAllStringFunc(link, func(match string) string {
+		u, err := url.Parse(match[1 : len(match)-1])
+		if err != nil {
+			return match
+		}
+		return "<" + r.scrubURL(u) + ">"
+	})
+}
+
+// scrubJSON returns body with the scrubbed fields replaced, in canonical
+// form. It reports false when body is not JSON.
+func (r *Recorder) scrubJSON(body []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	scrubbed, err := json.Marshal(r.scrubValue(v))
+	if err != nil {
+		return nil, false
+	}
+	return scrubbed, true
+}
+
+func (r *Recorder) scrubValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if r.scrubFields[key] {
+				v[key] = r.replace(value)
+			} else {
+				v[key] = r.scrubValue(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = r.scrubValue(value)
+		}
+	}
+	return v
+}
+
+// replace replaces a scrubbed value, keeping the shape of lists.
+func (r *Recorder) replace(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		for i, value := range v {
+			v[i] = r.replace(value)
+		}
+		return v
+	default:
+		return r.replacement
+	}
+}
+
+// Unused returns the recorded interactions that have not been replayed.
+// Tests can assert it is empty to catch requests the code no longer sends.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Save writes the cassette if new interactions were recorded.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.dirty {
+		return nil
+	}
+
+	if err := r.cassette.Save(r.path); err != nil {
+		return err
+	}
+
+	r.dirty = false
+	return nil
+}
@@ -0,0 +1,214 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webextest"
+)
+
+func newClient(t *testing.T, baseURL string, r *Recorder) *webexgosdk.Client {
+	t.Helper()
+
+	client, err := webexgosdk.NewClient(webextest.DefaultAccessToken, webexgosdk.ClientOptions{
+		BaseURL:     baseURL,
+		Middlewares: []webexgosdk.Middleware{r.Middleware()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// exercise creates a room and reads it back, returning what it saw.
+func exercise(ctx context.Context, client *webexgosdk.Client) ([]string, error) {
+	room, err := client.Messaging.Rooms.Create(ctx, &messaging.RoomCreateRequest{Title: "Recorded"})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.Messaging.Messages.Create(ctx, &messaging.MessageCreateRequest{RoomID: room.ID, Text: "hello"}); err != nil {
+		return nil, err
+	}
+
+	messages, err := client.Messaging.Messages.List(ctx, &messaging.MessageListOptions{RoomID: room.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := client.Messaging.People.Get(ctx, "me")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{room.ID, messages[0].Text, messages[0].PersonEmail, me.Emails[0]}, nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rooms.yaml")
+	opts := &Options{ScrubFields: []string{"emails", "personEmail"}}
+
+	server := webextest.NewServer(nil)
+	baseURL := server.BaseURL()
+
+	opts.Mode = ModeRecord
+	recorder, err := New(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := exercise(ctx, newClient(t, baseURL, recorder))
+	if err != nil {
+		t.Fatalf("failed to record: %v", err)
+	}
+	server.Close()
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, secret := range []string{webextest.DefaultAccessToken, "test.user@example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette leaks %q:\n%s", secret, data)
+		}
+	}
+
+	opts.Mode = ModeReplay
+	recorder, err = New(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newClient(t, baseURL, recorder)
+
+	replayed, err := exercise(ctx, client)
+	if err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+
+	if recorded[0] != replayed[0] || replayed[1] != "hello" || replayed[3] != DefaultReplacement {
+		t.Errorf("replayed %v, recorded %v", replayed, recorded)
+	}
+
+	if unused := recorder.Unused(); len(unused) != 0 {
+		t.Errorf("expected every interaction to be replayed, %d left", len(unused))
+	}
+
+	_, err = client.Messaging.Rooms.Get(ctx, "unrecorded")
+	var unmatched *UnmatchedError
+	if !errors.As(err, &unmatched) || unmatched.Method != "GET" || !strings.HasSuffix(unmatched.URL, "/rooms/unrecorded") {
+		t.Errorf("expected an unmatched request error, got %v", err)
+	}
+}
+
+func TestRecordMissing(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "people.json")
+
+	server := webextest.NewServer(nil)
+	defer server.Close()
+
+	recorder, err := New(path, &Options{Mode: ModeRecordMissing})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newClient(t, server.BaseURL(), recorder)
+	if _, err := client.Messaging.People.Get(ctx, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rate limiting the server shows which requests reach it.
+	server.RateLimit("", 10, 0)
+
+	recorder, err = New(path, &Options{Mode: ModeRecordMissing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = newClient(t, server.BaseURL(), recorder)
+
+	if _, err := client.Messaging.People.Get(ctx, "me"); err != nil {
+		t.Errorf("expected the recorded request to replay, got %v", err)
+	}
+
+	var rateErr *webexgosdk.RateLimitError
+	if _, err := client.Messaging.Rooms.List(ctx, nil); !errors.As(err, &rateErr) {
+		t.Errorf("expected the missing request to reach the server, got %v", err)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Interactions) != 2 || c.Interactions[1].Response.StatusCode != 429 {
+		t.Errorf("expected the new interaction to be appended, got %d", len(c.Interactions))
+	}
+}
+
+func TestReplayRequiresCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+	if err == nil {
+		t.Error("expected an error for a missing cassette in replay mode")
+	}
+
+	if _, err := New("cassette.txt", nil); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	if _, err := ParseMode("rewind"); !errors.Is(err, ErrUnknownMode) {
+		t.Errorf("expected ErrUnknownMode, got %v", err)
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	want := &Cassette{
+		Version: Version,
+		Interactions: []*Interaction{{
+			Request: Request{
+				Method: "POST",
+				URL:    "https://webexapis.com/v1/messages?a=1&b=two",
+				Header: map[string][]string{"Content-Type": {"application/json"}},
+				Body:   "{\n  \"text\": \"line: one\\n\",\n  \"markdown\": \"# title\"\n}",
+			},
+			Response: Response{
+				StatusCode: 200,
+				Header:     map[string][]string{"Link": {`<https://x/?cursor=abc>; rel="next"`}},
+				Body:       " leading space\nand \"quotes\"\n\nünïcode\t",
+			},
+		}, {
+			Request:  Request{Method: "GET", URL: "https://webexapis.com/v1/people/me"},
+			Response: Response{StatusCode: 204, Body: "-not a list\n  indented\ntrailing\n"},
+		}},
+	}
+
+	path := filepath.Join(t.TempDir(), "roundtrip.yml")
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		data, _ := os.ReadFile(path)
+		t.Fatalf("failed to load: %v\n%s", err, data)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		data, _ := os.ReadFile(path)
+		t.Errorf("round trip changed the cassette:\n%s", data)
+	}
+}
@@ -0,0 +1,273 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/internal/yamlout"
+)
+
+// Cassettes are stored as YAML written by yamlout. The reader below supports
+// the subset of YAML that yamlout writes: block mappings and sequences, plain
+// and double-quoted scalars, literal blocks for multi-line text, and plain
+// numbers, booleans and null.
+
+// jsonToYAML converts a JSON document to YAML.
+func jsonToYAML(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, fmt.Errorf("top-level value must be an object")
+	}
+	return yamlout.FromJSON(data)
+}
+
+// yamlLine is a line split into its indentation and text.
+type yamlLine struct {
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// yamlToJSON converts YAML written by jsonToYAML back to JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	p := &yamlParser{}
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := strings.TrimLeft(line, " ")
+		p.lines = append(p.lines, yamlLine{indent: len(line) - len(text), text: text})
+	}
+
+	tree, err := p.parseBlock(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if line, ok := p.next(); ok {
+		return nil, p.errorf("unexpected %q", line.text)
+	}
+
+	var b bytes.Buffer
+	if err := writeJSON(&b, tree); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// next returns the next line that is neither blank nor a comment.
+func (p *yamlParser) next() (yamlLine, bool) {
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.text != "" && !strings.HasPrefix(line.text, "#") {
+			return line, true
+		}
+		p.pos++
+	}
+	return yamlLine{}, false
+}
+
+// parseBlock parses a mapping or sequence indented by at least minIndent.
+func (p *yamlParser) parseBlock(minIndent int) (any, error) {
+	line, ok := p.next()
+	if !ok || line.indent < minIndent {
+		return nil, p.errorf("expected an indented block")
+	}
+
+	if isItem(line.text) {
+		return p.parseSequence(line.indent)
+	}
+	return p.parseMapping(line.indent)
+}
+
+func isItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseMapping(indent int) (yamlout.Map, error) {
+	m := yamlout.Map{}
+	for {
+		line, ok := p.next()
+		if !ok || line.indent < indent {
+			return m, nil
+		}
+		if line.indent > indent || isItem(line.text) {
+			return nil, p.errorf("unexpected %q", line.text)
+		}
+
+		key, rest, found := splitKey(line.text)
+		if !found {
+			return nil, p.errorf("expected a key in %q", line.text)
+		}
+		p.pos++
+
+		value, err := p.parseValue(rest, indent)
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, yamlout.Field{Key: key, Value: value})
+	}
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]any, error) {
+	list := []any{}
+	for {
+		line, ok := p.next()
+		if !ok || line.indent < indent {
+			return list, nil
+		}
+		if line.indent > indent || !isItem(line.text) {
+			return nil, p.errorf("unexpected %q", line.text)
+		}
+
+		rest := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if isKey(rest) {
+			// "- key: value" starts a mapping indented past the dash.
+			p.lines[p.pos] = yamlLine{indent: indent + 2, text: rest}
+			m, err := p.parseMapping(indent + 2)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, m)
+			continue
+		}
+
+		p.pos++
+		value, err := p.parseValue(rest, indent)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+}
+
+// isKey reports whether text starts a mapping entry.
+func isKey(text string) bool {
+	_, _, found := splitKey(text)
+	return found
+}
+
+// splitKey splits a "key: value" or "key:" line. Plain keys may contain
+// colons that are not followed by a space; other keys are double-quoted.
+func splitKey(text string) (key, rest string, found bool) {
+	if strings.HasPrefix(text, `"`) {
+		dec := json.NewDecoder(strings.NewReader(text))
+		if err := dec.Decode(&key); err != nil {
+			return "", "", false
+		}
+		rest, found = strings.CutPrefix(text[dec.InputOffset():], ":")
+		if !found || (rest != "" && rest[0] != ' ') {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest), true
+	}
+
+	if i := strings.Index(text, ": "); i > 0 {
+		key, rest = text[:i], text[i+2:]
+	} else if strings.HasSuffix(text, ":") && len(text) > 1 {
+		key = text[:len(text)-1]
+	} else {
+		return "", "", false
+	}
+
+	if strings.ContainsAny(key, " {[") {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(rest), true
+}
+
+// parseValue parses the text after "key:" or "-". Empty text introduces a
+// nested block.
+func (p *yamlParser) parseValue(text string, indent int) (any, error) {
+	switch {
+	case text == "":
+		return p.parseBlock(indent + 1)
+	case text == "|-":
+		return p.parseLiteral(indent + 1), nil
+	case text == "{}":
+		return yamlout.Map{}, nil
+	case text == "[]":
+		return []any{}, nil
+	case text[0] == '"':
+		var s string
+		if err := json.Unmarshal([]byte(text), &s); err != nil {
+			return nil, p.errorf("invalid string %s", text)
+		}
+		return s, nil
+	case json.Valid([]byte(text)):
+		return json.RawMessage(text), nil
+	}
+	return text, nil
+}
+
+// parseLiteral reads a literal block whose lines are indented by at least
+// minIndent. Trailing blank lines are dropped, as "|-" requires.
+func (p *yamlParser) parseLiteral(minIndent int) string {
+	blockIndent := -1
+	var lines []string
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if line.text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if line.indent < minIndent || (blockIndent >= 0 && line.indent < blockIndent) {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = line.indent
+		}
+		lines = append(lines, strings.Repeat(" ", line.indent-blockIndent)+line.text)
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func writeJSON(w io.Writer, v any) error {
+	switch v := v.(type) {
+	case yamlout.Map:
+		io.WriteString(w, "{")
+		for i, field := range v {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			key, _ := json.Marshal(field.Key)
+			w.Write(key)
+			io.WriteString(w, ":")
+			if err := writeJSON(w, field.Value); err != nil {
+				return err
+			}
+		}
+		io.WriteString(w, "}")
+	case []any:
+		io.WriteString(w, "[")
+		for i, item := range v {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			if err := writeJSON(w, item); err != nil {
+				return err
+			}
+		}
+		io.WriteString(w, "]")
+	case json.RawMessage:
+		w.Write(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		w.Write(data)
+	}
+	return nil
+}
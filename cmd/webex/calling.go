@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/calling"
+)
+
+var callsCommands = map[string]command{
+	"dial":    {usage: "<destination>", run: callsDial},
+	"hangup":  {usage: "<call-id>", run: callsHangup},
+	"history": {usage: "[-type placed|received|missed] [-max n]", run: callsHistory},
+}
+
+var callHistoryColumns = []column[*calling.CallHistoryRecord]{
+	{"START", func(r *calling.CallHistoryRecord) string { return formatTime(r.StartTime) }},
+	{"TYPE", func(r *calling.CallHistoryRecord) string { return r.Type.String() }},
+	{"NAME", func(r *calling.CallHistoryRecord) string { return r.Name }},
+	{"NUMBER", func(r *calling.CallHistoryRecord) string { return r.Number }},
+	{"DURATION", func(r *calling.CallHistoryRecord) string { return formatSeconds(r.Duration) }},
+}
+
+var dialColumns = []column[*calling.DialResponse]{
+	{"CALL ID", func(r *calling.DialResponse) string { return r.CallID }},
+	{"SESSION ID", func(r *calling.DialResponse) string { return r.CallSessionID }},
+}
+
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func callsDial(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	call, err := client.Calling.Calls.Dial(ctx, &calling.DialRequest{Destination: fs.Arg(0)})
+	if err != nil {
+		return fmt.Errorf("failed to dial: %w", err)
+	}
+	return printItem(a, call, dialColumns)
+}
+
+func callsHangup(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	if err := client.Calling.Calls.Hangup(ctx, fs.Arg(0)); err != nil {
+		return fmt.Errorf("failed to hang up: %w", err)
+	}
+	return a.printText("Hung up call "+fs.Arg(0), map[string]string{"hungUp": fs.Arg(0)})
+}
+
+func callsHistory(ctx context.Context, a *app, args []string) error {
+	var opts calling.CallHistoryListOptions
+	fs := a.flags()
+	fs.StringVar((*string)(&opts.Type), "type", "", "list only `placed`, received or missed calls")
+	fs.IntVar(&opts.Max, "max", 0, "maximum number of calls")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	records, err := client.Calling.CallHistory.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to list call history: %w", err)
+	}
+	return printList(a, records, callHistoryColumns)
+}
+
+var voicemailCommands = map[string]command{
+	"list":    {usage: "[-max n]", run: voicemailList},
+	"summary": {usage: "", run: voicemailSummary},
+}
+
+var voicemailColumns = []column[*calling.VoiceMessage]{
+	{"ID", func(m *calling.VoiceMessage) string { return m.ID }},
+	{"CREATED", func(m *calling.VoiceMessage) string { return formatTime(m.Created) }},
+	{"FROM", func(m *calling.VoiceMessage) string {
+		if m.CallingParty == nil {
+			return ""
+		}
+		if m.CallingParty.Name != "" {
+			return m.CallingParty.Name
+		}
+		return m.CallingParty.Number
+	}},
+	{"DURATION", func(m *calling.VoiceMessage) string { return formatSeconds(m.Duration) }},
+	{"READ", func(m *calling.VoiceMessage) string { return strconv.FormatBool(m.Read) }},
+	{"URGENT", func(m *calling.VoiceMessage) string { return strconv.FormatBool(m.Urgent) }},
+}
+
+var voicemailSummaryColumns = []column[*calling.VoiceMessageSummary]{
+	{"NEW", func(s *calling.VoiceMessageSummary) string { return strconv.Itoa(s.NewMessages) }},
+	{"OLD", func(s *calling.VoiceMessageSummary) string { return strconv.Itoa(s.OldMessages) }},
+	{"NEW URGENT", func(s *calling.VoiceMessageSummary) string { return strconv.Itoa(s.NewUrgentMessages) }},
+	{"OLD URGENT", func(s *calling.VoiceMessageSummary) string { return strconv.Itoa(s.OldUrgentMessages) }},
+}
+
+func voicemailList(ctx context.Context, a *app, args []string) error {
+	var opts calling.VoicemailListOptions
+	fs := a.flags()
+	fs.IntVar(&opts.Max, "max", 0, "maximum number of messages")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	messages, err := client.Calling.Voicemail.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to list voicemail: %w", err)
+	}
+	return printList(a, messages, voicemailColumns)
+}
+
+func voicemailSummary(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	summary, err := client.Calling.Voicemail.GetSummary(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get voicemail summary: %w", err)
+	}
+	return printItem(a, summary, voicemailSummaryColumns)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rainuxhe/webexgosdk"
+)
+
+const (
+	configEnvVar  = "WEBEX_CONFIG"
+	profileEnvVar = "WEBEX_PROFILE"
+
+	defaultProfile = "default"
+)
+
+// Config is the CLI config file, for example:
+//
+//	{
+//	  "defaultProfile": "work",
+//	  "profiles": {
+//	    "work": {"token": "..."},
+//	    "sandbox": {"token": "...", "baseUrl": "https://sandbox.example.com/v1/"}
+//	  }
+//	}
+type Config struct {
+	DefaultProfile string              `json:"defaultProfile,omitempty"`
+	Profiles       map[string]*Profile `json:"profiles"`
+}
+
+// Profile holds the credentials of one account.
+type Profile struct {
+	Token   string `json:"token"`
+	BaseURL string `json:"baseUrl,omitempty"`
+}
+
+// configFile returns the config file location: the -config flag,
+// $WEBEX_CONFIG, or webex/config.json in the user config directory.
+func (a *app) configFile() (string, error) {
+	if a.configPath != "" {
+		return a.configPath, nil
+	}
+
+	if path := a.getenv(configEnvVar); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "webex", "config.json"), nil
+}
+
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// credentials resolves the profile to use. $WEBEX_ACCESS_TOKEN takes
+// precedence unless a profile is selected with -profile or $WEBEX_PROFILE.
+func (a *app) credentials() (*Profile, error) {
+	name := a.profile
+	if name == "" {
+		name = a.getenv(profileEnvVar)
+	}
+
+	if name == "" {
+		if token := a.getenv(webexgosdk.AccessTokenEnvVar); token != "" {
+			return &Profile{Token: token}, nil
+		}
+	}
+
+	path, err := a.configFile()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := loadConfig(path)
+	if errors.Is(err, os.ErrNotExist) && name == "" {
+		return nil, fmt.Errorf("no access token: set %s or create profiles in %s", webexgosdk.AccessTokenEnvVar, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if name == "" {
+		name = config.DefaultProfile
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok || profile.Token == "" {
+		return nil, fmt.Errorf("profile %q has no token in %s", name, path)
+	}
+	return profile, nil
+}
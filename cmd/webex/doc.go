@@ -0,0 +1,19 @@
+// Webex is a command-line client for the Webex APIs built on the SDK.
+// It manages rooms, messages, people, webhooks, meetings, calls and
+// voicemail, printing results as a table, JSON or YAML. The access token is
+// read from WEBEX_ACCESS_TOKEN or from a profile in the config file.
+//
+// Usage:
+//
+//	webex [-profile name] [-output table|json|yaml] <command> <subcommand> [arguments]
+
+package main
+
+// This file serves as the package documentation for the webex command.
+// All commands are defined in their respective files:
+// - main.go: Command dispatch, flags and client creation.
+// - config.go: Config file and profile resolution.
+// - output.go: Table, JSON and YAML output.
+// - messaging.go: rooms, messages, people and webhooks commands.
+// - meeting.go: meetings commands.
+// - calling.go: calls and voicemail commands.
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// errUsage reports invalid arguments. The usage has already been printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a subcommand such as "rooms list".
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+// commands maps each group to its subcommands.
+var commands = map[string]map[string]command{
+	"rooms":     roomsCommands,
+	"messages":  messagesCommands,
+	"people":    peopleCommands,
+	"webhooks":  webhooksCommands,
+	"meetings":  meetingsCommands,
+	"calls":     callsCommands,
+	"voicemail": voicemailCommands,
+}
+
+// app holds the state shared by all commands of one invocation.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	profile    string
+	configPath string
+	baseURL    string
+	output     string
+
+	// name and usage describe the running subcommand.
+	name  string
+	usage string
+
+	client *webexgosdk.Client
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line args and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) int {
+	a := &app{
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		getenv: getenv,
+		output: formatTable,
+	}
+
+	fs := flag.NewFlagSet("webex", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&a.profile, "profile", "", "`name` of the profile in the config file")
+	fs.StringVar(&a.configPath, "config", "", "`path` of the config file (default $"+configEnvVar+" or the user config directory)")
+	fs.StringVar(&a.baseURL, "base-url", "", "API base `url`")
+	a.outputFlags(fs)
+	fs.Usage = func() { a.printUsage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if fs.NArg() < 2 {
+		a.printUsage(fs)
+		return exitUsage
+	}
+
+	group, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "webex: unknown command %q\n", fs.Arg(0))
+		a.printUsage(fs)
+		return exitUsage
+	}
+
+	cmd, ok := group[fs.Arg(1)]
+	if !ok {
+		fmt.Fprintf(stderr, "webex: unknown command %q\n", fs.Arg(0)+" "+fs.Arg(1))
+		a.printUsage(fs)
+		return exitUsage
+	}
+
+	a.name, a.usage = fs.Arg(0)+" "+fs.Arg(1), cmd.usage
+	if err := cmd.run(ctx, a, fs.Args()[2:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return exitUsage
+		}
+		fmt.Fprintf(stderr, "webex: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func (a *app) printUsage(fs *flag.FlagSet) {
+	fmt.Fprintln(a.stderr, "Usage: webex [flags] <command> <subcommand> [arguments]")
+	fmt.Fprintln(a.stderr, "\nCommands:")
+
+	groups := make([]string, 0, len(commands))
+	for name := range commands {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	for _, name := range groups {
+		subcommands := make([]string, 0, len(commands[name]))
+		for sub := range commands[name] {
+			subcommands = append(subcommands, sub)
+		}
+		sort.Strings(subcommands)
+
+		for _, sub := range subcommands {
+			line := fmt.Sprintf("  %s %s %s", name, sub, commands[name][sub].usage)
+			fmt.Fprintln(a.stderr, strings.TrimRight(line, " "))
+		}
+	}
+
+	fmt.Fprintln(a.stderr, "\nFlags:")
+	fs.PrintDefaults()
+}
+
+// flags returns the flag set for a subcommand. Output flags are accepted
+// after the subcommand as well.
+func (a *app) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("webex "+a.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	a.outputFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(a.stderr, strings.TrimRight("Usage: webex "+a.name+" "+a.usage, " "))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func (a *app) outputFlags(fs *flag.FlagSet) {
+	fs.StringVar(&a.output, "output", a.output, "output `format`: table, json or yaml")
+	fs.StringVar(&a.output, "o", a.output, "shorthand for -output")
+}
+
+// parse parses args and checks the number of positional arguments.
+func (a *app) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		fs.Usage()
+		return errUsage
+	}
+
+	if !validFormat(a.output) {
+		fmt.Fprintf(a.stderr, "webex: unknown output format %q\n", a.output)
+		return errUsage
+	}
+	return nil
+}
+
+// usageError prints msg and the usage of fs.
+func (a *app) usageError(fs *flag.FlagSet, msg string) error {
+	fmt.Fprintf(a.stderr, "webex: %s\n", msg)
+	fs.Usage()
+	return errUsage
+}
+
+// webex returns the API client, creating it on first use.
+func (a *app) webex() (*webexgosdk.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	creds, err := a.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := creds.BaseURL
+	if a.baseURL != "" {
+		baseURL = a.baseURL
+	}
+	if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	client, err := webexgosdk.NewClient(creds.Token, webexgosdk.ClientOptions{
+		BaseURL:   baseURL,
+		UserAgent: "webex-cli/" + webexgosdk.Version,
+		Retry:     webexgosdk.DefaultRetryPolicy(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.client = client
+	return client, nil
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAPI records requests and answers them from a table of canned
+// responses keyed by "METHOD /path".
+type fakeAPI struct {
+	server    *httptest.Server
+	responses map[string]any
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	tokens   []string
+}
+
+func newFakeAPI(t *testing.T, responses map[string]any) *fakeAPI {
+	t.Helper()
+
+	api := &fakeAPI{responses: responses}
+	api.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		api.mu.Lock()
+		api.requests = append(api.requests, r)
+		api.bodies = append(api.bodies, string(body))
+		api.tokens = append(api.tokens, r.Header.Get("Authorization"))
+		api.mu.Unlock()
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if response == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(api.server.Close)
+
+	return api
+}
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// run runs the CLI against api with env as the environment.
+func (api *fakeAPI) run(t *testing.T, env map[string]string, args ...string) result {
+	t.Helper()
+
+	if env == nil {
+		env = map[string]string{"WEBEX_ACCESS_TOKEN": "env-token"}
+	}
+	if _, ok := env[configEnvVar]; !ok {
+		env[configEnvVar] = filepath.Join(t.TempDir(), "missing.json")
+	}
+
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-base-url", api.server.URL}, args...)
+	code := run(context.Background(), args, strings.NewReader("from stdin\n"), &stdout, &stderr, func(key string) string {
+		return env[key]
+	})
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func TestRoomsListTable(t *testing.T) {
+	api := newFakeAPI(t, map[string]any{
+		"GET /rooms": map[string]any{"items": []map[string]any{
+			{"id": "r1", "title": "Design", "type": "group"},
+			{"id": "r2", "title": "Alice", "type": "direct"},
+		}},
+	})
+
+	res := api.run(t, nil, "rooms", "list", "-type", "group", "-max", "5")
+	if res.code != exitOK {
+		t.Fatalf("exit code %d: %s", res.code, res.stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(res.stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Design") {
+		t.Errorf("unexpected table:\n%s", res.stdout)
+	}
+
+	query := api.requests[0].URL.Query()
+	if query.Get("type") != "group" || query.Get("max") != "5" {
+		t.Errorf("unexpected query: %v", query)
+	}
+
+	if api.tokens[0] != "Bearer env-token" {
+		t.Errorf("expected token from the environment, got %q", api.tokens[0])
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	api := newFakeAPI(t, map[string]any{
+		"GET /people/me": map[string]any{
+			"id":          "p1",
+			"displayName": "Alice: Admin",
+			"emails":      []string{"alice@example.com"},
+			"addresses":   []map[string]any{{"type": "work", "country": "GB"}},
+		},
+	})
+
+	res := api.run(t, nil, "people", "me", "-o", "json")
+	var person map[string]any
+	if err := json.Unmarshal([]byte(res.stdout), &person); err != nil || person["id"] != "p1" {
+		t.Errorf("expected JSON person, got %q: %v", res.stdout, err)
+	}
+
+	res = api.run(t, nil, "-output", "yaml", "people", "me")
+	want := `id: p1
+emails:
+  - alice@example.com
+displayName: "Alice: Admin"
+addresses:
+  - type: work
+    country: GB
+`
+	if !strings.HasPrefix(res.stdout, want) {
+		t.Errorf("unexpected YAML:\n%s\nwant:\n%s", res.stdout, want)
+	}
+
+	res = api.run(t, nil, "people", "me", "-o", "xml")
+	if res.code != exitUsage {
+		t.Errorf("expected usage error for unknown format, got %d", res.code)
+	}
+}
+
+func TestMessagesSend(t *testing.T) {
+	api := newFakeAPI(t, map[string]any{
+		"POST /messages": map[string]any{"id": "m2", "roomId": "r1", "parentId": "m1"},
+	})
+
+	res := api.run(t, nil, "messages", "send", "-room", "r1", "-thread", "m1", "-markdown", "**hi**")
+	if res.code != exitOK {
+		t.Fatalf("exit code %d: %s", res.code, res.stderr)
+	}
+
+	var req map[string]any
+	json.Unmarshal([]byte(api.bodies[0]), &req)
+	if req["roomId"] != "r1" || req["parentId"] != "m1" || req["markdown"] != "**hi**" || req["text"] != nil {
+		t.Errorf("unexpected request: %v", req)
+	}
+
+	file := filepath.Join(t.TempDir(), "report.txt")
+	os.WriteFile(file, []byte("report body"), 0o644)
+
+	res = api.run(t, nil, "messages", "send", "-to", "bob@example.com", "-file", file, "-")
+	if res.code != exitOK {
+		t.Fatalf("exit code %d: %s", res.code, res.stderr)
+	}
+
+	if !strings.HasPrefix(api.requests[1].Header.Get("Content-Type"), "multipart/form-data") {
+		t.Errorf("expected multipart upload, got %q", api.requests[1].Header.Get("Content-Type"))
+	}
+	if !strings.Contains(api.bodies[1], "report body") || !strings.Contains(api.bodies[1], `filename="report.txt"`) {
+		t.Errorf("expected uploaded file, got %q", api.bodies[1])
+	}
+	if !strings.Contains(api.bodies[1], "from stdin") || !strings.Contains(api.bodies[1], "bob@example.com") {
+		t.Errorf("expected text from stdin and recipient, got %q", api.bodies[1])
+	}
+
+	res = api.run(t, nil, "messages", "send", "hello")
+	if res.code != exitUsage || !strings.Contains(res.stderr, "-room") {
+		t.Errorf("expected usage error without a destination, got %d: %s", res.code, res.stderr)
+	}
+}
+
+func TestProfileConfig(t *testing.T) {
+	api := newFakeAPI(t, map[string]any{
+		"GET /telephony/voiceMessages/summary": map[string]any{"newMessages": 2, "oldMessages": 5},
+	})
+
+	config := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(config, []byte(`{
+		"defaultProfile": "work",
+		"profiles": {
+			"work": {"token": "work-token"},
+			"home": {"token": "home-token"}
+		}
+	}`), 0o600)
+
+	res := api.run(t, map[string]string{configEnvVar: config}, "voicemail", "summary")
+	if res.code != exitOK || !strings.Contains(res.stdout, "NEW") {
+		t.Fatalf("exit code %d: %s%s", res.code, res.stdout, res.stderr)
+	}
+
+	env := map[string]string{configEnvVar: config, "WEBEX_ACCESS_TOKEN": "env-token"}
+	api.run(t, env, "-profile", "home", "voicemail", "summary")
+
+	if strings.Join(api.tokens, ",") != "Bearer work-token,Bearer home-token" {
+		t.Errorf("unexpected tokens: %v", api.tokens)
+	}
+
+	res = api.run(t, map[string]string{configEnvVar: config}, "-profile", "missing", "voicemail", "summary")
+	if res.code != exitError || !strings.Contains(res.stderr, `profile "missing"`) {
+		t.Errorf("expected missing profile error, got %d: %s", res.code, res.stderr)
+	}
+
+	res = api.run(t, map[string]string{}, "voicemail", "summary")
+	if res.code != exitError || !strings.Contains(res.stderr, "WEBEX_ACCESS_TOKEN") {
+		t.Errorf("expected missing token error, got %d: %s", res.code, res.stderr)
+	}
+}
+
+func TestWebhooksReconcileDryRun(t *testing.T) {
+	api := newFakeAPI(t, map[string]any{
+		"GET /webhooks": map[string]any{"items": []map[string]any{
+			{"id": "w1", "name": "old", "targetUrl": "https://old.example.com", "resource": "rooms", "event": "all", "status": "active"},
+		}},
+	})
+
+	file := filepath.Join(t.TempDir(), "webhooks.json")
+	os.WriteFile(file, []byte(`[{"name":"bot","targetUrl":"https://bot.example.com","resource":"messages","event":"created"}]`), 0o644)
+
+	res := api.run(t, nil, "webhooks", "reconcile", "-dry-run", "-prune", file)
+	if res.code != exitOK {
+		t.Fatalf("exit code %d: %s", res.code, res.stderr)
+	}
+
+	if !strings.Contains(res.stdout, "create") || !strings.Contains(res.stdout, "delete") {
+		t.Errorf("expected create and delete in plan:\n%s", res.stdout)
+	}
+
+	for _, r := range api.requests {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestCallingAndMeetings(t *testing.T) {
+	api := newFakeAPI(t, map[string]any{
+		"POST /telephony/calls/dial":   map[string]any{"callId": "c1", "callSessionId": "s1"},
+		"POST /telephony/calls/hangup": nil,
+		"POST /meetings":               map[string]any{"id": "mt1", "title": "Standup"},
+		"POST /meetings/join":          map[string]any{"joinLink": "https://example.webex.com/join/mt1"},
+	})
+
+	if res := api.run(t, nil, "calls", "dial", "+15551234"); res.code != exitOK || !strings.Contains(res.stdout, "c1") {
+		t.Errorf("unexpected dial result %d: %s%s", res.code, res.stdout, res.stderr)
+	}
+
+	if res := api.run(t, nil, "calls", "hangup", "c1"); res.code != exitOK || !strings.Contains(api.bodies[1], `"callId":"c1"`) {
+		t.Errorf("unexpected hangup result %d: %s", res.code, res.stderr)
+	}
+
+	res := api.run(t, nil, "meetings", "create", "-start", "2024-01-02T10:00:00Z", "-duration", "15m", "Standup")
+	if res.code != exitOK {
+		t.Fatalf("exit code %d: %s", res.code, res.stderr)
+	}
+
+	var req map[string]any
+	json.Unmarshal([]byte(api.bodies[2]), &req)
+	if req["title"] != "Standup" || req["end"] != "2024-01-02T10:15:00Z" {
+		t.Errorf("unexpected meeting request: %v", req)
+	}
+
+	if res := api.run(t, nil, "meetings", "join", "mt1"); strings.TrimSpace(res.stdout) != "https://example.webex.com/join/mt1" {
+		t.Errorf("unexpected join output %q: %s", res.stdout, res.stderr)
+	}
+
+	if res := api.run(t, nil, "meetings", "create", "-start", "tomorrow", "Standup"); res.code != exitUsage {
+		t.Errorf("expected usage error for invalid time, got %d", res.code)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/meeting"
+)
+
+var meetingsCommands = map[string]command{
+	"list":   {usage: "[-from time] [-to time] [-state state] [-max n]", run: meetingsList},
+	"create": {usage: "-start time [-end time | -duration d] [-agenda text] [-password p] [-timezone tz] <title>", run: meetingsCreate},
+	"join":   {usage: "[-email address] [-name display-name] <meeting-id>", run: meetingsJoin},
+}
+
+var meetingColumns = []column[*meeting.Meeting]{
+	{"ID", func(m *meeting.Meeting) string { return m.ID }},
+	{"NUMBER", func(m *meeting.Meeting) string { return m.MeetingNumber }},
+	{"TITLE", func(m *meeting.Meeting) string { return m.Title }},
+	{"START", func(m *meeting.Meeting) string { return formatTime(m.Start) }},
+	{"END", func(m *meeting.Meeting) string { return formatTime(m.End) }},
+	{"STATE", func(m *meeting.Meeting) string { return m.State.String() }},
+}
+
+// timeFlag is a flag holding an RFC 3339 time.
+type timeFlag struct {
+	t *time.Time
+}
+
+func (f timeFlag) String() string {
+	if f.t == nil || f.t.IsZero() {
+		return ""
+	}
+	return f.t.Format(time.RFC3339)
+}
+
+func (f timeFlag) Set(value string) error {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("expected an RFC 3339 time such as 2024-01-02T15:04:05Z")
+	}
+	*f.t = t
+	return nil
+}
+
+func meetingsList(ctx context.Context, a *app, args []string) error {
+	var opts meeting.MeetingListOptions
+	fs := a.flags()
+	fs.Var(timeFlag{&opts.From}, "from", "list meetings starting at or after this `time`")
+	fs.Var(timeFlag{&opts.To}, "to", "list meetings starting before this `time`")
+	fs.StringVar((*string)(&opts.State), "state", "", "list only meetings in this `state`, such as scheduled or ended")
+	fs.IntVar(&opts.Max, "max", 0, "maximum number of meetings")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	meetings, err := client.Meeting.Meetings.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to list meetings: %w", err)
+	}
+	return printList(a, meetings, meetingColumns)
+}
+
+func meetingsCreate(ctx context.Context, a *app, args []string) error {
+	var (
+		req      meeting.MeetingCreateRequest
+		duration time.Duration
+	)
+	fs := a.flags()
+	fs.Var(timeFlag{&req.Start}, "start", "start `time`")
+	fs.Var(timeFlag{&req.End}, "end", "end `time`")
+	fs.DurationVar(&duration, "duration", 30*time.Minute, "meeting length when -end is not set")
+	fs.StringVar(&req.Agenda, "agenda", "", "meeting agenda")
+	fs.StringVar(&req.Password, "password", "", "meeting password")
+	fs.StringVar(&req.Timezone, "timezone", "", "time zone such as Europe/London")
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	req.Title = fs.Arg(0)
+
+	if req.Start.IsZero() {
+		return a.usageError(fs, "-start is required")
+	}
+	if req.End.IsZero() {
+		req.End = req.Start.Add(duration)
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	m, err := client.Meeting.Meetings.Create(ctx, &req)
+	if err != nil {
+		return fmt.Errorf("failed to create meeting: %w", err)
+	}
+	return printItem(a, m, meetingColumns)
+}
+
+func meetingsJoin(ctx context.Context, a *app, args []string) error {
+	var email, name string
+	fs := a.flags()
+	fs.StringVar(&email, "email", "", "join as the guest with this email `address`")
+	fs.StringVar(&name, "name", "", "display name of the guest")
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	info, err := client.Meeting.Meetings.Join(ctx, fs.Arg(0), email, name)
+	if err != nil {
+		return fmt.Errorf("failed to join meeting: %w", err)
+	}
+	return a.printText(info.JoinLink, info)
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+var roomsCommands = map[string]command{
+	"list":   {usage: "[-type group|direct] [-team id] [-max n]", run: roomsList},
+	"create": {usage: "[-team id] [-description text] <title>", run: roomsCreate},
+	"delete": {usage: "<room-id>", run: roomsDelete},
+}
+
+var roomColumns = []column[*messaging.Room]{
+	{"ID", func(r *messaging.Room) string { return r.ID }},
+	{"TITLE", func(r *messaging.Room) string { return r.Title }},
+	{"TYPE", func(r *messaging.Room) string { return r.Type.String() }},
+	{"LAST ACTIVITY", func(r *messaging.Room) string { return formatTime(r.LastActivity) }},
+}
+
+func roomsList(ctx context.Context, a *app, args []string) error {
+	var opts messaging.RoomListOptions
+	fs := a.flags()
+	fs.StringVar((*string)(&opts.Type), "type", "", "list only `group` or direct rooms")
+	fs.StringVar(&opts.TeamID, "team", "", "list rooms of the team with this `id`")
+	fs.IntVar(&opts.Max, "max", 0, "maximum number of rooms")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	rooms, err := client.Messaging.Rooms.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to list rooms: %w", err)
+	}
+	return printList(a, rooms, roomColumns)
+}
+
+func roomsCreate(ctx context.Context, a *app, args []string) error {
+	var req messaging.RoomCreateRequest
+	fs := a.flags()
+	fs.StringVar(&req.TeamID, "team", "", "create the room in the team with this `id`")
+	fs.StringVar(&req.Description, "description", "", "room description")
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	req.Title = fs.Arg(0)
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	room, err := client.Messaging.Rooms.Create(ctx, &req)
+	if err != nil {
+		return fmt.Errorf("failed to create room: %w", err)
+	}
+	return printItem(a, room, roomColumns)
+}
+
+func roomsDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	if err := client.Messaging.Rooms.Delete(ctx, fs.Arg(0)); err != nil {
+		return fmt.Errorf("failed to delete room: %w", err)
+	}
+	return a.printText("Deleted room "+fs.Arg(0), map[string]string{"deleted": fs.Arg(0)})
+}
+
+var messagesCommands = map[string]command{
+	"send":   {usage: "(-room id | -to email | -person id) [-markdown] [-thread id] [-file path] [text | -]", run: messagesSend},
+	"list":   {usage: "-room id [-thread id] [-max n]", run: messagesList},
+	"delete": {usage: "<message-id>", run: messagesDelete},
+}
+
+var messageColumns = []column[*messaging.Message]{
+	{"ID", func(m *messaging.Message) string { return m.ID }},
+	{"CREATED", func(m *messaging.Message) string { return formatTime(m.Created) }},
+	{"FROM", func(m *messaging.Message) string { return m.PersonEmail }},
+	{"THREAD", func(m *messaging.Message) string { return m.ParentID }},
+	{"TEXT", func(m *messaging.Message) string {
+		if m.Text == "" && len(m.Files) > 0 {
+			return fmt.Sprintf("(%d files)", len(m.Files))
+		}
+		return m.Text
+	}},
+}
+
+func messagesSend(ctx context.Context, a *app, args []string) error {
+	var (
+		req      messaging.MessageCreateRequest
+		markdown bool
+		file     string
+	)
+	fs := a.flags()
+	fs.StringVar(&req.RoomID, "room", "", "send to the room with this `id`")
+	fs.StringVar(&req.ToPersonEmail, "to", "", "send a direct message to this `email`")
+	fs.StringVar(&req.ToPersonID, "person", "", "send a direct message to the person with this `id`")
+	fs.StringVar(&req.ParentID, "thread", "", "reply in the thread of the message with this `id`")
+	fs.BoolVar(&markdown, "markdown", false, "send the text as markdown")
+	fs.StringVar(&file, "file", "", "attach the file at `path`")
+	if err := a.parse(fs, args, 0, 1); err != nil {
+		return err
+	}
+
+	if req.RoomID == "" && req.ToPersonEmail == "" && req.ToPersonID == "" {
+		return a.usageError(fs, "one of -room, -to or -person is required")
+	}
+
+	text := fs.Arg(0)
+	if text == "-" {
+		data, err := io.ReadAll(a.stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+		text = strings.TrimSuffix(string(data), "\n")
+	}
+
+	if text == "" && file == "" {
+		return a.usageError(fs, "message text or -file is required")
+	}
+
+	if markdown {
+		req.Markdown = text
+	} else {
+		req.Text = text
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	var message *messaging.Message
+	if file == "" {
+		message, err = client.Messaging.Messages.Create(ctx, &req)
+	} else {
+		var f *os.File
+		if f, err = os.Open(file); err != nil {
+			return err
+		}
+		defer f.Close()
+
+		message, err = client.Messaging.Messages.CreateWithFile(ctx, &req, []messaging.MessageFile{
+			{Name: filepath.Base(file), Reader: f},
+		}, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return printItem(a, message, messageColumns)
+}
+
+func messagesList(ctx context.Context, a *app, args []string) error {
+	var opts messaging.MessageListOptions
+	fs := a.flags()
+	fs.StringVar(&opts.RoomID, "room", "", "list messages of the room with this `id`")
+	fs.StringVar(&opts.ParentID, "thread", "", "list the replies to the message with this `id`")
+	fs.IntVar(&opts.Max, "max", 50, "maximum number of messages")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if opts.RoomID == "" {
+		return a.usageError(fs, "-room is required")
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	messages, err := client.Messaging.Messages.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to list messages: %w", err)
+	}
+	return printList(a, messages, messageColumns)
+}
+
+func messagesDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	if err := client.Messaging.Messages.Delete(ctx, fs.Arg(0)); err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+	return a.printText("Deleted message "+fs.Arg(0), map[string]string{"deleted": fs.Arg(0)})
+}
+
+var peopleCommands = map[string]command{
+	"me":     {usage: "", run: peopleMe},
+	"search": {usage: "(-email address | -name prefix) [-max n]", run: peopleSearch},
+}
+
+var personColumns = []column[*messaging.Person]{
+	{"ID", func(p *messaging.Person) string { return p.ID }},
+	{"NAME", func(p *messaging.Person) string { return p.DisplayName }},
+	{"EMAIL", func(p *messaging.Person) string { return strings.Join(p.Emails, ", ") }},
+	{"STATUS", func(p *messaging.Person) string { return p.Status }},
+}
+
+func peopleMe(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	me, err := client.Messaging.People.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your details: %w", err)
+	}
+	return printItem(a, me, personColumns)
+}
+
+func peopleSearch(ctx context.Context, a *app, args []string) error {
+	var opts messaging.PeopleListOptions
+	fs := a.flags()
+	fs.StringVar(&opts.Email, "email", "", "find the person with this email `address`")
+	fs.StringVar(&opts.DisplayName, "name", "", "find people whose display name starts with `prefix`")
+	fs.IntVar(&opts.Max, "max", 0, "maximum number of people")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if opts.Email == "" && opts.DisplayName == "" {
+		return a.usageError(fs, "-email or -name is required")
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	people, err := client.Messaging.People.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to search people: %w", err)
+	}
+	return printList(a, people, personColumns)
+}
+
+var webhooksCommands = map[string]command{
+	"list":      {usage: "[-max n]", run: webhooksList},
+	"create":    {usage: "-name name -target url -resource resource -event event [-filter filter] [-secret secret]", run: webhooksCreate},
+	"reconcile": {usage: "[-dry-run] [-prune] <webhooks.json>", run: webhooksReconcile},
+}
+
+var webhookColumns = []column[*messaging.Webhook]{
+	{"ID", func(w *messaging.Webhook) string { return w.ID }},
+	{"NAME", func(w *messaging.Webhook) string { return w.Name }},
+	{"RESOURCE", func(w *messaging.Webhook) string { return w.Resource.String() }},
+	{"EVENT", func(w *messaging.Webhook) string { return w.Event.String() }},
+	{"STATUS", func(w *messaging.Webhook) string { return w.Status.String() }},
+	{"TARGET", func(w *messaging.Webhook) string { return w.TargetURL }},
+}
+
+func webhooksList(ctx context.Context, a *app, args []string) error {
+	var opts messaging.WebhookListOptions
+	fs := a.flags()
+	fs.IntVar(&opts.Max, "max", 0, "maximum number of webhooks")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := client.Messaging.Webhooks.List(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return printList(a, webhooks, webhookColumns)
+}
+
+func webhooksCreate(ctx context.Context, a *app, args []string) error {
+	var req messaging.WebhookCreateRequest
+	fs := a.flags()
+	fs.StringVar(&req.Name, "name", "", "webhook name")
+	fs.StringVar(&req.TargetURL, "target", "", "`url` that receives the events")
+	fs.StringVar((*string)(&req.Resource), "resource", "", "resource such as messages or rooms")
+	fs.StringVar((*string)(&req.Event), "event", "", "event such as created or all")
+	fs.StringVar(&req.Filter, "filter", "", "event filter, for example roomId=...")
+	fs.StringVar(&req.Secret, "secret", "", "secret used to sign the events")
+	if err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if req.Name == "" || req.TargetURL == "" || req.Resource == "" || req.Event == "" {
+		return a.usageError(fs, "-name, -target, -resource and -event are required")
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	webhook, err := client.Messaging.Webhooks.Create(ctx, &req)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return printItem(a, webhook, webhookColumns)
+}
+
+func webhooksReconcile(ctx context.Context, a *app, args []string) error {
+	var opts messaging.WebhookReconcileOptions
+	fs := a.flags()
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "print the changes without applying them")
+	fs.BoolVar(&opts.DeleteUnmanaged, "prune", false, "delete webhooks missing from the file")
+	if err := a.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var desired []messaging.WebhookCreateRequest
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", fs.Arg(0), err)
+	}
+
+	client, err := a.webex()
+	if err != nil {
+		return err
+	}
+
+	plan, err := client.Messaging.Webhooks.Reconcile(ctx, desired, &opts)
+	// A plan that failed part-way still shows what was applied.
+	if plan != nil {
+		if err := a.printText(plan.String(), plan); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reconcile webhooks: %w", err)
+	}
+	return nil
+}
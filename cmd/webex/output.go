@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/yamlout"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+func validFormat(format string) bool {
+	return format == formatTable || format == formatJSON || format == formatYAML
+}
+
+// column is a table column rendered from a value of type T.
+type column[T any] struct {
+	header string
+	value  func(T) string
+}
+
+// printList writes items as a table with cols, or encodes them as JSON or
+// YAML.
+func printList[T any](a *app, items []T, cols []column[T]) error {
+	if a.output != formatTable {
+		if items == nil {
+			items = []T{}
+		}
+		return a.encode(items)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, item := range items {
+		values := make([]string, len(cols))
+		for i, col := range cols {
+			values[i] = cell(col.value(item))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printItem writes a single value as a one-row table, JSON or YAML.
+func printItem[T any](a *app, item T, cols []column[T]) error {
+	if a.output != formatTable {
+		return a.encode(item)
+	}
+	return printList(a, []T{item}, cols)
+}
+
+// printText writes text in table mode, and v otherwise.
+func (a *app) printText(text string, v any) error {
+	if a.output != formatTable {
+		return a.encode(v)
+	}
+	_, err := fmt.Fprintln(a.stdout, text)
+	return err
+}
+
+func (a *app) encode(v any) error {
+	if a.output == formatYAML {
+		return yamlout.Write(a.stdout, v)
+	}
+
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// maxCellWidth bounds free-text columns such as message bodies.
+const maxCellWidth = 60
+
+// cell flattens s onto one line and truncates it for a table.
+func cell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len([]rune(s)) > maxCellWidth {
+		s = string([]rune(s)[:maxCellWidth-1]) + "…"
+	}
+	return s
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,11 @@
+// Package export archives the message history of Webex rooms.
+// It walks a room backwards page by page into a resumable spool, rebuilds
+// threads, resolves authors and writes JSON Lines, HTML and Markdown
+// transcripts, optionally with the attached files.
+
+package export
+
+// This file serves as the package documentation for the export package.
+// All types are defined in their respective files:
+// - export.go: Exporter, options, checkpointing and history collection.
+// - render.go: Thread reconstruction and transcript writers.
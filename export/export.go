@@ -0,0 +1,389 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+type Format string
+
+const (
+	FormatJSONL    Format = "jsonl"
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+)
+
+const (
+	CheckpointFile = "checkpoint.json"
+	SpoolFile      = "spool.jsonl"
+	FilesDir       = "files"
+
+	DefaultPageSize = 100
+
+	// maxScanWaits bounds how often a download waits for Webex to finish
+	// scanning a file.
+	maxScanWaits = 5
+)
+
+var (
+	ErrCheckpointMismatch = errors.New("checkpoint belongs to a different room")
+)
+
+type Options struct {
+	// Dir receives the transcripts, downloaded files and the checkpoint.
+	Dir string
+
+	// Formats lists the transcripts to write. Defaults to FormatJSONL.
+	Formats []Format
+
+	// DownloadFiles saves attached files under Dir/files/<message ID>.
+	DownloadFiles bool
+
+	// PageSize is the number of messages fetched per request. Defaults to
+	// DefaultPageSize.
+	PageSize int
+
+	// Restart discards an existing checkpoint instead of resuming from it.
+	Restart bool
+
+	// Progress is called after every page with the number of messages
+	// collected so far.
+	Progress func(collected int)
+}
+
+// Entry is an exported message.
+type Entry struct {
+	*messaging.Message
+
+	// AuthorName is the display name of the sender, or the sender's email
+	// when the person cannot be resolved.
+	AuthorName string `json:"authorName,omitempty"`
+
+	// LocalFiles are the downloaded files relative to the export directory.
+	LocalFiles []string `json:"localFiles,omitempty"`
+
+	// Replies are the thread replies of a top-level message.
+	Replies []*Entry `json:"-"`
+}
+
+// Checkpoint records the progress of collecting a room's history. The spool
+// is truncated to Offset on resume, so a page is either fully recorded or
+// fetched again.
+type Checkpoint struct {
+	RoomID        string    `json:"roomId"`
+	BeforeMessage string    `json:"beforeMessage,omitempty"`
+	Offset        int64     `json:"offset"`
+	Collected     int       `json:"collected"`
+	Complete      bool      `json:"complete"`
+	Updated       time.Time `json:"updated"`
+}
+
+// Result describes a finished export.
+type Result struct {
+	Messages int
+	Files    int
+
+	// Outputs maps each written format to its file.
+	Outputs map[Format]string
+}
+
+// Exporter archives the history of rooms.
+type Exporter struct {
+	client  *webexgosdk.Client
+	options Options
+	people  map[string]string
+}
+
+func New(client *webexgosdk.Client, opts *Options) *Exporter {
+	e := &Exporter{
+		client: client,
+		people: make(map[string]string),
+	}
+
+	if opts != nil {
+		e.options = *opts
+	}
+
+	if len(e.options.Formats) == 0 {
+		e.options.Formats = []Format{FormatJSONL}
+	}
+
+	if e.options.PageSize <= 0 {
+		e.options.PageSize = DefaultPageSize
+	}
+
+	return e
+}
+
+// Export walks the room's history from the newest message backwards,
+// recording every page in the spool, and then writes the transcripts. An
+// interrupted export resumes from its checkpoint when run again.
+func (e *Exporter) Export(ctx context.Context, roomID string) (*Result, error) {
+	switch {
+	case roomID == "":
+		return nil, webexgosdk.InvalidParameter("Exporter.Export", "roomID")
+	case e.options.Dir == "":
+		return nil, webexgosdk.InvalidParameter("Exporter.Export", "Options.Dir")
+	}
+
+	if err := os.MkdirAll(e.options.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	checkpoint, err := e.loadCheckpoint(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !checkpoint.Complete {
+		if err := e.collect(ctx, checkpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := e.readSpool()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.render(ctx, roomID, entries)
+}
+
+func (e *Exporter) path(name string) string {
+	return filepath.Join(e.options.Dir, name)
+}
+
+func (e *Exporter) loadCheckpoint(roomID string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{RoomID: roomID}
+	if e.options.Restart {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(e.path(CheckpointFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+
+	if checkpoint.RoomID != roomID {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointMismatch, checkpoint.RoomID)
+	}
+
+	return checkpoint, nil
+}
+
+// saveCheckpoint replaces the checkpoint file atomically.
+func (e *Exporter) saveCheckpoint(checkpoint *Checkpoint) error {
+	checkpoint.Updated = time.Now().UTC()
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := e.path(CheckpointFile + ".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp, e.path(CheckpointFile)); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// collect fetches the pages not yet recorded in the spool.
+func (e *Exporter) collect(ctx context.Context, checkpoint *Checkpoint) error {
+	spool, err := os.OpenFile(e.path(SpoolFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open spool: %w", err)
+	}
+	defer spool.Close()
+
+	// Drop anything written after the last checkpoint.
+	if err := spool.Truncate(checkpoint.Offset); err != nil {
+		return fmt.Errorf("failed to truncate spool: %w", err)
+	}
+	if _, err := spool.Seek(checkpoint.Offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek spool: %w", err)
+	}
+
+	pager, err := e.client.Messaging.Messages.ListPages(&messaging.MessageListOptions{
+		RoomID:        checkpoint.RoomID,
+		BeforeMessage: checkpoint.BeforeMessage,
+		Max:           e.options.PageSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for pager.HasMore() {
+		messages, err := pager.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := bufio.NewWriter(spool)
+		enc := json.NewEncoder(w)
+		for _, message := range messages {
+			entry := &Entry{Message: message}
+			if e.options.DownloadFiles {
+				if entry.LocalFiles, err = e.downloadFiles(ctx, message); err != nil {
+					return err
+				}
+			}
+
+			if err := enc.Encode(entry); err != nil {
+				return fmt.Errorf("failed to write spool: %w", err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to write spool: %w", err)
+		}
+
+		offset, err := spool.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fmt.Errorf("failed to seek spool: %w", err)
+		}
+
+		checkpoint.Offset = offset
+		checkpoint.Collected += len(messages)
+		if len(messages) > 0 {
+			checkpoint.BeforeMessage = messages[len(messages)-1].ID
+		}
+		checkpoint.Complete = !pager.HasMore()
+		if err := e.saveCheckpoint(checkpoint); err != nil {
+			return err
+		}
+
+		if e.options.Progress != nil {
+			e.options.Progress(checkpoint.Collected)
+		}
+	}
+
+	if !checkpoint.Complete {
+		checkpoint.Complete = true
+		return e.saveCheckpoint(checkpoint)
+	}
+	return nil
+}
+
+func (e *Exporter) readSpool() ([]*Entry, error) {
+	spool, err := os.Open(e.path(SpoolFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spool: %w", err)
+	}
+	defer spool.Close()
+
+	var entries []*Entry
+	seen := make(map[string]bool)
+	dec := json.NewDecoder(spool)
+	for {
+		var entry Entry
+		if err := dec.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read spool: %w", err)
+		}
+
+		if entry.Message == nil || seen[entry.ID] {
+			continue
+		}
+		seen[entry.ID] = true
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// downloadFiles saves the files of message and returns their paths relative
+// to the export directory.
+func (e *Exporter) downloadFiles(ctx context.Context, message *messaging.Message) ([]string, error) {
+	if len(message.Files) == 0 {
+		return nil, nil
+	}
+
+	dir := filepath.Join(FilesDir, message.ID)
+	if err := os.MkdirAll(e.path(dir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create files directory: %w", err)
+	}
+
+	paths := make([]string, 0, len(message.Files))
+	for i, fileURL := range message.Files {
+		name, err := e.downloadFile(ctx, fileURL, e.path(dir), i)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, filepath.ToSlash(filepath.Join(dir, name)))
+	}
+
+	return paths, nil
+}
+
+// downloadFile downloads fileURL into dir and returns the file name. Files
+// still being scanned are retried after the delay Webex asks for.
+func (e *Exporter) downloadFile(ctx context.Context, fileURL, dir string, index int) (string, error) {
+	for waits := 0; ; waits++ {
+		tmp, err := os.CreateTemp(dir, ".download-*")
+		if err != nil {
+			return "", fmt.Errorf("failed to create file: %w", err)
+		}
+
+		info, err := e.client.Messaging.Files.Download(ctx, fileURL, tmp)
+		tmp.Close()
+		if err == nil {
+			// Prefix the index so that files with the same name do not clash.
+			name := fmt.Sprintf("%d-%s", index+1, info.Name)
+			if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+				os.Remove(tmp.Name())
+				return "", fmt.Errorf("failed to save file: %w", err)
+			}
+			return name, nil
+		}
+		os.Remove(tmp.Name())
+
+		var scanning *webexgosdk.FileScanningError
+		if !errors.As(err, &scanning) || waits >= maxScanWaits {
+			return "", err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(scanning.RetryAfter):
+		}
+	}
+}
+
+// authorName resolves a person ID to a display name, caching the result.
+func (e *Exporter) authorName(ctx context.Context, message *messaging.Message) string {
+	if name, ok := e.people[message.PersonID]; ok {
+		return name
+	}
+
+	name := message.PersonEmail
+	if message.PersonID != "" {
+		if person, err := e.client.Messaging.People.Get(ctx, message.PersonID); err == nil && person.DisplayName != "" {
+			name = person.DisplayName
+		}
+	}
+
+	e.people[message.PersonID] = name
+	return name
+}
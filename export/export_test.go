@@ -0,0 +1,181 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+// fakeRoom serves a room history of five messages, newest first, two per
+// page. failPage makes the request for that page fail once.
+type fakeRoom struct {
+	server   *httptest.Server
+	failPage atomic.Int32
+	lookups  atomic.Int32
+}
+
+func newFakeRoom(t *testing.T) *fakeRoom {
+	t.Helper()
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	history := []*messaging.Message{
+		{ID: "m5", RoomID: "room-1", PersonID: "bob", Text: "done", Created: start.Add(5 * time.Minute)},
+		{ID: "m4", RoomID: "room-1", PersonID: "alice", ParentID: "m1", Text: "reply <b>", Created: start.Add(4 * time.Minute)},
+		{ID: "m3", RoomID: "room-1", PersonID: "gone", PersonEmail: "gone@example.com", Text: "hi", Created: start.Add(3 * time.Minute)},
+		{ID: "m2", RoomID: "room-1", PersonID: "bob", Files: []string{"/contents/f1"}, Created: start.Add(2 * time.Minute)},
+		{ID: "m1", RoomID: "room-1", PersonID: "alice", Text: "first", Created: start.Add(1 * time.Minute)},
+	}
+
+	room := &fakeRoom{}
+	room.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/messages":
+			from := 0
+			if before := r.URL.Query().Get("beforeMessage"); before != "" {
+				for i, message := range history {
+					if message.ID == before {
+						from = i + 1
+					}
+				}
+			}
+
+			page := int32(from/2 + 1)
+			if room.failPage.CompareAndSwap(page, 0) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			to := min(from+2, len(history))
+			if to < len(history) {
+				w.Header().Set("Link", fmt.Sprintf(`<%s/messages?roomId=room-1&beforeMessage=%s>; rel="next"`, room.server.URL, history[to-1].ID))
+			}
+			json.NewEncoder(w).Encode(map[string]any{"items": history[from:to]})
+		case strings.HasPrefix(r.URL.Path, "/people/"):
+			room.lookups.Add(1)
+			id := strings.TrimPrefix(r.URL.Path, "/people/")
+			if id == "gone" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(&messaging.Person{ID: id, DisplayName: strings.ToUpper(id[:1]) + id[1:]})
+		case r.URL.Path == "/contents/f1":
+			w.Header().Set("Content-Disposition", `attachment; filename="plan.txt"`)
+			w.Write([]byte("the plan"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(room.server.Close)
+
+	return room
+}
+
+func (room *fakeRoom) client(t *testing.T) *webexgosdk.Client {
+	t.Helper()
+
+	client, err := webexgosdk.NewClient("test-token", webexgosdk.ClientOptions{BaseURL: room.server.URL + "/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestExportResumesFromCheckpoint(t *testing.T) {
+	room := newFakeRoom(t)
+	dir := t.TempDir()
+	opts := &Options{
+		Dir:           dir,
+		Formats:       []Format{FormatJSONL, FormatHTML, FormatMarkdown},
+		DownloadFiles: true,
+		PageSize:      2,
+	}
+
+	room.failPage.Store(2)
+	if _, err := New(room.client(t), opts).Export(context.Background(), "room-1"); err == nil {
+		t.Fatal("expected the interrupted export to fail")
+	}
+
+	var checkpoint Checkpoint
+	data, _ := os.ReadFile(filepath.Join(dir, CheckpointFile))
+	json.Unmarshal(data, &checkpoint)
+	if checkpoint.Collected != 2 || checkpoint.BeforeMessage != "m4" || checkpoint.Complete {
+		t.Fatalf("unexpected checkpoint: %+v", checkpoint)
+	}
+
+	result, err := New(room.client(t), opts).Export(context.Background(), "room-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Messages != 5 || result.Files != 1 {
+		t.Errorf("expected 5 messages and 1 file, got %+v", result)
+	}
+
+	if room.lookups.Load() != 3 {
+		t.Errorf("expected 3 cached person lookups, got %d", room.lookups.Load())
+	}
+
+	f, err := os.Open(result.Outputs[FormatJSONL])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	var ids, authors []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, entry.ID)
+		authors = append(authors, entry.AuthorName)
+	}
+
+	if strings.Join(ids, ",") != "m1,m2,m3,m4,m5" {
+		t.Errorf("expected chronological messages without duplicates, got %v", ids)
+	}
+
+	if strings.Join(authors, ",") != "Alice,Bob,gone@example.com,Alice,Bob" {
+		t.Errorf("unexpected authors: %v", authors)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "files", "m2", "1-plan.txt")); err != nil || string(data) != "the plan" {
+		t.Errorf("expected downloaded file, got %q, %v", data, err)
+	}
+
+	markdown, _ := os.ReadFile(result.Outputs[FormatMarkdown])
+	if !strings.Contains(string(markdown), "first\n\n> **Alice**") || !strings.Contains(string(markdown), "- [1-plan.txt](files/m2/1-plan.txt)") {
+		t.Errorf("expected threaded reply and file link in markdown:\n%s", markdown)
+	}
+
+	html, _ := os.ReadFile(result.Outputs[FormatHTML])
+	if !strings.Contains(string(html), `<div class="replies">`) || !strings.Contains(string(html), "reply &lt;b&gt;") {
+		t.Errorf("expected escaped threaded reply in html:\n%s", html)
+	}
+}
+
+func TestExportRejectsCheckpointOfOtherRoom(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, CheckpointFile), []byte(`{"roomId":"room-2"}`), 0o644)
+
+	room := newFakeRoom(t)
+	_, err := New(room.client(t), &Options{Dir: dir}).Export(context.Background(), "room-1")
+	if !errors.Is(err, ErrCheckpointMismatch) {
+		t.Errorf("expected ErrCheckpointMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+var outputFiles = map[Format]string{
+	FormatJSONL:    "messages.jsonl",
+	FormatHTML:     "transcript.html",
+	FormatMarkdown: "transcript.md",
+}
+
+// render resolves authors, rebuilds threads and writes every requested
+// format.
+func (e *Exporter) render(ctx context.Context, roomID string, entries []*Entry) (*Result, error) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Created.Before(entries[j].Created)
+	})
+
+	result := &Result{
+		Messages: len(entries),
+		Outputs:  make(map[Format]string),
+	}
+
+	for _, entry := range entries {
+		entry.AuthorName = e.authorName(ctx, entry.Message)
+		result.Files += len(entry.LocalFiles)
+	}
+
+	threads := buildThreads(entries)
+	for _, format := range e.options.Formats {
+		name, ok := outputFiles[format]
+		if !ok {
+			return nil, fmt.Errorf("unknown export format %q", format)
+		}
+
+		err := e.writeFile(name, func(w io.Writer) error {
+			switch format {
+			case FormatJSONL:
+				return writeJSONL(w, entries)
+			case FormatHTML:
+				return writeHTML(w, roomID, threads)
+			default:
+				return writeMarkdown(w, roomID, threads)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result.Outputs[format] = e.path(name)
+	}
+
+	return result, nil
+}
+
+func (e *Exporter) writeFile(name string, write func(w io.Writer) error) error {
+	f, err := os.Create(e.path(name))
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+
+	w := bufio.NewWriter(f)
+	if err := write(w); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+// buildThreads returns the top-level messages in chronological order with
+// their replies attached. Replies whose parent was not exported are kept as
+// top-level messages.
+func buildThreads(entries []*Entry) []*Entry {
+	byID := make(map[string]*Entry, len(entries))
+	messages := make([]*messaging.Message, len(entries))
+	for i, entry := range entries {
+		entry.Replies = nil
+		byID[entry.ID] = entry
+		messages[i] = entry.Message
+	}
+
+	var threads []*Entry
+	for _, thread := range messaging.NewConversation(messages).Threads {
+		if thread.Orphaned() {
+			for _, reply := range thread.Replies {
+				threads = append(threads, byID[reply.ID])
+			}
+			continue
+		}
+
+		root := byID[thread.RootID]
+		for _, reply := range thread.Replies {
+			root.Replies = append(root.Replies, byID[reply.ID])
+		}
+		threads = append(threads, root)
+	}
+	return threads
+}
+
+func writeJSONL(w io.Writer, entries []*Entry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// body returns the message content, preferring markdown.
+func (e *Entry) body() string {
+	if e.Markdown != "" {
+		return e.Markdown
+	}
+	return e.Text
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
+
+func writeMarkdown(w io.Writer, roomID string, threads []*Entry) error {
+	fmt.Fprintf(w, "# Room %s\n", roomID)
+
+	var write func(entry *Entry, depth int)
+	write = func(entry *Entry, depth int) {
+		prefix := strings.Repeat("> ", depth)
+		fmt.Fprintf(w, "\n%s**%s** · %s\n", prefix, entry.AuthorName, formatTime(entry.Created))
+
+		if body := entry.body(); body != "" {
+			fmt.Fprintf(w, "%s\n", prefix)
+			for _, line := range strings.Split(body, "\n") {
+				fmt.Fprintf(w, "%s%s\n", prefix, line)
+			}
+		}
+
+		for _, file := range entry.files() {
+			fmt.Fprintf(w, "%s- [%s](%s)\n", prefix, file.Name, file.Href)
+		}
+
+		for _, reply := range entry.Replies {
+			write(reply, depth+1)
+		}
+	}
+
+	for _, entry := range threads {
+		write(entry, 0)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type fileLink struct {
+	Name string
+	Href string
+}
+
+// files links downloaded files, or the remote URLs when files were not
+// downloaded.
+func (e *Entry) files() []fileLink {
+	if len(e.LocalFiles) > 0 {
+		links := make([]fileLink, len(e.LocalFiles))
+		for i, path := range e.LocalFiles {
+			links[i] = fileLink{Name: path[strings.LastIndex(path, "/")+1:], Href: path}
+		}
+		return links
+	}
+
+	links := make([]fileLink, len(e.Message.Files))
+	for i, url := range e.Message.Files {
+		links[i] = fileLink{Name: fmt.Sprintf("file %d", i+1), Href: url}
+	}
+	return links
+}
+
+var htmlTranscript = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"time": formatTime,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Room {{.RoomID}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 52rem; margin: 2rem auto; color: #1f1f1f; }
+.message { margin: 1rem 0; }
+.replies { margin-left: 1.5rem; padding-left: 1rem; border-left: 3px solid #ddd; }
+.author { font-weight: 600; }
+.time { color: #777; font-size: 0.85em; margin-left: 0.5rem; }
+.body { white-space: pre-wrap; margin-top: 0.25rem; }
+.files { margin: 0.25rem 0 0; padding-left: 1.25rem; }
+</style>
+</head>
+<body>
+<h1>Room {{.RoomID}}</h1>
+<p>{{len .Threads}} threads, exported {{time .Exported}}</p>
+{{range .Threads}}{{template "message" .}}{{end}}
+</body>
+</html>
+{{define "message"}}<div class="message" id="{{.ID}}">
+<div><span class="author">{{.AuthorName}}</span><span class="time">{{time .Created}}</span></div>
+{{with .Body}}<div class="body">{{.}}</div>{{end}}
+{{with .Files}}<ul class="files">{{range .}}<li><a href="{{.Href}}">{{.Name}}</a></li>{{end}}</ul>{{end}}
+{{with .Replies}}<div class="replies">{{range .}}{{template "message" .}}{{end}}</div>{{end}}
+</div>
+{{end}}`))
+
+type htmlEntry struct {
+	*Entry
+	Body    string
+	Files   []fileLink
+	Replies []*htmlEntry
+}
+
+func newHTMLEntry(entry *Entry) *htmlEntry {
+	h := &htmlEntry{Entry: entry, Body: entry.body(), Files: entry.files()}
+	for _, reply := range entry.Replies {
+		h.Replies = append(h.Replies, newHTMLEntry(reply))
+	}
+	return h
+}
+
+func writeHTML(w io.Writer, roomID string, threads []*Entry) error {
+	data := struct {
+		RoomID   string
+		Exported time.Time
+		Threads  []*htmlEntry
+	}{RoomID: roomID, Exported: time.Now()}
+
+	for _, entry := range threads {
+		data.Threads = append(data.Threads, newHTMLEntry(entry))
+	}
+
+	return htmlTranscript.Execute(w, data)
+}
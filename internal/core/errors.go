@@ -4,12 +4,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 	"time"
 )
 
 var (
 	ErrInvalidParameter = errors.New("invalid or missing required parameter")
+
+	// Status sentinels match API errors with errors.Is.
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+
+	// ErrForeignURL rejects absolute URLs outside the API, which would
+	// otherwise receive the access token.
+	ErrForeignURL = errors.New("URL is not on the API host")
 )
 
 type APIError struct {
@@ -17,6 +27,10 @@ type APIError struct {
 	Message    string
 	TrackingID string
 	Errors     []ErrorDetail
+
+	// Header and Body are the raw error response, kept for debugging.
+	Header http.Header
+	Body   []byte
 }
 
 type ErrorDetail struct {
@@ -36,6 +50,54 @@ func (e *APIError) Error() string {
 	return msg
 }
 
+// Is matches the status sentinels, so errors.Is(err, ErrNotFound) reports
+// a 404 response.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// ValidationError is returned when a method rejects its arguments before
+// sending a request. It matches ErrInvalidParameter with errors.Is.
+type ValidationError struct {
+	// Method is the rejecting method, such as "MessagesService.Create".
+	Method string
+
+	// Field names the invalid argument or request field, such as "roomID"
+	// or "req.Title".
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid or missing %s", e.Method, e.Field)
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidParameter
+}
+
+// InvalidParameter returns a ValidationError for field of method.
+func InvalidParameter(method, field string) error {
+	return &ValidationError{Method: method, Field: field}
+}
+
+// IsRetryable reports whether err is a rate limit, a temporary server error
+// or a dropped connection, which the retry policy retries.
+func IsRetryable(err error) bool {
+	return isRetryable(err)
+}
+
 type RateLimitError struct {
 	*APIError
 	RetryAfter time.Duration
@@ -45,29 +107,58 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("rate limit exceeded: retry after %v", e.RetryAfter)
 }
 
-func NewAPIError(resp *http.Response, message string, trackingID string, errors []ErrorDetail) *APIError {
+// Unwrap lets errors.As find the underlying APIError.
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
+// FileScanningError is returned with status 423 while Webex scans a file for
+// malware. The file can be downloaded after RetryAfter.
+type FileScanningError struct {
+	*APIError
+	RetryAfter time.Duration
+}
+
+func (e *FileScanningError) Error() string {
+	return fmt.Sprintf("file is being scanned: retry after %v", e.RetryAfter)
+}
+
+// Unwrap lets errors.As find the underlying APIError.
+func (e *FileScanningError) Unwrap() error {
+	return e.APIError
+}
+
+func NewAPIError(resp *http.Response, body []byte, message string, trackingID string, errors []ErrorDetail) *APIError {
 	return &APIError{
 		StatusCode: resp.StatusCode,
 		Message:    message,
 		TrackingID: trackingID,
 		Errors:     errors,
+		Header:     resp.Header.Clone(),
+		Body:       body,
 	}
 }
 
-func NewRateLimitError(resp *http.Response, message string, trackingID string) *RateLimitError {
-	retryAfter := 60 * time.Second
-	if retryHeader := resp.Header.Get("Retry-After"); retryHeader != "" {
-		if seconds, err := strconv.Atoi(retryHeader); err == nil {
-			retryAfter = time.Duration(seconds) * time.Second
-		}
+func NewRateLimitError(resp *http.Response, body []byte, message string, trackingID string, errors []ErrorDetail) *RateLimitError {
+	retryAfter, ok := parseRetryAfter(resp.Header, time.Now())
+	if !ok {
+		retryAfter = 60 * time.Second
 	}
 
 	return &RateLimitError{
-		APIError: &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    message,
-			TrackingID: trackingID,
-		},
+		APIError:   NewAPIError(resp, body, message, trackingID, errors),
+		RetryAfter: retryAfter,
+	}
+}
+
+func NewFileScanningError(resp *http.Response, body []byte, message string, trackingID string, errors []ErrorDetail) *FileScanningError {
+	retryAfter, ok := parseRetryAfter(resp.Header, time.Now())
+	if !ok {
+		retryAfter = 5 * time.Second
+	}
+
+	return &FileScanningError{
+		APIError:   NewAPIError(resp, body, message, trackingID, errors),
 		RetryAfter: retryAfter,
 	}
 }
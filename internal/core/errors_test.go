@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorMatchesStatusSentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Trackingid", "ROUTER_1")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"message":"nope","errors":[{"description":"nope"}]}`))
+		}))
+
+		session := NewRestSession(&RestSessionConfig{AccessToken: "test-token", BaseURL: server.URL + "/"})
+		err := session.Get(context.Background(), "rooms/1", nil, nil)
+		server.Close()
+
+		wrapped := fmt.Errorf("failed to get room: %w", err)
+		if !errors.Is(wrapped, tt.target) {
+			t.Errorf("status %d: expected %v to match %v", tt.status, err, tt.target)
+		}
+
+		if errors.Is(wrapped, ErrNotFound) != (tt.status == http.StatusNotFound) {
+			t.Errorf("status %d: unexpected ErrNotFound match", tt.status)
+		}
+
+		var apiErr *APIError
+		if !errors.As(wrapped, &apiErr) {
+			t.Fatalf("status %d: expected an APIError, got %T", tt.status, err)
+		}
+
+		if string(apiErr.Body) != `{"message":"nope","errors":[{"description":"nope"}]}` || apiErr.Header.Get("Trackingid") != "ROUTER_1" {
+			t.Errorf("status %d: response not captured: %q %v", tt.status, apiErr.Body, apiErr.Header)
+		}
+
+		if len(apiErr.Errors) != 1 {
+			t.Errorf("status %d: expected error details, got %v", tt.status, apiErr.Errors)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := fmt.Errorf("failed: %w", InvalidParameter("RoomsService.Get", "roomID"))
+
+	if !errors.Is(err, ErrInvalidParameter) {
+		t.Error("expected a ValidationError to match ErrInvalidParameter")
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "roomID" || validationErr.Method != "RoomsService.Get" {
+		t.Errorf("unexpected validation error %v", err)
+	}
+
+	if validationErr.Error() != "RoomsService.Get: invalid or missing roomID" {
+		t.Errorf("unexpected message %q", validationErr.Error())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !IsRetryable(&RateLimitError{APIError: &APIError{StatusCode: http.StatusTooManyRequests}}) {
+		t.Error("expected a rate limit to be retryable")
+	}
+
+	if IsRetryable(&APIError{StatusCode: http.StatusNotFound}) || IsRetryable(InvalidParameter("RoomsService.Get", "roomID")) {
+		t.Error("expected client errors not to be retryable")
+	}
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Attribute keys recorded on every API call span.
+const (
+	AttrHTTPMethod     = "http.request.method"
+	AttrHTTPStatusCode = "http.response.status_code"
+	AttrURL            = "url.full"
+	AttrTrackingID     = "webex.tracking_id"
+	AttrRetryCount     = "webex.retry_count"
+	AttrRateLimitWait  = "webex.rate_limit_wait"
+)
+
+// Tracer starts a span for every API call. The span name is the operation,
+// for example "messaging.Messages.Create".
+type Tracer interface {
+	Start(ctx context.Context, operation string) (context.Context, Span)
+}
+
+// Span is a single traced API call.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key/value pair recorded on a span. Value is a string, int,
+// bool or time.Duration.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Metrics receives one record per API call, after all retries.
+type Metrics interface {
+	RecordRequest(ctx context.Context, request RequestMetrics)
+}
+
+// RequestMetrics describes a completed API call.
+type RequestMetrics struct {
+	Operation     string
+	Method        string
+	StatusCode    int
+	Duration      time.Duration
+	Retries       int
+	RateLimitWait time.Duration
+	Err           error
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, operation string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordRequest(ctx context.Context, request RequestMetrics) {}
+
+// callStats accumulates what happened across the attempts of one API call.
+type callStats struct {
+	statusCode    int
+	trackingID    string
+	retries       int
+	rateLimitWait time.Duration
+}
+
+// finishCall ends the span and records metrics for a completed API call.
+func (s *RestSession) finishCall(ctx context.Context, span Span, operation, method, fullURL string, start time.Time, stats callStats, err error) {
+	span.SetAttributes(
+		Attribute{Key: AttrHTTPMethod, Value: method},
+		Attribute{Key: AttrURL, Value: redactURL(fullURL)},
+		Attribute{Key: AttrHTTPStatusCode, Value: stats.statusCode},
+		Attribute{Key: AttrTrackingID, Value: stats.trackingID},
+		Attribute{Key: AttrRetryCount, Value: stats.retries},
+		Attribute{Key: AttrRateLimitWait, Value: stats.rateLimitWait},
+	)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+
+	s.metrics.RecordRequest(ctx, RequestMetrics{
+		Operation:     operation,
+		Method:        method,
+		StatusCode:    stats.statusCode,
+		Duration:      time.Since(start),
+		Retries:       stats.retries,
+		RateLimitWait: stats.rateLimitWait,
+		Err:           err,
+	})
+}
+
+type operationKey struct{}
+
+// WithOperation names the API operation performed with ctx, such as
+// "messaging.Messages.Create". Service methods set it so that spans and
+// metrics are named after them.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// operationName returns the operation named by WithOperation. It falls back
+// to the HTTP method and path when ctx names none.
+func operationName(ctx context.Context, method, fullURL string) string {
+	if operation, ok := ctx.Value(operationKey{}).(string); ok && operation != "" {
+		return operation
+	}
+
+	path := fullURL
+	if u, err := url.Parse(fullURL); err == nil {
+		path = u.Path
+	}
+	return method + " " + path
+}
+
+// redactURL drops the query string, which may contain email addresses.
+func redactURL(fullURL string) string {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return fullURL
+	}
+	u.RawQuery = ""
+	return u.String()
+}
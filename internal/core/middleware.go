@@ -0,0 +1,139 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+)
+
+// Doer sends an HTTP request. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer with cross-cutting behaviour such as logging.
+type Middleware func(next Doer) Doer
+
+// Chain wraps doer with middlewares. The first middleware is the outermost
+// one and sees the request first.
+func Chain(doer Doer, middlewares ...Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// LoggingMiddleware logs every request with its status code, latency and
+// Webex tracking ID. Failed requests are logged at warn level.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.Redacted()),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+				logger.LogAttrs(req.Context(), slog.LevelWarn, "webex request failed", attrs...)
+				return resp, err
+			}
+
+			attrs = append(attrs,
+				slog.Int("status", resp.StatusCode),
+				slog.String("trackingId", resp.Header.Get("Trackingid")),
+			)
+
+			level := slog.LevelDebug
+			if resp.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+			logger.LogAttrs(req.Context(), level, "webex request", attrs...)
+
+			return resp, nil
+		})
+	}
+}
+
+// HeaderMiddleware sets the given headers on every request, replacing any
+// value already present.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, values := range header {
+				req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// DumpMiddleware writes every request and response to w in HTTP wire format.
+// The Authorization header is redacted. When body is false, only the headers
+// are dumped, which keeps large uploads out of memory.
+func DumpMiddleware(w io.Writer, body bool) Middleware {
+	var mu sync.Mutex
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			dumpReq := req.Clone(req.Context())
+			dumpReq.Header.Set("Authorization", "[REDACTED]")
+
+			if body && req.Body != nil {
+				data, err := io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, fmt.Errorf("failed to read request body: %w", err)
+				}
+				req.Body = io.NopCloser(bytes.NewReader(data))
+				dumpReq.Body = io.NopCloser(bytes.NewReader(data))
+			}
+
+			reqDump, err := httputil.DumpRequestOut(dumpReq, body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to dump request: %w", err)
+			}
+
+			resp, err := next.Do(req)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			w.Write(reqDump)
+			if err != nil {
+				fmt.Fprintf(w, "\n<error: %v>\n\n", err)
+				return resp, err
+			}
+
+			respDump, dumpErr := httputil.DumpResponse(resp, body)
+			if dumpErr != nil {
+				return resp, fmt.Errorf("failed to dump response: %w", dumpErr)
+			}
+			w.Write(respDump)
+			io.WriteString(w, "\n\n")
+
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareChainOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Proxy-Auth"); got != "secret" {
+			t.Errorf("expected injected header, got %q", got)
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Middlewares: []Middleware{trace("outer"), trace("inner")},
+	})
+	session.Use(HeaderMiddleware(http.Header{"X-Proxy-Auth": {"secret"}}), trace("last"))
+
+	if err := session.Get(context.Background(), "rooms", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(order, ",") != "outer,inner,last" {
+		t.Errorf("unexpected middleware order: %v", order)
+	}
+}
+
+func TestDumpMiddlewareRedactsAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		if body.String() != `{"text":"hello"}` {
+			t.Errorf("expected body to reach the server intact, got %q", body.String())
+		}
+		w.Write([]byte(`{"id":"message-1"}`))
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "super-secret-token",
+		BaseURL:     server.URL + "/",
+		Middlewares: []Middleware{DumpMiddleware(&dump, true)},
+	})
+
+	var result testItem
+	if err := session.Post(context.Background(), "messages", map[string]string{"text": "hello"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != "message-1" {
+		t.Errorf("expected message-1, got %s", result.ID)
+	}
+
+	out := dump.String()
+	if strings.Contains(out, "super-secret-token") {
+		t.Error("dump must not contain the access token")
+	}
+
+	for _, want := range []string{"POST /messages", "Authorization: [REDACTED]", `{"text":"hello"}`, `{"id":"message-1"}`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dump to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"strings"
+)
+
+var (
+	ErrFileTooLarge      = errors.New("file exceeds the maximum upload size")
+	ErrBodyNotReplayable = errors.New("request body cannot be resent because a file is not seekable")
+	ErrFileSizeMismatch  = errors.New("file length does not match its size")
+)
+
+// unknownSize marks a file or body whose length is not known in advance.
+const unknownSize = -1
+
+// MultipartFile is a file part of a multipart upload.
+type MultipartFile struct {
+	FieldName   string
+	FileName    string
+	ContentType string
+	Reader      io.Reader
+
+	// Size is the length of Reader. When zero it is detected for files,
+	// seekers and readers with a Len method such as bytes.Reader.
+	Size int64
+}
+
+// MultipartUpload describes a streamed multipart/form-data request body.
+type MultipartUpload struct {
+	Fields map[string]string
+	Files  []MultipartFile
+
+	// MaxFileSize rejects files larger than this many bytes. Zero means no
+	// limit.
+	MaxFileSize int64
+
+	// Progress is called as file content is sent with the bytes sent so far
+	// and the total file size, or -1 when a size is unknown.
+	Progress func(sent, total int64)
+}
+
+// sizedReader carries the content length of a streamed body so that the
+// request does not fall back to chunked encoding.
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+func (r *sizedReader) Size() int64 {
+	return r.size
+}
+
+// Close closes the underlying reader, which lets the transport stop the
+// writer of a streamed body.
+func (r *sizedReader) Close() error {
+	if closer, ok := r.Reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// newMultipartBody validates upload and returns a bodyFunc that streams the
+// multipart body through a pipe, together with its content type. Files that
+// implement io.Seeker are rewound when the request is retried.
+func newMultipartBody(upload *MultipartUpload) (bodyFunc, string, error) {
+	files := make([]MultipartFile, len(upload.Files))
+	offsets := make([]int64, len(upload.Files))
+	total := int64(0)
+	seekable := true
+
+	var err error
+
+	for i, file := range upload.Files {
+		if file.Reader == nil || file.FieldName == "" {
+			return nil, "", InvalidParameter("RestSession.PostMultipartStream", "upload.Files")
+		}
+
+		if file.Size <= 0 {
+			file.Size = readerSize(file.Reader)
+		}
+
+		if upload.MaxFileSize > 0 && file.Size > upload.MaxFileSize {
+			return nil, "", fmt.Errorf("%w: %s is %d bytes, limit is %d", ErrFileTooLarge, file.FileName, file.Size, upload.MaxFileSize)
+		}
+
+		if total >= 0 && file.Size >= 0 {
+			total += file.Size
+		} else {
+			total = unknownSize
+		}
+
+		seeker, ok := file.Reader.(io.Seeker)
+		if !ok {
+			seekable = false
+		} else if offsets[i], err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			// Pipes and other special files cannot seek.
+			seekable = false
+		}
+
+		files[i] = file
+	}
+
+	// The boundary is fixed up front so that every attempt produces the same
+	// content type and length.
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	length := int64(unknownSize)
+	if total >= 0 {
+		var overhead int64
+		if overhead, err = multipartOverhead(boundary, upload.Fields, files); err != nil {
+			return nil, "", err
+		}
+		length = overhead + total
+	}
+
+	attempts := 0
+	var previous *io.PipeReader
+	var writing chan struct{}
+	newBody := func() (io.Reader, error) {
+		attempts++
+		if attempts > 1 {
+			if !seekable {
+				return nil, ErrBodyNotReplayable
+			}
+
+			// The previous attempt's writer may still be reading the files.
+			// Stop it and wait for it to exit before rewinding them.
+			previous.Close()
+			<-writing
+
+			for i, file := range files {
+				if _, err := file.Reader.(io.Seeker).Seek(offsets[i], io.SeekStart); err != nil {
+					return nil, fmt.Errorf("failed to rewind file %s: %w", file.FileName, err)
+				}
+			}
+		}
+
+		pr, pw := io.Pipe()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			pw.CloseWithError(writeMultipart(pw, boundary, upload, files, total))
+		}()
+		previous, writing = pr, done
+
+		if length < 0 {
+			return pr, nil
+		}
+		return &sizedReader{Reader: pr, size: length}, nil
+	}
+
+	writer := multipart.NewWriter(io.Discard)
+	writer.SetBoundary(boundary)
+	return newBody, writer.FormDataContentType(), nil
+}
+
+// writeMultipart writes the fields and files of upload to w.
+func writeMultipart(w io.Writer, boundary string, upload *MultipartUpload, files []MultipartFile, total int64) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	for key, value := range upload.Fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return fmt.Errorf("failed to write field %s: %w", key, err)
+		}
+	}
+
+	var sent int64
+	for _, file := range files {
+		part, err := writer.CreatePart(fileHeader(file))
+		if err != nil {
+			return fmt.Errorf("failed to create form file: %w", err)
+		}
+
+		reader := file.Reader
+		switch {
+		case file.Size >= 0:
+			// The content length was computed from Size, so send exactly
+			// that many bytes.
+			reader = io.LimitReader(reader, file.Size)
+		case upload.MaxFileSize > 0:
+			// Enforce the limit for readers whose size was unknown.
+			reader = io.LimitReader(reader, upload.MaxFileSize+1)
+		}
+
+		dst := io.Writer(part)
+		if upload.Progress != nil {
+			dst = &progressWriter{w: part, sent: &sent, total: total, fn: upload.Progress}
+		}
+
+		n, err := io.Copy(dst, reader)
+		if err != nil {
+			return fmt.Errorf("failed to copy file content: %w", err)
+		}
+
+		if upload.MaxFileSize > 0 && n > upload.MaxFileSize {
+			return fmt.Errorf("%w: %s exceeds %d bytes", ErrFileTooLarge, file.FileName, upload.MaxFileSize)
+		}
+
+		if file.Size >= 0 {
+			if n < file.Size {
+				return fmt.Errorf("%w: %s ended after %d of %d bytes", ErrFileSizeMismatch, file.FileName, n, file.Size)
+			}
+			if extra, _ := file.Reader.Read(make([]byte, 1)); extra > 0 {
+				return fmt.Errorf("%w: %s is longer than %d bytes", ErrFileSizeMismatch, file.FileName, file.Size)
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// multipartOverhead returns the number of bytes a multipart body adds around
+// the file contents.
+func multipartOverhead(boundary string, fields map[string]string, files []MultipartFile) (int64, error) {
+	counter := &countingWriter{}
+	writer := multipart.NewWriter(counter)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, file := range files {
+		if _, err := writer.CreatePart(fileHeader(file)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+func fileHeader(file MultipartFile) textproto.MIMEHeader {
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(file.FieldName), escapeQuotes(file.FileName)))
+	header.Set("Content-Type", contentType)
+	return header
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// readerSize detects the remaining length of r, or returns -1.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case *os.File:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return unknownSize
+		}
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return unknownSize
+		}
+		return info.Size() - offset
+	case io.Seeker:
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return unknownSize
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return unknownSize
+		}
+		if _, err := v.Seek(offset, io.SeekStart); err != nil {
+			return unknownSize
+		}
+		return end - offset
+	}
+	return unknownSize
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+type progressWriter struct {
+	w     io.Writer
+	sent  *int64
+	total int64
+	fn    func(sent, total int64)
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	*w.sent += int64(n)
+	w.fn(*w.sent, w.total)
+	return n, err
+}
@@ -0,0 +1,212 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPostMultipartStreamRetriesSeekableFiles(t *testing.T) {
+	content := strings.Repeat("x", 1000)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength <= int64(len(content)) {
+			t.Errorf("expected content length covering the file, got %d", r.ContentLength)
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("failed to parse multipart body: %v", err)
+			return
+		}
+
+		if r.FormValue("roomId") != "room-1" {
+			t.Errorf("expected roomId field, got %q", r.FormValue("roomId"))
+		}
+
+		file, header, err := r.FormFile("files")
+		if err != nil {
+			t.Errorf("missing file part: %v", err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != content || header.Filename != "report.txt" || header.Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("unexpected file part %q (%d bytes)", header.Filename, len(data))
+		}
+
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"message-1"}`))
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Retry:       &RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true, MinBackoff: 1},
+	})
+
+	var progress []int64
+	upload := &MultipartUpload{
+		Fields: map[string]string{"roomId": "room-1"},
+		Files: []MultipartFile{{
+			FieldName:   "files",
+			FileName:    "report.txt",
+			ContentType: "text/plain",
+			Reader:      strings.NewReader(content),
+		}},
+		Progress: func(sent, total int64) {
+			if total != int64(len(content)) {
+				t.Errorf("expected total %d, got %d", len(content), total)
+			}
+			progress = append(progress, sent)
+		},
+	}
+
+	var result testItem
+	if err := session.PostMultipartStream(context.Background(), "messages", upload, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != "message-1" || calls.Load() != 2 {
+		t.Errorf("expected message-1 after 2 calls, got %q after %d", result.ID, calls.Load())
+	}
+
+	// Progress restarts with every attempt.
+	if len(progress) != 2 || progress[1] != int64(len(content)) {
+		t.Errorf("expected complete progress for both attempts, got %v", progress)
+	}
+}
+
+func TestPostMultipartStreamEnforcesMaxFileSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	known := &MultipartUpload{
+		MaxFileSize: 10,
+		Files:       []MultipartFile{{FieldName: "files", FileName: "big.bin", Reader: bytes.NewReader(make([]byte, 11))}},
+	}
+	if err := session.PostMultipartStream(context.Background(), "messages", known, nil); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected ErrFileTooLarge before sending, got %v", err)
+	}
+
+	// A reader of unknown size is cut off while streaming.
+	unknown := &MultipartUpload{
+		MaxFileSize: 10,
+		Files:       []MultipartFile{{FieldName: "files", FileName: "big.bin", Reader: io.MultiReader(strings.NewReader(strings.Repeat("x", 11)))}},
+	}
+	if err := session.PostMultipartStream(context.Background(), "messages", unknown, nil); err == nil {
+		t.Error("expected an error for an oversized stream")
+	}
+}
+
+func TestPostMultipartStreamChecksDeclaredSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	for _, size := range []int64{5, 20} {
+		upload := &MultipartUpload{
+			Files: []MultipartFile{{FieldName: "files", FileName: "notes.txt", Size: size, Reader: io.MultiReader(strings.NewReader(strings.Repeat("x", 10)))}},
+		}
+		if err := session.PostMultipartStream(context.Background(), "messages", upload, nil); !errors.Is(err, ErrFileSizeMismatch) {
+			t.Errorf("size %d: expected ErrFileSizeMismatch, got %v", size, err)
+		}
+	}
+}
+
+func TestPostMultipartStreamClosesBodyWhenRequestFails(t *testing.T) {
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     "http://bad host/",
+	})
+
+	before := runtime.NumGoroutine()
+	upload := &MultipartUpload{
+		Fields: map[string]string{"roomId": "room-1"},
+		Files:  []MultipartFile{{FieldName: "files", FileName: "report.txt", Reader: strings.NewReader("content")}},
+	}
+	if err := session.PostMultipartStream(context.Background(), "messages", upload, nil); err == nil {
+		t.Fatal("expected an invalid URL to fail")
+	}
+
+	// The writer goroutine exits once the pipe is closed.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if runtime.NumGoroutine() > before {
+		t.Errorf("expected the multipart writer to exit, %d goroutines left over", runtime.NumGoroutine()-before)
+	}
+}
+
+func TestPostMultipartStreamRewindsAfterWriterStops(t *testing.T) {
+	content := strings.Repeat("x", 4<<20)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt is rejected before its body has been read.
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		if err := r.ParseMultipartForm(8 << 20); err != nil {
+			t.Errorf("failed to parse multipart body: %v", err)
+			return
+		}
+
+		file, _, err := r.FormFile("files")
+		if err != nil {
+			t.Errorf("missing file part: %v", err)
+			return
+		}
+		if data, _ := io.ReadAll(file); string(data) != content {
+			t.Errorf("expected the complete file on retry, got %d bytes", len(data))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Retry:       &RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true, MinBackoff: 1},
+	})
+
+	upload := &MultipartUpload{
+		Files: []MultipartFile{{FieldName: "files", FileName: "big.bin", Reader: strings.NewReader(content)}},
+	}
+	if err := session.PostMultipartStream(context.Background(), "messages", upload, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
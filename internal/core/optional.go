@@ -0,0 +1,76 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional is a request field that distinguishes unset, null and a value,
+// including the zero value. Tag fields with omitzero so that unset fields
+// are left out of the request body:
+//
+//	IsLocked Optional[bool] `json:"isLocked,omitzero"`
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, set: true}
+}
+
+// Null returns an Optional that is sent as JSON null.
+func Null[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// IsZero reports whether o is unset. It lets omitzero skip the field.
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// IsSet reports whether o holds a value or null.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull reports whether o is set to null.
+func (o Optional[T]) IsNull() bool {
+	return o.null
+}
+
+// Get returns the value and whether o holds one.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set && !o.null
+}
+
+// Or returns the value, or fallback when o is unset or null.
+func (o Optional[T]) Or(fallback T) T {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return fallback
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set || o.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Null[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
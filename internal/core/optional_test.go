@@ -0,0 +1,37 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptionalJSON(t *testing.T) {
+	type request struct {
+		Locked Optional[bool]   `json:"locked,omitzero"`
+		Count  Optional[int]    `json:"count,omitzero"`
+		Team   Optional[string] `json:"team,omitzero"`
+	}
+
+	data, err := json.Marshal(request{Locked: Some(false), Team: Null[string]()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"locked":false,"team":null}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+
+	var decoded request
+	if err := json.Unmarshal([]byte(`{"locked":true,"team":null}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if locked, ok := decoded.Locked.Get(); !ok || !locked {
+		t.Errorf("expected locked to be true, got %+v", decoded.Locked)
+	}
+	if decoded.Count.IsSet() || decoded.Count.Or(7) != 7 {
+		t.Errorf("expected count to be unset, got %+v", decoded.Count)
+	}
+	if !decoded.Team.IsNull() || decoded.Team.Or("fallback") != "fallback" {
+		t.Errorf("expected team to be null, got %+v", decoded.Team)
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrNoMorePages = errors.New("no more pages")
+)
+
+// Pager walks a list endpoint one page at a time by following the
+// RFC 5988 Link rel="next" header returned by the Webex API.
+type Pager[T any] struct {
+	session   *RestSession
+	operation string
+	nextURL   string
+	done      bool
+}
+
+// NewPager creates a pager whose first page is fetched from path with params.
+// Every page is fetched as operation, such as "messaging.Rooms.ListPages".
+func NewPager[T any](session *RestSession, operation, path string, params url.Values) *Pager[T] {
+	return &Pager[T]{
+		session:   session,
+		operation: operation,
+		nextURL:   session.buildURL(path, params),
+	}
+}
+
+// HasMore reports whether another page can be fetched.
+func (p *Pager[T]) HasMore() bool {
+	return !p.done
+}
+
+// NextPage fetches the next page of items. After the last page has been
+// returned, it reports ErrNoMorePages. A failed page can be retried by
+// calling NextPage again, except when the next link leaves the API host,
+// which is reported as ErrForeignURL and ends the pager.
+func (p *Pager[T]) NextPage(ctx context.Context) ([]*T, error) {
+	if p.done {
+		return nil, ErrNoMorePages
+	}
+
+	var response struct {
+		Items []*T `json:"items"`
+	}
+
+	header, err := p.session.do(WithOperation(ctx, p.operation), http.MethodGet, p.nextURL, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	next := nextLink(header)
+	if next == "" {
+		p.nextURL, p.done = "", true
+		return response.Items, nil
+	}
+
+	// The access token is sent with every page, so only follow links that
+	// stay on the API host.
+	nextURL, err := p.session.apiURL(next)
+	if err != nil {
+		p.done = true
+		return nil, err
+	}
+
+	p.nextURL = nextURL
+	return response.Items, nil
+}
+
+// All returns an iterator over the items of every remaining page. Iteration
+// stops after the first error, which is yielded with a nil item.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for p.HasMore() {
+			items, err := p.NextPage(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ErrorSeq returns an iterator that yields err once. It lets ListAll methods
+// report validation errors through the same iterator interface.
+func ErrorSeq[T any](err error) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		yield(nil, err)
+	}
+}
+
+// resolveURL resolves a possibly relative link against the base URL.
+func (s *RestSession) resolveURL(link string) string {
+	if link == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(link)
+	if err != nil || ref.IsAbs() {
+		return link
+	}
+
+	base, err := url.Parse(s.baseURL)
+	if err != nil {
+		return link
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// nextLink extracts the rel="next" target from the Link headers.
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, rels, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok {
+				continue
+			}
+
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range strings.Split(rels, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testItem struct {
+	ID string `json:"id"`
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"empty", "", ""},
+		{"next only", `<https://webexapis.com/v1/rooms?cursor=abc>; rel="next"`, "https://webexapis.com/v1/rooms?cursor=abc"},
+		{"prev and next", `<https://example.com/a>; rel="prev", <https://example.com/b>; rel="next"`, "https://example.com/b"},
+		{"unquoted rel", `<https://example.com/b>; rel=next`, "https://example.com/b"},
+		{"no next", `<https://example.com/a>; rel="first"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("Link", tt.header)
+			}
+			if got := nextLink(header); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPagerFollowsNextLinks(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rooms" {
+			t.Errorf("expected /rooms path, got %s", r.URL.Path)
+		}
+
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "":
+			if r.URL.Query().Get("max") != "2" {
+				t.Errorf("expected max=2 on first page, got %s", r.URL.RawQuery)
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/rooms?max=2&page=2>; rel="next"`, server.URL))
+			w.Write([]byte(`{"items":[{"id":"1"},{"id":"2"}]}`))
+		case "2":
+			w.Header().Set("Link", `</rooms?max=2&page=3>; rel="next"`)
+			w.Write([]byte(`{"items":[{"id":"3"},{"id":"4"}]}`))
+		case "3":
+			w.Write([]byte(`{"items":[{"id":"5"}]}`))
+		default:
+			t.Errorf("unexpected page %s", page)
+		}
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	pager := NewPager[testItem](session, "test.Rooms.ListPages", "rooms", map[string][]string{"max": {"2"}})
+
+	var ids []string
+	for item, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+
+	if len(ids) != 5 || ids[0] != "1" || ids[4] != "5" {
+		t.Errorf("expected items 1..5, got %v", ids)
+	}
+
+	if pager.HasMore() {
+		t.Error("expected pager to be exhausted")
+	}
+
+	if _, err := pager.NextPage(context.Background()); err != ErrNoMorePages {
+		t.Errorf("expected ErrNoMorePages, got %v", err)
+	}
+}
+
+func TestPagerRejectsForeignNextLinks(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("the pager followed a foreign link with %q", r.Header.Get("Authorization"))
+	}))
+	defer foreign.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/rooms?page=2>; rel="next"`, foreign.URL))
+		w.Write([]byte(`{"items":[{"id":"1"}]}`))
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	pager := NewPager[testItem](session, "test.Rooms.ListPages", "rooms", nil)
+	if _, err := pager.NextPage(context.Background()); !errors.Is(err, ErrForeignURL) {
+		t.Errorf("expected ErrForeignURL, got %v", err)
+	}
+
+	if pager.HasMore() {
+		t.Error("expected the pager to stop at a foreign link")
+	}
+}
@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts = 4
+	DefaultMinBackoff  = 500 * time.Millisecond
+	DefaultMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy controls how failed requests are retried. Requests are retried
+// on 429 Too Many Requests, 502, 503 and 504 responses and on connection
+// resets. When the server sends a Retry-After header, it takes precedence
+// over the computed backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the base delay of the exponential backoff.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent allows POST and PATCH requests to be retried.
+	// By default only GET, HEAD, OPTIONS, PUT and DELETE are retried.
+	RetryNonIdempotent bool
+
+	// OnRetry, when set, is called before each retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	Method     string
+	URL        string
+	Attempt    int
+	Wait       time.Duration
+	StatusCode int
+	Err        error
+}
+
+// DefaultRetryPolicy returns a policy with the default attempt count and
+// backoff bounds.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: DefaultMaxAttempts,
+		MinBackoff:  DefaultMinBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// backoff reports whether the failed attempt should be retried and how long
+// to wait before doing so. It never schedules a retry past the context
+// deadline.
+func (p *RetryPolicy) backoff(ctx context.Context, method string, attempt int, header http.Header, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return 0, false
+	}
+
+	if !isRetryable(err) {
+		return 0, false
+	}
+
+	wait, ok := parseRetryAfter(header, time.Now())
+	if !ok {
+		wait = p.exponential(attempt)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// exponential returns a jittered, exponentially growing delay for the given
+// attempt.
+func (p *RetryPolicy) exponential(attempt int) time.Duration {
+	minBackoff := p.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultMinBackoff
+	}
+
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	// A MaxBackoff below the (default) MinBackoff caps every delay.
+	minBackoff = min(minBackoff, maxBackoff)
+
+	ceiling := minBackoff
+	for i := 1; i < attempt && ceiling < maxBackoff; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, maxBackoff)
+
+	return minBackoff/2 + rand.N(ceiling-minBackoff/2+1)
+}
+
+func (p *RetryPolicy) notify(event RetryEvent) {
+	if p != nil && p.OnRetry != nil {
+		p.OnRetry(event)
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryable(err error) bool {
+	switch statusCode(err) {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case 0:
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+	}
+	return false
+}
+
+// statusCode returns the HTTP status carried by an API error, or 0.
+func statusCode(err error) int {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.StatusCode
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
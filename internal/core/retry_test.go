@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"title":"retry"}` {
+			t.Errorf("expected full body on every attempt, got %q", body)
+		}
+
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"room-1"}`))
+	}))
+	defer server.Close()
+
+	var events []RetryEvent
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Retry: &RetryPolicy{
+			MaxAttempts: 3,
+			OnRetry: func(event RetryEvent) {
+				events = append(events, event)
+			},
+		},
+	})
+
+	var result testItem
+	if err := session.Put(context.Background(), "rooms/1", map[string]string{"title": "retry"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != "room-1" {
+		t.Errorf("expected room-1, got %s", result.ID)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 retries, got %d", len(events))
+	}
+
+	if events[0].StatusCode != http.StatusTooManyRequests || events[0].Wait != 0 {
+		t.Errorf("unexpected retry event: %+v", events[0])
+	}
+}
+
+func TestRetrySkipsNonIdempotentMethods(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Retry:       &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+	})
+
+	err := session.Post(context.Background(), "messages", map[string]string{"text": "hi"}, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected POST not to be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestRetryRespectsContextDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	session := NewRestSession(&RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Retry:       DefaultRetryPolicy(),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := session.Get(ctx, "rooms", nil, nil)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 30*time.Second {
+		t.Fatalf("expected RateLimitError with 30s RetryAfter, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected no retry past the deadline, got %d calls", calls.Load())
+	}
+}
+
+func TestExponentialBackoffWithSmallMaxBackoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 5, MaxBackoff: 100 * time.Millisecond}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		if wait := policy.exponential(attempt); wait < 0 || wait > policy.MaxBackoff {
+			t.Errorf("attempt %d: expected a wait within [0, %v], got %v", attempt, policy.MaxBackoff, wait)
+		}
+	}
+}
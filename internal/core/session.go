@@ -6,11 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -22,18 +23,46 @@ const (
 
 type RestSession struct {
 	client      *http.Client
+	middlewares []Middleware
+	doer        Doer
 	baseURL     string
 	accessToken string
+	tokens      TokenSource
 	userAgent   string
+	timeout     time.Duration
+	retry       *RetryPolicy
+	tracer      Tracer
+	metrics     Metrics
 	mu          sync.RWMutex
 }
 
 type RestSessionConfig struct {
 	AccessToken string
 	BaseURL     string
-	Timeout     time.Duration
 	UserAgent   string
-	HTTPClient  *http.Client
+
+	// Timeout limits each attempt of a request. It defaults to
+	// DefaultTimeout, or to no limit beyond the client's own when HTTPClient
+	// is set, and can be overridden per request with WithTimeout.
+	Timeout time.Duration
+
+	HTTPClient *http.Client
+
+	// Middlewares wrap every request sent by the session. The first
+	// middleware is the outermost one.
+	Middlewares []Middleware
+
+	// TokenSource supplies access tokens. When set, it takes precedence over
+	// AccessToken.
+	TokenSource TokenSource
+
+	// Retry configures automatic retries of failed requests. A nil policy
+	// disables retries.
+	Retry *RetryPolicy
+
+	// Tracer and Metrics instrument every API call. Both default to no-ops.
+	Tracer  Tracer
+	Metrics Metrics
 }
 
 // NewRestSession creates a new RestSession with the provided configuration.
@@ -44,15 +73,15 @@ func NewRestSession(config *RestSessionConfig) *RestSession {
 	}
 
 	timeout := DefaultTimeout
-	if config.Timeout > 0 {
-		timeout = config.Timeout
-	}
-
 	client := config.HTTPClient
 	if client == nil {
-		client = &http.Client{
-			Timeout: timeout,
-		}
+		client = &http.Client{}
+	} else {
+		timeout = 0
+	}
+
+	if config.Timeout > 0 {
+		timeout = config.Timeout
 	}
 
 	userAgent := "webexgosdk/1.0"
@@ -60,85 +89,288 @@ func NewRestSession(config *RestSessionConfig) *RestSession {
 		userAgent = config.UserAgent
 	}
 
+	var tracer Tracer = noopTracer{}
+	if config.Tracer != nil {
+		tracer = config.Tracer
+	}
+
+	var metrics Metrics = noopMetrics{}
+	if config.Metrics != nil {
+		metrics = config.Metrics
+	}
+
 	return &RestSession{
 		client:      client,
+		middlewares: slices.Clone(config.Middlewares),
+		doer:        Chain(client, config.Middlewares...),
 		baseURL:     baseURL,
 		accessToken: config.AccessToken,
+		tokens:      config.TokenSource,
 		userAgent:   userAgent,
+		timeout:     timeout,
+		retry:       config.Retry,
+		tracer:      tracer,
+		metrics:     metrics,
 	}
 }
 
+// SetAccessToken replaces the session's credentials with a static token.
 func (s *RestSession) SetAccessToken(token string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.accessToken = token
+	s.tokens = nil
 }
 
 func (s *RestSession) GetAccessToken() string {
+	token, _ := s.token()
+	return token
+}
+
+// SetTokenSource replaces the session's credentials with a token source.
+func (s *RestSession) SetTokenSource(source TokenSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = source
+}
+
+// token returns the access token to send with the next request.
+func (s *RestSession) token() (string, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.accessToken
+	accessToken, source := s.accessToken, s.tokens
+	s.mu.RUnlock()
+
+	if source == nil {
+		return accessToken, nil
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain access token: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+// refreshToken forces the token source to refresh after the API rejected
+// the current token. It reports whether a new token was obtained.
+func (s *RestSession) refreshToken(ctx context.Context, err error) bool {
+	if statusCode(err) != http.StatusUnauthorized {
+		return false
+	}
+
+	s.mu.RLock()
+	refresher, ok := s.tokens.(Refresher)
+	s.mu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	_, refreshErr := refresher.Refresh(ctx)
+	return refreshErr == nil
 }
 
 func (s *RestSession) SetHTTPClient(client *http.Client) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.client = client
+	s.timeout = 0
+	s.doer = Chain(client, s.middlewares...)
+}
+
+// Use appends middlewares to the session's chain. They wrap requests inside
+// the middlewares that were configured earlier.
+func (s *RestSession) Use(middlewares ...Middleware) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.middlewares = append(s.middlewares, middlewares...)
+	s.doer = Chain(s.client, s.middlewares...)
 }
 
 func (s *RestSession) doRequest(ctx context.Context, method, path string, params url.Values, body any, result any) error {
+	_, err := s.do(ctx, method, s.buildURL(path, params), body, result)
+	return err
+}
+
+// buildURL joins path and the encoded query parameters onto the base URL.
+func (s *RestSession) buildURL(path string, params url.Values) string {
 	fullURL := s.baseURL + path
-	if params != nil {
+	if len(params) > 0 {
 		fullURL += "?" + params.Encode()
 	}
+	return fullURL
+}
 
-	var bodyReader io.Reader
+// do sends a JSON request to fullURL and decodes the response into result.
+// The response headers are returned so callers can inspect pagination links.
+func (s *RestSession) do(ctx context.Context, method, fullURL string, body any, result any) (http.Header, error) {
+	var newBody bodyFunc
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		newBody = func() (io.Reader, error) {
+			return bytes.NewReader(jsonBody), nil
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
-	if err != nil {
-		return fmt.Errorf("failed to create request")
+	return s.send(ctx, method, fullURL, "application/json", newBody, result)
+}
+
+type timeoutKey struct{}
+
+// WithTimeout overrides the per-attempt timeout of requests made with ctx.
+// A zero timeout disables the limit, for example for large downloads.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+// bodyFunc returns a fresh reader over the request body. It is called once
+// per attempt so that retried requests resend the complete body.
+type bodyFunc func() (io.Reader, error)
+
+// send performs the request, retrying according to the session's retry
+// policy, and decodes a successful response into result.
+func (s *RestSession) send(ctx context.Context, method, fullURL, contentType string, newBody bodyFunc, result any) (header http.Header, err error) {
+	operation := operationName(ctx, method, fullURL)
+	ctx, span := s.tracer.Start(ctx, operation)
+
+	var stats callStats
+	start := time.Now()
+	defer func() {
+		s.finishCall(ctx, span, operation, method, fullURL, start, stats, err)
+	}()
+
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		var status int
+		status, header, err = s.sendOnce(ctx, method, fullURL, contentType, newBody, result)
+		stats.statusCode = status
+		if trackingID := header.Get("Trackingid"); trackingID != "" {
+			stats.trackingID = trackingID
+		}
+
+		if err == nil {
+			return header, nil
+		}
+
+		if !refreshed && s.refreshToken(ctx, err) {
+			refreshed = true
+			attempt--
+			continue
+		}
+
+		wait, ok := s.retry.backoff(ctx, method, attempt, header, err)
+		if !ok {
+			return header, err
+		}
+
+		s.retry.notify(RetryEvent{
+			Method:     method,
+			URL:        fullURL,
+			Attempt:    attempt,
+			Wait:       wait,
+			StatusCode: status,
+			Err:        err,
+		})
+
+		stats.retries++
+		if status == http.StatusTooManyRequests {
+			stats.rateLimitWait += wait
+		}
+
+		if waitErr := sleep(ctx, wait); waitErr != nil {
+			return header, err
+		}
 	}
+}
 
+func (s *RestSession) sendOnce(ctx context.Context, method, fullURL, contentType string, newBody bodyFunc, result any) (int, http.Header, error) {
 	s.mu.RLock()
-	token := s.accessToken
+	timeout := s.timeout
 	s.mu.RUnlock()
 
+	if override, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// The token is fetched before the body is created: a streamed body
+	// starts writing as soon as it exists and must be closed on failure.
+	token, err := s.token()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var bodyReader io.Reader
+	if newBody != nil {
+		if bodyReader, err = newBody(); err != nil {
+			return 0, nil, fmt.Errorf("failed to create request body: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		if closer, ok := bodyReader.(io.Closer); ok {
+			closer.Close()
+		}
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if sized, ok := bodyReader.(*sizedReader); ok {
+		req.ContentLength = sized.Size()
+	}
+
 	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", s.userAgent)
 
-	resp, err := s.client.Do(req)
+	s.mu.RLock()
+	doer := s.doer
+	s.mu.RUnlock()
+
+	resp, err := doer.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return s.handleErrorResponse(resp)
+		return resp.StatusCode, resp.Header, s.handleErrorResponse(resp)
+	}
+
+	if raw, ok := result.(*rawBody); ok {
+		// Part of the body may already have been written, so a failed copy
+		// must not be retried: the error deliberately does not wrap err.
+		if _, err := io.Copy(raw.w, resp.Body); err != nil {
+			return resp.StatusCode, resp.Header, fmt.Errorf("failed to stream response body: %v", err)
+		}
+		return resp.StatusCode, resp.Header, nil
 	}
 
 	if result != nil && resp.StatusCode != http.StatusNoContent {
 		respBody, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
 		}
 
 		if len(respBody) > 0 {
 			if err := json.Unmarshal(respBody, result); err != nil {
-				return fmt.Errorf("failed to unmarshal response body: %w", err)
+				return resp.StatusCode, resp.Header, fmt.Errorf("failed to unmarshal response body: %w", err)
 			}
 		}
 	}
 
-	return nil
+	return resp.StatusCode, resp.Header, nil
 }
 
 // handleErrorResponse handles an error response from the API.
@@ -147,8 +379,8 @@ func (s *RestSession) handleErrorResponse(resp *http.Response) error {
 	trackingID := resp.Header.Get("Trackingid")
 
 	var errorResp struct {
-		Message string `json:"message"`
-		Errors []ErrorDetail `json:"errors"`
+		Message string        `json:"message"`
+		Errors  []ErrorDetail `json:"errors"`
 	}
 
 	if len(respBody) > 0 {
@@ -156,9 +388,13 @@ func (s *RestSession) handleErrorResponse(resp *http.Response) error {
 	}
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return NewRateLimitError(resp, errorResp.Message, trackingID)
+		return NewRateLimitError(resp, respBody, errorResp.Message, trackingID, errorResp.Errors)
 	}
-	return NewAPIError(resp, errorResp.Message, trackingID, errorResp.Errors)
+
+	if resp.StatusCode == http.StatusLocked {
+		return NewFileScanningError(resp, respBody, errorResp.Message, trackingID, errorResp.Errors)
+	}
+	return NewAPIError(resp, respBody, errorResp.Message, trackingID, errorResp.Errors)
 }
 
 func (s *RestSession) Get(ctx context.Context, path string, params url.Values, result any) error {
@@ -177,75 +413,92 @@ func (s *RestSession) Delete(ctx context.Context, path string) error {
 	return s.doRequest(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
-func (s *RestSession) PostMultipart(ctx context.Context, path string, fields map[string]string, files map[string]string, result any) error {
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+// rawBody is passed as the result of a request whose response body is
+// copied to w instead of being decoded as JSON.
+type rawBody struct {
+	w io.Writer
+}
 
-	for key, value := range fields {
-		if err := writer.WriteField(key, value); err != nil {
-			return fmt.Errorf("failed to write field %s: %w", key, err)
-		}
+// Head sends a HEAD request and returns the response headers. rawURL must be
+// relative to or on the same host as the base URL.
+func (s *RestSession) Head(ctx context.Context, rawURL string) (http.Header, error) {
+	fullURL, err := s.apiURL(rawURL)
+	if err != nil {
+		return nil, err
 	}
+	return s.send(ctx, http.MethodHead, fullURL, "", nil, nil)
+}
 
-	for fieldName, filePath := range files {
-		file, err := os.Open(filePath)
-		if err != nil {
-			return fmt.Errorf("failed to open file %s: %w", filePath, err)
-		}
-		defer file.Close()
-
-		part, err := writer.CreateFormFile(fieldName, filepath.Base(filePath))
-		if err != nil {
-			return fmt.Errorf("failed to create form file: %w", err)
-		}
-
-		if _, err := io.Copy(part, file); err != nil {
-			return fmt.Errorf("failed to copy file content: %w", err)
-		}
-
+// Download streams the body of a GET request to w and returns the response
+// headers. rawURL must be relative to or on the same host as the base URL.
+// The session's per-attempt timeout would cut off large files while they
+// stream, so only ctx and a timeout set with WithTimeout limit a download.
+func (s *RestSession) Download(ctx context.Context, rawURL string, w io.Writer) (http.Header, error) {
+	fullURL, err := s.apiURL(rawURL)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := writer.Close(); err != nil {
-		return fmt.Errorf("failed to close multipart writer: %w", err)
+	if _, ok := ctx.Value(timeoutKey{}).(time.Duration); !ok {
+		ctx = WithTimeout(ctx, 0)
 	}
+	return s.send(ctx, http.MethodGet, fullURL, "", nil, &rawBody{w: w})
+}
 
-	fullURL := s.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, &buf)
+// apiURL resolves rawURL against the base URL and rejects URLs with another
+// scheme or host, so that the access token is only sent to the API.
+func (s *RestSession) apiURL(rawURL string) (string, error) {
+	fullURL := s.resolveURL(rawURL)
+
+	target, err := url.Parse(fullURL)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	s.mu.RLock()
-	token := s.accessToken
-	s.mu.RUnlock()
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", s.userAgent)
-
-	resp, err := s.client.Do(req)
+	base, err := url.Parse(s.baseURL)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return "", fmt.Errorf("failed to parse base URL: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		return s.handleErrorResponse(resp)
+	if !strings.EqualFold(target.Scheme, base.Scheme) || !strings.EqualFold(target.Host, base.Host) {
+		return "", fmt.Errorf("%w: %s://%s", ErrForeignURL, target.Scheme, target.Host)
 	}
+	return fullURL, nil
+}
 
-	if result != nil && resp.StatusCode != http.StatusNoContent {
-		respBody, err := io.ReadAll(resp.Body)
+// PostMultipart uploads the files at the given paths, keyed by form field
+// name, together with fields.
+func (s *RestSession) PostMultipart(ctx context.Context, path string, fields map[string]string, files map[string]string, result any) error {
+	upload := &MultipartUpload{Fields: fields}
+	for fieldName, filePath := range files {
+		file, err := os.Open(filePath)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return fmt.Errorf("failed to open file %s: %w", filePath, err)
 		}
+		defer file.Close()
 
-		if len(respBody) > 0 {
-			if err := json.Unmarshal(respBody, result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
-			}
-		}
+		upload.Files = append(upload.Files, MultipartFile{
+			FieldName: fieldName,
+			FileName:  filepath.Base(filePath),
+			Reader:    file,
+		})
+	}
+
+	return s.PostMultipartStream(ctx, path, upload, result)
+}
+
+// PostMultipartStream sends upload as a multipart/form-data body that is
+// streamed from the file readers instead of being buffered in memory.
+func (s *RestSession) PostMultipartStream(ctx context.Context, path string, upload *MultipartUpload, result any) error {
+	if upload == nil {
+		return InvalidParameter("RestSession.PostMultipartStream", "upload")
 	}
 
-	return nil
-}
\ No newline at end of file
+	newBody, contentType, err := newMultipartBody(upload)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.send(ctx, http.MethodPost, s.buildURL(path, nil), contentType, newBody, result)
+	return err
+}
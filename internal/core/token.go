@@ -0,0 +1,280 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTokenURL    = DefaultBaseURL + "access_token"
+	DefaultExpiryDelta = 5 * time.Minute
+)
+
+var (
+	ErrRefreshTokenRequired = errors.New("refresh token is required")
+)
+
+// Token is an OAuth2 access token. Its JSON encoding matches the one used by
+// golang.org/x/oauth2.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the token is set and not expired. A zero Expiry
+// means the token never expires.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Before(t.Expiry))
+}
+
+// expiresWithin reports whether the token expires within d.
+func (t *Token) expiresWithin(d time.Duration) bool {
+	return !t.Expiry.IsZero() && time.Now().Add(d).After(t.Expiry)
+}
+
+// TokenSource supplies access tokens. Implementations must be safe for
+// concurrent use and should return a cached token until it expires, just like
+// golang.org/x/oauth2.TokenSource.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// Refresher is implemented by token sources that can be forced to fetch a
+// new token, for example after the API rejected the current one with 401.
+type Refresher interface {
+	Refresh(ctx context.Context) (*Token, error)
+}
+
+// TokenStore persists tokens between runs.
+type TokenStore interface {
+	Load() (*Token, error)
+	Save(token *Token) error
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource returns a TokenSource that always returns accessToken.
+func StaticTokenSource(accessToken string) TokenSource {
+	return &staticTokenSource{token: &Token{AccessToken: accessToken, TokenType: "Bearer"}}
+}
+
+func (s *staticTokenSource) Token() (*Token, error) {
+	return s.token, nil
+}
+
+type RefreshTokenConfig struct {
+	ClientID     string
+	ClientSecret string
+
+	// Token is the initial token. Only RefreshToken is required; an empty
+	// AccessToken triggers a refresh on first use.
+	Token *Token
+
+	// TokenURL defaults to DefaultTokenURL.
+	TokenURL string
+
+	// ExpiryDelta is how long before expiry the token is proactively
+	// refreshed. Defaults to DefaultExpiryDelta.
+	ExpiryDelta time.Duration
+
+	// Store, when set, provides the initial token if Token is nil and
+	// receives every refreshed token.
+	Store TokenStore
+
+	HTTPClient *http.Client
+}
+
+// RefreshTokenSource exchanges a Webex integration refresh token for access
+// tokens and caches the result until shortly before it expires.
+type RefreshTokenSource struct {
+	config RefreshTokenConfig
+	token  *Token
+	mu     sync.Mutex
+}
+
+// NewRefreshTokenSource creates a RefreshTokenSource. When config.Token is nil,
+// the initial token is loaded from config.Store.
+func NewRefreshTokenSource(config *RefreshTokenConfig) (*RefreshTokenSource, error) {
+	source := &RefreshTokenSource{config: *config}
+
+	if source.config.TokenURL == "" {
+		source.config.TokenURL = DefaultTokenURL
+	}
+
+	if source.config.ExpiryDelta <= 0 {
+		source.config.ExpiryDelta = DefaultExpiryDelta
+	}
+
+	if source.config.HTTPClient == nil {
+		source.config.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	source.token = config.Token
+	if source.token == nil && config.Store != nil {
+		token, err := config.Store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load token: %w", err)
+		}
+		source.token = token
+	}
+
+	if source.token == nil || source.token.RefreshToken == "" {
+		return nil, ErrRefreshTokenRequired
+	}
+
+	return source, nil
+}
+
+// Token returns the cached token, refreshing it first if it is missing or
+// about to expire.
+func (s *RefreshTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.AccessToken != "" && !s.token.expiresWithin(s.config.ExpiryDelta) {
+		return s.token, nil
+	}
+
+	return s.refresh(context.Background())
+}
+
+// Refresh unconditionally exchanges the refresh token for a new access token.
+func (s *RefreshTokenSource) Refresh(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refresh(ctx)
+}
+
+func (s *RefreshTokenSource) refresh(ctx context.Context) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", s.config.ClientID)
+	form.Set("client_secret", s.config.ClientSecret)
+	form.Set("refresh_token", s.token.RefreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token refresh failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var errorResp struct {
+			Message string        `json:"message"`
+			Errors  []ErrorDetail `json:"errors"`
+		}
+		json.Unmarshal(respBody, &errorResp)
+		return nil, NewAPIError(resp, respBody, errorResp.Message, resp.Header.Get("Trackingid"), errorResp.Errors)
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	token := &Token{
+		AccessToken:  tokenResp.AccessToken,
+		TokenType:    tokenResp.TokenType,
+		RefreshToken: tokenResp.RefreshToken,
+	}
+	if token.TokenType == "" {
+		token.TokenType = "Bearer"
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = s.token.RefreshToken
+	}
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	s.token = token
+
+	if s.config.Store != nil {
+		if err := s.config.Store.Save(token); err != nil {
+			return nil, fmt.Errorf("failed to save token: %w", err)
+		}
+	}
+
+	return token, nil
+}
+
+// FileTokenStore stores a token as JSON in a file readable only by its owner.
+type FileTokenStore struct {
+	Path string
+}
+
+func (s *FileTokenStore) Load() (*Token, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token file %s: %w", s.Path, err)
+	}
+	return &token, nil
+}
+
+// Save writes the token to a temporary file and renames it into place so that
+// an interrupted write never corrupts the stored token.
+func (s *FileTokenStore) Save(token *Token) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "refresh-1" {
+			t.Errorf("unexpected refresh request: %v", r.PostForm)
+		}
+
+		refreshes.Add(1)
+		w.Write([]byte(`{"access_token":"access-2","expires_in":1209600,"refresh_token":"refresh-1"}`))
+	}))
+	defer server.Close()
+
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "token.json")}
+	source, err := NewRefreshTokenSource(&RefreshTokenConfig{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL,
+		Store:        store,
+		Token: &Token{
+			AccessToken:  "access-1",
+			RefreshToken: "refresh-1",
+			Expiry:       time.Now().Add(time.Minute),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 2 {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AccessToken != "access-2" {
+			t.Errorf("expected access-2, got %s", token.AccessToken)
+		}
+	}
+
+	if refreshes.Load() != 1 {
+		t.Errorf("expected a single refresh, got %d", refreshes.Load())
+	}
+
+	saved, err := store.Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.AccessToken != "access-2" || saved.RefreshToken != "refresh-1" {
+		t.Errorf("unexpected stored token: %+v", saved)
+	}
+}
+
+func TestSessionRefreshesTokenOnUnauthorized(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"fresh","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	var calls atomic.Int32
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":"me"}`))
+	}))
+	defer apiServer.Close()
+
+	source, err := NewRefreshTokenSource(&RefreshTokenConfig{
+		TokenURL: tokenServer.URL,
+		Token:    &Token{AccessToken: "revoked", RefreshToken: "refresh"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	session := NewRestSession(&RestSessionConfig{
+		BaseURL:     apiServer.URL + "/",
+		TokenSource: source,
+	})
+
+	var result testItem
+	if err := session.Post(context.Background(), "messages", map[string]string{"text": "hi"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != "me" || calls.Load() != 2 {
+		t.Errorf("expected one retry after refresh, got %d calls and result %+v", calls.Load(), result)
+	}
+}
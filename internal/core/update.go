@@ -0,0 +1,75 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LoadUpdate fills req, a pointer to an update request, with the matching
+// fields of current, so that a read-modify-write only changes the fields the
+// caller sets afterwards.
+func LoadUpdate(current, req any) error {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode current value: %w", err)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("failed to decode current value: %w", err)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	for _, name := range jsonFieldNames(reflect.TypeOf(req)) {
+		if v, ok := object[name]; ok {
+			fields[name] = v
+		}
+	}
+
+	if data, err = json.Marshal(fields); err != nil {
+		return fmt.Errorf("failed to encode current value: %w", err)
+	}
+
+	if err := json.Unmarshal(data, req); err != nil {
+		return fmt.Errorf("failed to load current value: %w", err)
+	}
+	return nil
+}
+
+// jsonFieldNames lists the JSON names of the fields of struct type t,
+// including those promoted from embedded structs.
+func jsonFieldNames(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && field.Anonymous {
+			names = append(names, jsonFieldNames(field.Type)...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
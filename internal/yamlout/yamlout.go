@@ -0,0 +1,265 @@
+// Package yamlout writes JSON-encodable values as YAML. It is shared by the
+// command-line tool's YAML output and the cassette files.
+//
+// The writer emits a small subset of YAML: block mappings and sequences,
+// plain scalars where YAML reads them back unchanged, double-quoted strings
+// otherwise, literal blocks for multi-line text, and plain numbers, booleans
+// and null. Keys keep the order of the JSON encoding.
+package yamlout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Field is one key of a mapping, kept in order.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Map is a mapping whose keys keep their encoded order.
+type Map []Field
+
+// Write encodes v as JSON, so that field names and omitempty rules match the
+// JSON output, and writes it to w as YAML.
+func Write(w io.Writer, v any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	data, err := FromJSON(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// FromJSON converts a JSON document to YAML.
+func FromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	node, err := Decode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	switch node := node.(type) {
+	case Map:
+		if len(node) == 0 {
+			b.WriteString("{}\n")
+		} else {
+			writeMapping(&b, node, 0, false)
+		}
+	case []any:
+		if len(node) == 0 {
+			b.WriteString("[]\n")
+		} else {
+			writeSequence(&b, node, 0)
+		}
+	default:
+		// writeValue writes scalars after "key:" with a leading space.
+		var scalar bytes.Buffer
+		writeValue(&scalar, node, 0)
+		b.Write(scalar.Bytes()[1:])
+	}
+	return b.Bytes(), nil
+}
+
+// Decode decodes the next JSON value, keeping object keys in order as a Map.
+// Numbers are returned as json.Number when dec uses UseNumber.
+func Decode(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		m := Map{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := Decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, Field{Key: key.(string), Value: value})
+		}
+		_, err := dec.Token()
+		return m, err
+	case json.Delim('['):
+		list := []any{}
+		for dec.More() {
+			value, err := Decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := dec.Token()
+		return list, err
+	}
+
+	return token, nil
+}
+
+func pad(b *bytes.Buffer, indent int) {
+	b.WriteString(strings.Repeat(" ", indent))
+}
+
+// writeMapping writes the fields of m at indent. When inItem is set the
+// first key follows a "- " that has already been written.
+func writeMapping(b *bytes.Buffer, m Map, indent int, inItem bool) {
+	for i, field := range m {
+		if i > 0 || !inItem {
+			pad(b, indent)
+		}
+		b.WriteString(Scalar(field.Key))
+		b.WriteByte(':')
+		writeValue(b, field.Value, indent+2)
+	}
+}
+
+// writeSequence writes the items of list at indent.
+func writeSequence(b *bytes.Buffer, list []any, indent int) {
+	for _, item := range list {
+		pad(b, indent)
+		if m, ok := item.(Map); ok && len(m) > 0 {
+			b.WriteString("- ")
+			writeMapping(b, m, indent+2, true)
+			continue
+		}
+		b.WriteByte('-')
+		writeValue(b, item, indent+2)
+	}
+}
+
+// writeValue writes v after a "key:" or "-", children indented by indent.
+func writeValue(b *bytes.Buffer, v any, indent int) {
+	switch v := v.(type) {
+	case Map:
+		if len(v) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteByte('\n')
+		writeMapping(b, v, indent, false)
+	case []any:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteByte('\n')
+		writeSequence(b, v, indent)
+	case string:
+		if !literalText(v) {
+			b.WriteByte(' ')
+			b.WriteString(Scalar(v))
+			b.WriteByte('\n')
+			return
+		}
+		b.WriteString(" |-\n")
+		for _, line := range strings.Split(v, "\n") {
+			if line != "" {
+				pad(b, indent)
+				b.WriteString(line)
+			}
+			b.WriteByte('\n')
+		}
+	case nil:
+		b.WriteString(" null\n")
+	default:
+		fmt.Fprintf(b, " %v\n", v)
+	}
+}
+
+// Scalar formats a single-line string, leaving it plain when YAML reads it
+// back unchanged and double-quoting it otherwise.
+func Scalar(s string) string {
+	if Plain(s) {
+		return s
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Plain reports whether s can be written without quotes and read back as the
+// same string rather than as a number, boolean, null or structure.
+func Plain(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return false
+	}
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return false
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+		case strings.ContainsRune("_./+:", r):
+		case r == ' ' || r == '-' || r == '@':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// literalText reports whether s can be written as a literal block and read
+// back unchanged.
+func literalText(s string) bool {
+	if !strings.Contains(s, "\n") || strings.HasSuffix(s, "\n") || !utf8.ValidString(s) {
+		return false
+	}
+
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsSpace(r) {
+		return false
+	}
+
+	for _, r := range s {
+		if r != '\n' && !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	// Lines of only spaces would read back as blank lines.
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" && strings.TrimLeft(line, " ") == "" {
+			return false
+		}
+	}
+	return true
+}
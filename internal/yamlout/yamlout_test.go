@@ -0,0 +1,89 @@
+package yamlout
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	v := map[string]any{
+		"id":     "p1",
+		"emails": []string{"alice@example.com"},
+		"name":   "Alice: Admin",
+		"notes":  "line one\nline two",
+		"active": true,
+		"count":  2,
+		"empty":  []string{},
+		"tags":   []map[string]string{{"type": "work", "url": "https://example.com/<a>"}},
+		"quoted": "yes",
+	}
+
+	var b bytes.Buffer
+	if err := Write(&b, v); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := `active: true
+count: 2
+emails:
+  - alice@example.com
+empty: []
+id: p1
+name: "Alice: Admin"
+notes: |-
+  line one
+  line two
+quoted: "yes"
+tags:
+  - type: work
+    url: "https://example.com/<a>"
+`
+	if b.String() != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteTopLevel(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{[]string{}, "[]\n"},
+		{map[string]string{}, "{}\n"},
+		{"text", "text\n"},
+		{"true", "\"true\"\n"},
+		{[]int{1, 2}, "- 1\n- 2\n"},
+		{nil, "null\n"},
+	}
+
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := Write(&b, tt.v); err != nil {
+			t.Fatalf("Write(%#v) error = %v", tt.v, err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("Write(%#v) = %q, want %q", tt.v, b.String(), tt.want)
+		}
+	}
+}
+
+func TestPlain(t *testing.T) {
+	tests := map[string]bool{
+		"alice@example.com": true,
+		"Alice Smith":       true,
+		"a:b":               true,
+		"":                  false,
+		" padded":           false,
+		"null":              false,
+		"1.5":               false,
+		"key: value":        false,
+		"-dash":             false,
+		"#comment":          false,
+	}
+
+	for s, want := range tests {
+		if got := Plain(s); got != want {
+			t.Errorf("Plain(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package meeting
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 
@@ -36,10 +37,43 @@ type InviteeListOptions struct {
 }
 
 func (s *MeetingInviteesService) List(ctx context.Context, opts *InviteeListOptions) ([]*MeetingInvitee, error) {
+	ctx = core.WithOperation(ctx, "meeting.MeetingInvitees.List")
+
+	if opts == nil || opts.MeetingID == "" {
+		return nil, core.InvalidParameter("MeetingInviteesService.List", "opts.MeetingID")
+	}
+
+	var response struct {
+		Items []*MeetingInvitee `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "meetingInvitees", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all invitees of a meeting.
+func (s *MeetingInviteesService) ListPages(opts *InviteeListOptions) (*core.Pager[MeetingInvitee], error) {
 	if opts == nil || opts.MeetingID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingInviteesService.ListPages", "opts.MeetingID")
+	}
+
+	return core.NewPager[MeetingInvitee](s.session, "meeting.MeetingInvitees.ListPages", "meetingInvitees", opts.params()), nil
+}
+
+// ListAll iterates over all invitees of a meeting, following pagination links.
+func (s *MeetingInviteesService) ListAll(ctx context.Context, opts *InviteeListOptions) iter.Seq2[*MeetingInvitee, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[MeetingInvitee](err)
 	}
 
+	return pager.All(ctx)
+}
+
+func (opts *InviteeListOptions) params() url.Values {
 	params := url.Values{}
 	params.Set("meetingId", opts.MeetingID)
 
@@ -53,15 +87,7 @@ func (s *MeetingInviteesService) List(ctx context.Context, opts *InviteeListOpti
 		params.Set("max", strconv.Itoa(opts.Max))
 	}
 
-	var response struct {
-		Items []*MeetingInvitee `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "meetingInvitees", params, &response); err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	return params
 }
 
 type InviteeCreateRequest struct {
@@ -75,8 +101,13 @@ type InviteeCreateRequest struct {
 }
 
 func (s *MeetingInviteesService) Create(ctx context.Context, req *InviteeCreateRequest) (*MeetingInvitee, error) {
-	if req == nil || req.MeetingID == "" || req.Email == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.MeetingInvitees.Create")
+
+	switch {
+	case req == nil || req.MeetingID == "":
+		return nil, core.InvalidParameter("MeetingInviteesService.Create", "req.MeetingID")
+	case req.Email == "":
+		return nil, core.InvalidParameter("MeetingInviteesService.Create", "req.Email")
 	}
 
 	var invitee MeetingInvitee
@@ -103,8 +134,13 @@ type BulkInviteeItem struct {
 
 // BulkCreate creates multiple meeting invitees at once.
 func (s *MeetingInviteesService) BulkCreate(ctx context.Context, req *BulkCreateRequest) ([]*MeetingInvitee, error) {
-	if req == nil || req.MeetingID == "" || len(req.Items) == 0 {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.MeetingInvitees.BulkCreate")
+
+	switch {
+	case req == nil || req.MeetingID == "":
+		return nil, core.InvalidParameter("MeetingInviteesService.BulkCreate", "req.MeetingID")
+	case len(req.Items) == 0:
+		return nil, core.InvalidParameter("MeetingInviteesService.BulkCreate", "req.Items")
 	}
 
 	var response struct {
@@ -119,8 +155,10 @@ func (s *MeetingInviteesService) BulkCreate(ctx context.Context, req *BulkCreate
 }
 
 func (s *MeetingInviteesService) Get(ctx context.Context, inviteeID string) (*MeetingInvitee, error) {
+	ctx = core.WithOperation(ctx, "meeting.MeetingInvitees.Get")
+
 	if inviteeID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingInviteesService.Get", "inviteeID")
 	}
 
 	var invitee MeetingInvitee
@@ -132,17 +170,22 @@ func (s *MeetingInviteesService) Get(ctx context.Context, inviteeID string) (*Me
 }
 
 type InviteeUpdateRequest struct {
-	Email       string `json:"email"`
-	DisplayName string `json:"displayName,omitempty"`
-	CoHost      bool   `json:"coHost,omitempty"`
-	Panelist    bool   `json:"panelist,omitempty"`
-	SendEmail   bool   `json:"sendEmail,omitempty"`
-	HostEmail   string `json:"hostEmail,omitempty"`
+	Email       string              `json:"email"`
+	DisplayName string              `json:"displayName,omitempty"`
+	CoHost      core.Optional[bool] `json:"coHost,omitzero"`
+	Panelist    core.Optional[bool] `json:"panelist,omitzero"`
+	SendEmail   core.Optional[bool] `json:"sendEmail,omitzero"`
+	HostEmail   string              `json:"hostEmail,omitempty"`
 }
 
 func (s *MeetingInviteesService) Update(ctx context.Context, inviteeID string, req *InviteeUpdateRequest) (*MeetingInvitee, error) {
-	if inviteeID == "" || req == nil {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.MeetingInvitees.Update")
+
+	switch {
+	case inviteeID == "":
+		return nil, core.InvalidParameter("MeetingInviteesService.Update", "inviteeID")
+	case req == nil:
+		return nil, core.InvalidParameter("MeetingInviteesService.Update", "req")
 	}
 
 	var invitee MeetingInvitee
@@ -154,8 +197,10 @@ func (s *MeetingInviteesService) Update(ctx context.Context, inviteeID string, r
 }
 
 func (s *MeetingInviteesService) Delete(ctx context.Context, inviteeID string) error {
+	ctx = core.WithOperation(ctx, "meeting.MeetingInvitees.Delete")
+
 	if inviteeID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("MeetingInviteesService.Delete", "inviteeID")
 	}
 
 	if err := s.session.Delete(ctx, "meetingInvitees/"+inviteeID); err != nil {
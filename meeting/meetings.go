@@ -2,6 +2,7 @@ package meeting
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -10,16 +11,16 @@ import (
 )
 
 type Meeting struct {
-	ID            string    `json:"id,omitempty"`
-	MeetingNumber string    `json:"meetingNumber,omitempty"`
-	Title         string    `json:"title,omitempty"`
-	Agenda        string    `json:"agenda,omitempty"`
-	Password      string    `json:"password,omitempty"`
-	MeetingType   string    `json:"meetingType,omitempty"`
-	State         string    `json:"state,omitempty"`
-	Timezone      string    `json:"timezone,omitempty"`
-	Start         time.Time `json:"start,omitempty"`
-	End           time.Time `json:"end,omitempty"`
+	ID            string       `json:"id,omitempty"`
+	MeetingNumber string       `json:"meetingNumber,omitempty"`
+	Title         string       `json:"title,omitempty"`
+	Agenda        string       `json:"agenda,omitempty"`
+	Password      string       `json:"password,omitempty"`
+	MeetingType   MeetingType  `json:"meetingType,omitempty"`
+	State         MeetingState `json:"state,omitempty"`
+	Timezone      string       `json:"timezone,omitempty"`
+	Start         time.Time    `json:"start,omitempty"`
+	End           time.Time    `json:"end,omitempty"`
 
 	// Recurrence is the recurrence pattern in iCalendar PRULE format.
 	Recurrence string `json:"recurrence,omitempty"`
@@ -65,26 +66,75 @@ type Meeting struct {
 	Breakout                            *BreakoutSessions           `json:"breakout,omitempty"`
 }
 
+// MeetingType distinguishes a meeting series from its scheduled meetings and their occurrences.
+type MeetingType string
+
+const (
+	MeetingTypeMeetingSeries    MeetingType = "meetingSeries"
+	MeetingTypeScheduledMeeting MeetingType = "scheduledMeeting"
+	MeetingTypeMeeting          MeetingType = "meeting"
+)
+
+// IsValid reports whether t is a known meeting type.
+func (t MeetingType) IsValid() bool {
+	switch t {
+	case MeetingTypeMeetingSeries, MeetingTypeScheduledMeeting, MeetingTypeMeeting:
+		return true
+	}
+	return false
+}
+
+func (t MeetingType) String() string {
+	return string(t)
+}
+
+// MeetingState is the lifecycle state of a meeting.
+type MeetingState string
+
+const (
+	MeetingStateActive     MeetingState = "active"
+	MeetingStateScheduled  MeetingState = "scheduled"
+	MeetingStateReady      MeetingState = "ready"
+	MeetingStateLobby      MeetingState = "lobby"
+	MeetingStateInProgress MeetingState = "inProgress"
+	MeetingStateEnded      MeetingState = "ended"
+	MeetingStateMissed     MeetingState = "missed"
+	MeetingStateExpired    MeetingState = "expired"
+)
+
+// IsValid reports whether s is a known meeting state.
+func (s MeetingState) IsValid() bool {
+	switch s {
+	case MeetingStateActive, MeetingStateScheduled, MeetingStateReady, MeetingStateLobby, MeetingStateInProgress, MeetingStateEnded, MeetingStateMissed, MeetingStateExpired:
+		return true
+	}
+	return false
+}
+
+func (s MeetingState) String() string {
+	return string(s)
+}
+
 type MeetingOptions struct {
-	EnabledChat          bool   `json:"enabledChat,omitempty"`
-	EnabledVideo         bool   `json:"enabledVideo,omitempty"`
-	EnabledPolling       bool   `json:"enabledPolling,omitempty"`
-	EnabledNote          bool   `json:"enabledNote,omitempty"`
-	NoteType             string `json:"noteType,omitempty"`
-	EnabledClosedCaption bool   `json:"enabledClosedCaption,omitempty"`
-	EnabledFileTransfer  bool   `json:"enabledFileTransfer,omitempty"`
-	EnabledUCFRichMedia  bool   `json:"enabledUCFRichMedia,omitempty"`
+	EnabledChat          core.Optional[bool] `json:"enabledChat,omitzero"`
+	EnabledVideo         core.Optional[bool] `json:"enabledVideo,omitzero"`
+	EnabledPolling       core.Optional[bool] `json:"enabledPolling,omitzero"`
+	EnabledNote          core.Optional[bool] `json:"enabledNote,omitzero"`
+	NoteType             string              `json:"noteType,omitempty"`
+	EnabledClosedCaption core.Optional[bool] `json:"enabledClosedCaption,omitzero"`
+	EnabledFileTransfer  core.Optional[bool] `json:"enabledFileTransfer,omitzero"`
+	EnabledUCFRichMedia  core.Optional[bool] `json:"enabledUCFRichMedia,omitzero"`
 }
 
 type AudioConnectionOptions struct {
-	AudioConnectionType           string `json:"audioConnectionType,omitempty"`
-	EnabledTollFreeCallIn         bool   `json:"enabledTollFreeCallIn,omitempty"`
-	EnabledGlobalCallIn           bool   `json:"enabledGlobalCallIn,omitempty"`
-	EnabledAudienceCallBack       bool   `json:"enabledAudienceCallBack,omitempty"`
-	EntryAndExitTone              string `json:"entryAndExitTone,omitempty"`
-	AllowHostToUnmuteParticipants bool   `json:"allowHostToUnmuteParticipants,omitempty"`
-	AllowAttendeeToUnmuteSelf     bool   `json:"allowAttendeeToUnmuteSelf,omitempty"`
-	MuteAttendeeUponEntry         bool   `json:"muteAttendeeUponEntry,omitempty"`
+	AudioConnectionType           string              `json:"audioConnectionType,omitempty"`
+	EnabledTollFreeCallIn         core.Optional[bool] `json:"enabledTollFreeCallIn,omitzero"`
+	EnabledGlobalCallIn           core.Optional[bool] `json:"enabledGlobalCallIn,omitzero"`
+	EnabledAudienceCallBack       core.Optional[bool] `json:"enabledAudienceCallBack,omitzero"`
+	EntryAndExitTone              string              `json:"entryAndExitTone,omitempty"`
+	AllowHostToUnmuteParticipants core.Optional[bool] `json:"allowHostToUnmuteParticipants,omitzero"`
+	AllowAttendeeToUnmuteSelf     core.Optional[bool] `json:"allowAttendeeToUnmuteSelf,omitzero"`
+	MuteAttendeeUponEntry         core.Optional[bool] `json:"muteAttendeeUponEntry,omitzero"`
 }
 
 type Registration struct {
@@ -148,8 +198,8 @@ type MeetingListOptions struct {
 	MeetingNumber    string
 	WebLink          string
 	RoomID           string
-	MeetingType      string
-	State            string
+	MeetingType      MeetingType
+	State            MeetingState
 	ScheduledType    string
 	ParticipantEmail string
 	Current          bool
@@ -164,6 +214,54 @@ type MeetingListOptions struct {
 }
 
 func (s *MeetingsService) List(ctx context.Context, opts *MeetingListOptions) ([]*Meeting, error) {
+	ctx = core.WithOperation(ctx, "meeting.Meetings.List")
+
+	if err := opts.validate("MeetingsService.List"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Items []*Meeting `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "meetings", opts.params(), &response); err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all meetings matching opts.
+func (s *MeetingsService) ListPages(opts *MeetingListOptions) (*core.Pager[Meeting], error) {
+	if err := opts.validate("MeetingsService.ListPages"); err != nil {
+		return nil, err
+	}
+
+	return core.NewPager[Meeting](s.session, "meeting.Meetings.ListPages", "meetings", opts.params()), nil
+}
+
+// ListAll iterates over all meetings matching opts, following pagination links.
+func (s *MeetingsService) ListAll(ctx context.Context, opts *MeetingListOptions) iter.Seq2[*Meeting, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Meeting](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *MeetingListOptions) validate(method string) error {
+	switch {
+	case opts == nil:
+		return nil
+	case opts.MeetingType != "" && !opts.MeetingType.IsValid():
+		return core.InvalidParameter(method, "opts.MeetingType")
+	case opts.State != "" && !opts.State.IsValid():
+		return core.InvalidParameter(method, "opts.State")
+	}
+	return nil
+}
+
+func (opts *MeetingListOptions) params() url.Values {
 	params := url.Values{}
 
 	if opts != nil {
@@ -177,10 +275,10 @@ func (s *MeetingsService) List(ctx context.Context, opts *MeetingListOptions) ([
 			params.Set("roomId", opts.RoomID)
 		}
 		if opts.MeetingType != "" {
-			params.Set("meetingType", opts.MeetingType)
+			params.Set("meetingType", string(opts.MeetingType))
 		}
 		if opts.State != "" {
-			params.Set("state", opts.State)
+			params.Set("state", string(opts.State))
 		}
 		if opts.ScheduledType != "" {
 			params.Set("scheduledType", opts.ScheduledType)
@@ -211,14 +309,7 @@ func (s *MeetingsService) List(ctx context.Context, opts *MeetingListOptions) ([
 		}
 	}
 
-	var response struct {
-		Items []*Meeting `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "meetings", params, &response); err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+	return params
 }
 
 type MeetingRequestBase struct {
@@ -229,29 +320,29 @@ type MeetingRequestBase struct {
 	End                          time.Time               `json:"end"`
 	Timezone                     string                  `json:"timezone,omitempty"`
 	Recurrence                   string                  `json:"recurrence,omitempty"`
-	EnabledAutoRecordMeeting     bool                    `json:"enabledAutoRecordMeeting"`
-	AllowAnyUserToBeCoHost       bool                    `json:"allowAnyUserToBeCoHost"`
-	AllowFirstUserToBeCoHost     bool                    `json:"allowFirstUserToBeCoHost"`
-	AllowAuthenticateDevices     bool                    `json:"allowAuthenticateDevices"`
-	EnabledJoinBeforeHost        bool                    `json:"enabledJoinBeforeHost"`
-	JoinBeforeHostMinutes        int                     `json:"joinBeforeHostMinutes"`
-	EnableConnectAudioBeforeHost bool                    `json:"enableConnectAudioBeforeHost"`
-	ExcludePassword              bool                    `json:"excludePassword,omitempty"`
-	PublicMeeting                bool                    `json:"publicMeeting,omitempty"`
-	ReminderTime                 int                     `json:"reminderTime,omitempty"`
+	EnabledAutoRecordMeeting     core.Optional[bool]     `json:"enabledAutoRecordMeeting,omitzero"`
+	AllowAnyUserToBeCoHost       core.Optional[bool]     `json:"allowAnyUserToBeCoHost,omitzero"`
+	AllowFirstUserToBeCoHost     core.Optional[bool]     `json:"allowFirstUserToBeCoHost,omitzero"`
+	AllowAuthenticateDevices     core.Optional[bool]     `json:"allowAuthenticateDevices,omitzero"`
+	EnabledJoinBeforeHost        core.Optional[bool]     `json:"enabledJoinBeforeHost,omitzero"`
+	JoinBeforeHostMinutes        core.Optional[int]      `json:"joinBeforeHostMinutes,omitzero"`
+	EnableConnectAudioBeforeHost core.Optional[bool]     `json:"enableConnectAudioBeforeHost,omitzero"`
+	ExcludePassword              core.Optional[bool]     `json:"excludePassword,omitzero"`
+	PublicMeeting                core.Optional[bool]     `json:"publicMeeting,omitzero"`
+	ReminderTime                 core.Optional[int]      `json:"reminderTime,omitzero"`
 	UnlockedMeetingJoinSecurity  string                  `json:"unlockedMeetingJoinSecurity,omitempty"`
 	SessionTypeID                int                     `json:"sessionTypeId,omitempty"`
 	ScheduledType                string                  `json:"scheduledType,omitempty"`
-	EnabledWebcastView           bool                    `json:"enabledWebcastView"`
+	EnabledWebcastView           core.Optional[bool]     `json:"enabledWebcastView,omitzero"`
 	PanelistPassword             string                  `json:"panelistPassword,omitempty"`
-	EnableAutomaticLock          bool                    `json:"enableAutomaticLock"`
-	AutomaticLockMinutes         int                     `json:"automaticLockMinutes"`
+	EnableAutomaticLock          core.Optional[bool]     `json:"enableAutomaticLock,omitzero"`
+	AutomaticLockMinutes         core.Optional[int]      `json:"automaticLockMinutes,omitzero"`
 	HostEmail                    string                  `json:"hostEmail,omitempty"`
 	SiteURL                      string                  `json:"siteUrl,omitempty"`
 	MeetingOptions               *MeetingOptions         `json:"meetingOptions,omitempty"`
 	AudioConnectionOptions       *AudioConnectionOptions `json:"audioConnectionOptions,omitempty"`
 	IntegrationTags              []string                `json:"integrationTags,omitempty"`
-	SendEmail                    bool                    `json:"sendEmail,omitempty"`
+	SendEmail                    core.Optional[bool]     `json:"sendEmail,omitzero"`
 }
 
 type MeetingCreateRequest struct {
@@ -261,8 +352,15 @@ type MeetingCreateRequest struct {
 }
 
 func (s *MeetingsService) Create(ctx context.Context, req *MeetingCreateRequest) (*Meeting, error) {
-	if req == nil || req.Title == "" || req.Start.IsZero() || req.End.IsZero() {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.Meetings.Create")
+
+	switch {
+	case req == nil || req.Title == "":
+		return nil, core.InvalidParameter("MeetingsService.Create", "req.Title")
+	case req.Start.IsZero():
+		return nil, core.InvalidParameter("MeetingsService.Create", "req.Start")
+	case req.End.IsZero():
+		return nil, core.InvalidParameter("MeetingsService.Create", "req.End")
 	}
 
 	var meeting Meeting
@@ -274,8 +372,10 @@ func (s *MeetingsService) Create(ctx context.Context, req *MeetingCreateRequest)
 
 // Get returns details of a meeting by ID.
 func (s *MeetingsService) Get(ctx context.Context, meetingID string) (*Meeting, error) {
+	ctx = core.WithOperation(ctx, "meeting.Meetings.Get")
+
 	if meetingID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingsService.Get", "meetingID")
 	}
 
 	var meeting Meeting
@@ -291,8 +391,17 @@ type MeetingUpdateRequest struct {
 }
 
 func (s *MeetingsService) Update(ctx context.Context, meetingID string, req *MeetingUpdateRequest) (*Meeting, error) {
-	if meetingID == "" || req == nil || req.Title == "" || req.Start.IsZero() || req.End.IsZero() {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.Meetings.Update")
+
+	switch {
+	case meetingID == "":
+		return nil, core.InvalidParameter("MeetingsService.Update", "meetingID")
+	case req == nil || req.Title == "":
+		return nil, core.InvalidParameter("MeetingsService.Update", "req.Title")
+	case req.Start.IsZero():
+		return nil, core.InvalidParameter("MeetingsService.Update", "req.Start")
+	case req.End.IsZero():
+		return nil, core.InvalidParameter("MeetingsService.Update", "req.End")
 	}
 
 	var meeting Meeting
@@ -302,9 +411,36 @@ func (s *MeetingsService) Update(ctx context.Context, meetingID string, req *Mee
 	return &meeting, nil
 }
 
+// Modify fetches the meeting, lets modify change the update request built
+// from its current settings, and saves the result. Settings modify leaves
+// alone keep their current values.
+func (s *MeetingsService) Modify(ctx context.Context, meetingID string, modify func(req *MeetingUpdateRequest)) (*Meeting, error) {
+	switch {
+	case meetingID == "":
+		return nil, core.InvalidParameter("MeetingsService.Modify", "meetingID")
+	case modify == nil:
+		return nil, core.InvalidParameter("MeetingsService.Modify", "modify")
+	}
+
+	meeting, err := s.Get(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	var req MeetingUpdateRequest
+	if err := core.LoadUpdate(meeting, &req); err != nil {
+		return nil, err
+	}
+
+	modify(&req)
+	return s.Update(ctx, meetingID, &req)
+}
+
 func (s *MeetingsService) Delete(ctx context.Context, meetingID string) error {
+	ctx = core.WithOperation(ctx, "meeting.Meetings.Delete")
+
 	if meetingID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("MeetingsService.Delete", "meetingID")
 	}
 
 	return s.session.Delete(ctx, "meetings/"+meetingID)
@@ -316,8 +452,10 @@ type MeetingJoinInfo struct {
 }
 
 func (s *MeetingsService) Join(ctx context.Context, meetingID string, email string, displayName string) (*MeetingJoinInfo, error) {
+	ctx = core.WithOperation(ctx, "meeting.Meetings.Join")
+
 	if meetingID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingsService.Join", "meetingID")
 	}
 
 	reqBody := map[string]any{
@@ -2,6 +2,7 @@ package meeting
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -110,3 +111,55 @@ func TestMeetingsService_Create(t *testing.T) {
 		t.Errorf("expected MeetingType meetingSeries, got %s", meeting.MeetingType)
 	}
 }
+
+func TestMeetingsService_Modify(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"id": "meeting-123"}`))
+			return
+		}
+		w.Write([]byte(`{
+			"id": "meeting-123",
+			"title": "Standup",
+			"start": "2026-01-05T09:00:00Z",
+			"end": "2026-01-05T09:15:00Z",
+			"enabledJoinBeforeHost": true,
+			"joinBeforeHostMinutes": 5,
+			"meetingOptions": {"enabledChat": true, "enabledVideo": true}
+		}`))
+	}))
+	defer server.Close()
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	service := NewMeetingsService(session)
+	_, err := service.Modify(context.Background(), "meeting-123", func(req *MeetingUpdateRequest) {
+		req.EnabledJoinBeforeHost = core.Some(false)
+		req.JoinBeforeHostMinutes = core.Some(0)
+		req.MeetingOptions.EnabledChat = core.Some(false)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["title"] != "Standup" || body["start"] != "2026-01-05T09:00:00Z" {
+		t.Errorf("expected current settings to be kept, got %v", body)
+	}
+	if body["enabledJoinBeforeHost"] != false || body["joinBeforeHostMinutes"] != 0.0 {
+		t.Errorf("expected false and zero to be sent, got %v", body)
+	}
+	if _, ok := body["enableAutomaticLock"]; ok {
+		t.Errorf("expected unset fields to be omitted, got %v", body)
+	}
+
+	options, _ := body["meetingOptions"].(map[string]any)
+	if options["enabledChat"] != false || options["enabledVideo"] != true {
+		t.Errorf("unexpected meeting options %v", options)
+	}
+}
@@ -2,6 +2,7 @@ package meeting
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -13,7 +14,7 @@ type MeetingRegistrant struct {
 	ID                  string               `json:"id,omitempty"`
 	MeetingID           string               `json:"meetingId,omitempty"`
 	RegisterTime        time.Time            `json:"registerTime,omitempty"`
-	Status              string               `json:"status,omitempty"`
+	Status              RegistrantStatus     `json:"status,omitempty"`
 	FirstName           string               `json:"firstName,omitempty"`
 	LastName            string               `json:"lastName,omitempty"`
 	Email               string               `json:"email,omitempty"`
@@ -32,6 +33,28 @@ type MeetingRegistrant struct {
 	CustomizedQuestions []CustomizedQuestion `json:"customizedQuestions,omitempty"`
 }
 
+// RegistrantStatus is the approval status of a meeting registrant.
+type RegistrantStatus string
+
+const (
+	RegistrantStatusPending  RegistrantStatus = "pending"
+	RegistrantStatusApproved RegistrantStatus = "approved"
+	RegistrantStatusRejected RegistrantStatus = "rejected"
+)
+
+// IsValid reports whether s is a known registrant status.
+func (s RegistrantStatus) IsValid() bool {
+	switch s {
+	case RegistrantStatusPending, RegistrantStatusApproved, RegistrantStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (s RegistrantStatus) String() string {
+	return string(s)
+}
+
 type CustomizedQuestion struct {
 	QuestionID string `json:"questionId,omitempty"`
 	Answer     string `json:"answer,omitempty"`
@@ -58,10 +81,43 @@ type RegistrantListOptions struct {
 }
 
 func (s *MeetingRegistrantsService) List(ctx context.Context, opts *RegistrantListOptions) ([]*MeetingRegistrant, error) {
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.List")
+
 	if opts == nil || opts.MeetingID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingRegistrantsService.List", "opts.MeetingID")
 	}
 
+	var response struct {
+		Items []*MeetingRegistrant `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "meetingRegistrants", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all registrants of a meeting.
+func (s *MeetingRegistrantsService) ListPages(opts *RegistrantListOptions) (*core.Pager[MeetingRegistrant], error) {
+	if opts == nil || opts.MeetingID == "" {
+		return nil, core.InvalidParameter("MeetingRegistrantsService.ListPages", "opts.MeetingID")
+	}
+
+	return core.NewPager[MeetingRegistrant](s.session, "meeting.MeetingRegistrants.ListPages", "meetingRegistrants", opts.params()), nil
+}
+
+// ListAll iterates over all registrants of a meeting, following pagination links.
+func (s *MeetingRegistrantsService) ListAll(ctx context.Context, opts *RegistrantListOptions) iter.Seq2[*MeetingRegistrant, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[MeetingRegistrant](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *RegistrantListOptions) params() url.Values {
 	params := url.Values{}
 	params.Set("meetingId", opts.MeetingID)
 
@@ -89,15 +145,7 @@ func (s *MeetingRegistrantsService) List(ctx context.Context, opts *RegistrantLi
 		params.Set("max", strconv.Itoa(opts.Max))
 	}
 
-	var response struct {
-		Items []*MeetingRegistrant `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "meetingRegistrants", params, &response); err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	return params
 }
 
 type RegistrantCreateRequest struct {
@@ -121,8 +169,17 @@ type RegistrantCreateRequest struct {
 }
 
 func (s *MeetingRegistrantsService) Create(ctx context.Context, req *RegistrantCreateRequest) (*MeetingRegistrant, error) {
-	if req == nil || req.MeetingID == "" || req.FirstName == "" || req.LastName == "" || req.Email == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.Create")
+
+	switch {
+	case req == nil || req.MeetingID == "":
+		return nil, core.InvalidParameter("MeetingRegistrantsService.Create", "req.MeetingID")
+	case req.FirstName == "":
+		return nil, core.InvalidParameter("MeetingRegistrantsService.Create", "req.FirstName")
+	case req.LastName == "":
+		return nil, core.InvalidParameter("MeetingRegistrantsService.Create", "req.LastName")
+	case req.Email == "":
+		return nil, core.InvalidParameter("MeetingRegistrantsService.Create", "req.Email")
 	}
 
 	var registrant MeetingRegistrant
@@ -150,8 +207,13 @@ type BulkRegistrantItem struct {
 }
 
 func (s *MeetingRegistrantsService) BulkCreate(ctx context.Context, req *BulkRegistrantCreateRequest) ([]*MeetingRegistrant, error) {
-	if req == nil || req.MeetingID == "" || len(req.Items) == 0 {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.BulkCreate")
+
+	switch {
+	case req == nil || req.MeetingID == "":
+		return nil, core.InvalidParameter("MeetingRegistrantsService.BulkCreate", "req.MeetingID")
+	case len(req.Items) == 0:
+		return nil, core.InvalidParameter("MeetingRegistrantsService.BulkCreate", "req.Items")
 	}
 
 	var response struct {
@@ -166,8 +228,10 @@ func (s *MeetingRegistrantsService) BulkCreate(ctx context.Context, req *BulkReg
 }
 
 func (s *MeetingRegistrantsService) Get(ctx context.Context, registrantID string) (*MeetingRegistrant, error) {
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.Get")
+
 	if registrantID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingRegistrantsService.Get", "registrantID")
 	}
 
 	var registrant MeetingRegistrant
@@ -180,14 +244,19 @@ func (s *MeetingRegistrantsService) Get(ctx context.Context, registrantID string
 }
 
 type RegistrantUpdateStatusRequest struct {
-	Status    string `json:"status"`
-	SendEmail bool   `json:"sendEmail,omitempty"`
-	HostEmail string `json:"hostEmail,omitempty"`
+	Status    RegistrantStatus `json:"status"`
+	SendEmail bool             `json:"sendEmail,omitempty"`
+	HostEmail string           `json:"hostEmail,omitempty"`
 }
 
 func (s *MeetingRegistrantsService) UpdateStatus(ctx context.Context, registrantID string, req *RegistrantUpdateStatusRequest) (*MeetingRegistrant, error) {
-	if registrantID == "" || req == nil || req.Status == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.UpdateStatus")
+
+	switch {
+	case registrantID == "":
+		return nil, core.InvalidParameter("MeetingRegistrantsService.UpdateStatus", "registrantID")
+	case req == nil || !req.Status.IsValid():
+		return nil, core.InvalidParameter("MeetingRegistrantsService.UpdateStatus", "req.Status")
 	}
 
 	var response MeetingRegistrant
@@ -200,8 +269,10 @@ func (s *MeetingRegistrantsService) UpdateStatus(ctx context.Context, registrant
 }
 
 func (s *MeetingRegistrantsService) Delete(ctx context.Context, registrantID string) error {
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.Delete")
+
 	if registrantID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("MeetingRegistrantsService.Delete", "registrantID")
 	}
 
 	return s.session.Delete(ctx, "meetingRegistrants/"+registrantID)
@@ -241,8 +312,10 @@ type RegistrationQuestion struct {
 }
 
 func (s *MeetingRegistrantsService) GetRegistrationForm(ctx context.Context, meetingID string, opts *QueryRegistrationFormOption) (*RegistrationForm, error) {
+	ctx = core.WithOperation(ctx, "meeting.MeetingRegistrants.GetRegistrationForm")
+
 	if meetingID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MeetingRegistrantsService.GetRegistrationForm", "meetingID")
 	}
 
 	params := url.Values{}
@@ -0,0 +1,92 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+const AttachmentActionTypeSubmit = "submit"
+
+// AttachmentAction is a user's submission of an Adaptive Card.
+type AttachmentAction struct {
+	ID        string         `json:"id,omitempty"`
+	Type      string         `json:"type,omitempty"`
+	MessageID string         `json:"messageId,omitempty"`
+	Inputs    map[string]any `json:"inputs,omitempty"`
+	PersonID  string         `json:"personId,omitempty"`
+	RoomID    string         `json:"roomId,omitempty"`
+	Created   time.Time      `json:"created,omitempty"`
+}
+
+// DecodeInputs decodes the submitted inputs into v, typically a struct with
+// json tags matching the card's input IDs. Webex submits input values as
+// strings, so fields should be strings or use the ",string" option.
+func (a *AttachmentAction) DecodeInputs(v any) error {
+	data, err := json.Marshal(a.Inputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode attachment action inputs: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode attachment action inputs: %w", err)
+	}
+	return nil
+}
+
+type AttachmentActionsService struct {
+	session *core.RestSession
+}
+
+func NewAttachmentActionsService(session *core.RestSession) *AttachmentActionsService {
+	return &AttachmentActionsService{
+		session: session,
+	}
+}
+
+type AttachmentActionCreateRequest struct {
+	Type      string         `json:"type"`
+	MessageID string         `json:"messageId"`
+	Inputs    map[string]any `json:"inputs,omitempty"`
+}
+
+// Create submits an action for the card attached to a message. Type defaults
+// to "submit".
+func (s *AttachmentActionsService) Create(ctx context.Context, req *AttachmentActionCreateRequest) (*AttachmentAction, error) {
+	ctx = core.WithOperation(ctx, "messaging.AttachmentActions.Create")
+
+	if req == nil || req.MessageID == "" {
+		return nil, core.InvalidParameter("AttachmentActionsService.Create", "req.MessageID")
+	}
+
+	body := *req
+	if body.Type == "" {
+		body.Type = AttachmentActionTypeSubmit
+	}
+
+	var action AttachmentAction
+	if err := s.session.Post(ctx, "attachment/actions", &body, &action); err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// Get returns an attachment action, including the submitted inputs.
+func (s *AttachmentActionsService) Get(ctx context.Context, actionID string) (*AttachmentAction, error) {
+	ctx = core.WithOperation(ctx, "messaging.AttachmentActions.Get")
+
+	if actionID == "" {
+		return nil, core.InvalidParameter("AttachmentActionsService.Get", "actionID")
+	}
+
+	var action AttachmentAction
+	if err := s.session.Get(ctx, "attachment/actions/"+actionID, nil, &action); err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
@@ -0,0 +1,50 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+func TestAttachmentActionsServiceGetDecodeInputs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/attachment/actions/action-1" {
+			t.Errorf("expected /attachment/actions/action-1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "action-1",
+			"type": "submit",
+			"messageId": "message-1",
+			"inputs": {"service": "api", "replicas": "3", "action": "deploy"}
+		}`))
+	}))
+	defer server.Close()
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	action, err := NewAttachmentActionsService(session).Get(context.Background(), "action-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var inputs struct {
+		Service  string `json:"service"`
+		Replicas int    `json:"replicas,string"`
+		Action   string `json:"action"`
+	}
+	if err := action.DecodeInputs(&inputs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if inputs.Service != "api" || inputs.Replicas != 3 || inputs.Action != "deploy" {
+		t.Errorf("unexpected inputs: %+v", inputs)
+	}
+}
@@ -1,13 +1,20 @@
 // Package messaging provides access to the webex messaging API.
-// It includes services for managing messages, rooms, people, webhooks, teams, team memberships, and memberships.
+// It includes services for managing messages, rooms, people, webhooks, teams, team memberships, memberships and attachment actions.
 
 package messaging
 
 // This file serves as the package documentation for the messaging package.
 // All types and services are defined in their respective files:
 // - messaging.go: Messages service.
+// - threads.go: Thread and Conversation grouping of messages.
+// - markdown.go: Markdown builder with mentions and message splitting.
+// - attachment_actions.go: Attachment actions service for card submissions.
+// - files.go: Files service for downloading message attachments.
 // - rooms.go: Room service.
 // - people.go: People service.
 // - webhooks.go: Webhooks service.
+// - webhooks_reconcile.go: Declarative webhook reconciliation.
+// - events.go: Events service for org-wide activity.
+// - events_poll.go: Change feed over the events API.
 // - teams.go: Teams service.
 // - memberships.go: Membership and Team Memberships services.
\ No newline at end of file
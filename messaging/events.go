@@ -0,0 +1,219 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// Event records an activity in the organization, such as a message being
+// posted or a membership being deleted. Listing events requires a compliance
+// officer token.
+type Event struct {
+	ID       string          `json:"id,omitempty"`
+	Resource EventResource   `json:"resource,omitempty"`
+	Type     EventType       `json:"type,omitempty"`
+	AppID    string          `json:"appId,omitempty"`
+	ActorID  string          `json:"actorId,omitempty"`
+	OrgID    string          `json:"orgId,omitempty"`
+	Created  time.Time       `json:"created,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// DecodeData decodes the resource snapshot attached to the event into v, for
+// example a *Message for message events.
+func (e *Event) DecodeData(v any) error {
+	if len(e.Data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode event data: %w", err)
+	}
+	return nil
+}
+
+// EventResource is the kind of resource an event is about.
+type EventResource string
+
+const (
+	EventResourceAttachmentActions  EventResource = "attachmentActions"
+	EventResourceFileTranscodings   EventResource = "file_transcodings"
+	EventResourceMeetingMessages    EventResource = "meetingMessages"
+	EventResourceMeetingTranscripts EventResource = "meetingTranscripts"
+	EventResourceMeetings           EventResource = "meetings"
+	EventResourceMemberships        EventResource = "memberships"
+	EventResourceMessages           EventResource = "messages"
+	EventResourceRooms              EventResource = "rooms"
+	EventResourceTabs               EventResource = "tabs"
+)
+
+// IsValid reports whether r is a known event resource.
+func (r EventResource) IsValid() bool {
+	switch r {
+	case EventResourceAttachmentActions, EventResourceFileTranscodings, EventResourceMeetingMessages, EventResourceMeetingTranscripts, EventResourceMeetings, EventResourceMemberships, EventResourceMessages, EventResourceRooms, EventResourceTabs:
+		return true
+	}
+	return false
+}
+
+func (r EventResource) String() string {
+	return string(r)
+}
+
+// EventType is the action an event records.
+type EventType string
+
+const (
+	EventTypeCreated EventType = "created"
+	EventTypeUpdated EventType = "updated"
+	EventTypeDeleted EventType = "deleted"
+	EventTypeEnded   EventType = "ended"
+)
+
+// IsValid reports whether t is a known event type.
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventTypeCreated, EventTypeUpdated, EventTypeDeleted, EventTypeEnded:
+		return true
+	}
+	return false
+}
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+type EventsService struct {
+	session *core.RestSession
+}
+
+func NewEventsService(session *core.RestSession) *EventsService {
+	return &EventsService{
+		session: session,
+	}
+}
+
+type EventListOptions struct {
+	// Resource lists events about this kind of resource.
+	Resource EventResource
+
+	// Type lists events of this type.
+	Type EventType
+
+	// ActorID lists events caused by this person.
+	ActorID string
+
+	// From lists events created at or after this time.
+	From time.Time
+
+	// To lists events created before this time.
+	To time.Time
+
+	// Max limits the number of events returned per page.
+	Max int
+}
+
+// List returns the first page of events matching opts.
+func (s *EventsService) List(ctx context.Context, opts *EventListOptions) ([]*Event, error) {
+	ctx = core.WithOperation(ctx, "messaging.Events.List")
+
+	if err := opts.validate("EventsService.List"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Items []*Event `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "events", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all events matching opts.
+func (s *EventsService) ListPages(opts *EventListOptions) (*core.Pager[Event], error) {
+	if err := opts.validate("EventsService.ListPages"); err != nil {
+		return nil, err
+	}
+
+	return core.NewPager[Event](s.session, "messaging.Events.ListPages", "events", opts.params()), nil
+}
+
+// ListAll iterates over all events matching opts, following pagination links.
+func (s *EventsService) ListAll(ctx context.Context, opts *EventListOptions) iter.Seq2[*Event, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Event](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (s *EventsService) Get(ctx context.Context, eventID string) (*Event, error) {
+	ctx = core.WithOperation(ctx, "messaging.Events.Get")
+
+	if eventID == "" {
+		return nil, core.InvalidParameter("EventsService.Get", "eventID")
+	}
+
+	var event Event
+	if err := s.session.Get(ctx, "events/"+eventID, nil, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (opts *EventListOptions) validate(method string) error {
+	switch {
+	case opts == nil:
+		return nil
+	case opts.Resource != "" && !opts.Resource.IsValid():
+		return core.InvalidParameter(method, "opts.Resource")
+	case opts.Type != "" && !opts.Type.IsValid():
+		return core.InvalidParameter(method, "opts.Type")
+	case !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To):
+		return core.InvalidParameter(method, "opts.To")
+	}
+	return nil
+}
+
+func (opts *EventListOptions) params() url.Values {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Resource != "" {
+			params.Set("resource", string(opts.Resource))
+		}
+
+		if opts.Type != "" {
+			params.Set("type", string(opts.Type))
+		}
+
+		if opts.ActorID != "" {
+			params.Set("actorId", opts.ActorID)
+		}
+
+		if !opts.From.IsZero() {
+			params.Set("from", opts.From.UTC().Format(time.RFC3339Nano))
+		}
+
+		if !opts.To.IsZero() {
+			params.Set("to", opts.To.UTC().Format(time.RFC3339Nano))
+		}
+
+		if opts.Max > 0 {
+			params.Set("max", strconv.Itoa(opts.Max))
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,225 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// DefaultEventPollInterval is how often Poll checks for new events unless
+// EventPollOptions.Interval says otherwise.
+const DefaultEventPollInterval = 30 * time.Second
+
+// EventCursor is the high-water mark of an event feed: the creation time of
+// the newest event delivered and the IDs of the events delivered with that
+// time, which the next poll sees again because From is inclusive.
+type EventCursor struct {
+	Time time.Time `json:"time"`
+	IDs  []string  `json:"ids,omitempty"`
+}
+
+// EventCursorStore persists the cursor of an event feed so that a restarted
+// poller resumes where the previous one stopped.
+type EventCursorStore interface {
+	// Load returns the saved cursor, or nil if there is none yet.
+	Load() (*EventCursor, error)
+	Save(cursor *EventCursor) error
+}
+
+type EventPollOptions struct {
+	// Resource, Type and ActorID filter the events as in EventListOptions.
+	Resource EventResource
+	Type     EventType
+	ActorID  string
+
+	// Since is where the feed starts when Store holds no cursor. Defaults to
+	// the time Poll is called.
+	Since time.Time
+
+	// Interval is the delay between polls. Defaults to
+	// DefaultEventPollInterval.
+	Interval time.Duration
+
+	// Store, when set, provides the initial cursor and receives the cursor
+	// after every poll that delivered events.
+	Store EventCursorStore
+
+	// OnError is called when a poll or saving the cursor fails. The feed
+	// keeps running and retries on the next poll.
+	OnError func(error)
+}
+
+// Poll delivers new events on the returned channel, oldest first, until ctx
+// is cancelled, after which the channel is closed. Each poll lists the events
+// created since the cursor, drops those already delivered, and advances the
+// cursor. Events are delivered at least once: the cursor is saved after the
+// events it covers have been received from the channel.
+func (s *EventsService) Poll(ctx context.Context, opts *EventPollOptions) (<-chan *Event, error) {
+	if opts == nil {
+		opts = &EventPollOptions{}
+	}
+
+	listOpts := &EventListOptions{Resource: opts.Resource, Type: opts.Type, ActorID: opts.ActorID}
+	if err := listOpts.validate("EventsService.Poll"); err != nil {
+		return nil, err
+	}
+
+	if opts.Interval < 0 {
+		return nil, core.InvalidParameter("EventsService.Poll", "opts.Interval")
+	}
+
+	var cursor EventCursor
+	if opts.Store != nil {
+		saved, err := opts.Store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load event cursor: %w", err)
+		}
+		if saved != nil {
+			cursor = *saved
+		}
+	}
+
+	if cursor.Time.IsZero() {
+		cursor.Time = opts.Since
+		if cursor.Time.IsZero() {
+			cursor.Time = time.Now()
+		}
+	}
+
+	interval := opts.Interval
+	if interval == 0 {
+		interval = DefaultEventPollInterval
+	}
+
+	feed := &eventFeed{
+		service:  s,
+		opts:     opts,
+		listOpts: *listOpts,
+		cursor:   cursor,
+		events:   make(chan *Event),
+	}
+
+	go feed.run(ctx, interval)
+	return feed.events, nil
+}
+
+type eventFeed struct {
+	service  *EventsService
+	opts     *EventPollOptions
+	listOpts EventListOptions
+	cursor   EventCursor
+	events   chan *Event
+}
+
+func (f *eventFeed) run(ctx context.Context, interval time.Duration) {
+	defer close(f.events)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if err := f.poll(ctx); err != nil && ctx.Err() == nil {
+			f.report(err)
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// poll delivers the events created since the cursor and saves the advanced
+// cursor, including when ctx is cancelled part way through.
+func (f *eventFeed) poll(ctx context.Context) error {
+	events, err := f.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	delivered := 0
+	defer func() {
+		if delivered > 0 {
+			f.save()
+		}
+	}()
+
+	for _, event := range events {
+		select {
+		case f.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		f.advance(event)
+		delivered++
+	}
+
+	return nil
+}
+
+// fetch lists every page of events created since the cursor and returns the
+// new ones in creation order. Events that move across a page boundary while
+// paging, or that were delivered by the previous poll, are dropped.
+func (f *eventFeed) fetch(ctx context.Context) ([]*Event, error) {
+	opts := f.listOpts
+	opts.From = f.cursor.Time
+
+	seen := make(map[string]bool, len(f.cursor.IDs))
+	for _, id := range f.cursor.IDs {
+		seen[id] = true
+	}
+
+	var events []*Event
+	for event, err := range f.service.ListAll(ctx, &opts) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+
+		if seen[event.ID] || event.Created.Before(f.cursor.Time) {
+			continue
+		}
+		seen[event.ID] = true
+		events = append(events, event)
+	}
+
+	// The API lists events newest first; reversing keeps events created at
+	// the same time in their original order.
+	slices.Reverse(events)
+	slices.SortStableFunc(events, func(a, b *Event) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	return events, nil
+}
+
+func (f *eventFeed) advance(event *Event) {
+	if event.Created.After(f.cursor.Time) {
+		f.cursor.Time = event.Created
+		f.cursor.IDs = nil
+	}
+	f.cursor.IDs = append(f.cursor.IDs, event.ID)
+}
+
+func (f *eventFeed) save() {
+	if f.opts.Store == nil {
+		return
+	}
+
+	cursor := EventCursor{Time: f.cursor.Time, IDs: slices.Clone(f.cursor.IDs)}
+	if err := f.opts.Store.Save(&cursor); err != nil {
+		f.report(fmt.Errorf("failed to save event cursor: %w", err))
+	}
+}
+
+func (f *eventFeed) report(err error) {
+	if f.opts.OnError != nil {
+		f.opts.OnError(err)
+	}
+}
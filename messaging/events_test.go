@@ -0,0 +1,149 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// fakeEventsAPI serves events newest first, two per page, with each page
+// repeating the last event of the previous one.
+type fakeEventsAPI struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+func (f *fakeEventsAPI) add(event *Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+}
+
+func (f *fakeEventsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	from, _ := time.Parse(time.RFC3339Nano, r.URL.Query().Get("from"))
+	var matched []*Event
+	for _, event := range slices.Backward(f.events) {
+		if !event.Created.Before(from) && (r.URL.Query().Get("resource") == "" || string(event.Resource) == r.URL.Query().Get("resource")) {
+			matched = append(matched, event)
+		}
+	}
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+	end := min(start+2, len(matched))
+	if end < len(matched) {
+		next := r.URL.Query()
+		next.Set("start", strconv.Itoa(end-1))
+		w.Header().Set("Link", `<`+r.URL.Path+"?"+next.Encode()+`>; rel="next"`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"items": matched[start:end]})
+}
+
+type memoryCursorStore struct {
+	mu     sync.Mutex
+	cursor *EventCursor
+}
+
+func (s *memoryCursorStore) Load() (*EventCursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor, nil
+}
+
+func (s *memoryCursorStore) Save(cursor *EventCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = cursor
+	return nil
+}
+
+func TestEventsServicePoll(t *testing.T) {
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	api := &fakeEventsAPI{events: []*Event{
+		{ID: "old", Resource: EventResourceMessages, Created: base.Add(-time.Minute)},
+		{ID: "e1", Resource: EventResourceMessages, Created: base},
+		{ID: "e2", Resource: EventResourceMessages, Created: base.Add(time.Second)},
+		{ID: "r1", Resource: EventResourceRooms, Created: base.Add(time.Second)},
+		{ID: "e3", Resource: EventResourceMessages, Created: base.Add(time.Second)},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+	service := NewEventsService(session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &memoryCursorStore{}
+	events, err := service.Poll(ctx, &EventPollOptions{
+		Resource: EventResourceMessages,
+		Since:    base,
+		Interval: 10 * time.Millisecond,
+		Store:    store,
+		OnError:  func(err error) { t.Errorf("unexpected error: %v", err) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receive := func() string {
+		select {
+		case event := <-events:
+			return event.ID
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+			return ""
+		}
+	}
+
+	var got []string
+	for range 3 {
+		got = append(got, receive())
+	}
+	if !slices.Equal(got, []string{"e1", "e2", "e3"}) {
+		t.Errorf("expected events oldest first without duplicates, got %v", got)
+	}
+
+	api.add(&Event{ID: "e4", Resource: EventResourceMessages, Created: base.Add(2 * time.Second)})
+	if id := receive(); id != "e4" {
+		t.Errorf("expected only the new event, got %s", id)
+	}
+
+	cancel()
+	for event := range events {
+		t.Errorf("unexpected event after cancel: %s", event.ID)
+	}
+
+	if cursor, _ := store.Load(); cursor == nil || !cursor.Time.Equal(base.Add(2*time.Second)) || !slices.Equal(cursor.IDs, []string{"e4"}) {
+		t.Errorf("expected the cursor to be saved at e4, got %+v", cursor)
+	}
+}
+
+func TestEventsServiceValidatesFilters(t *testing.T) {
+	service := NewEventsService(core.NewRestSession(&core.RestSessionConfig{AccessToken: "test-token"}))
+
+	if _, err := service.List(context.Background(), &EventListOptions{Resource: "mesages"}); !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("expected an invalid resource to be rejected, got %v", err)
+	}
+
+	if _, err := service.Poll(context.Background(), &EventPollOptions{Type: "removed"}); !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("expected an invalid type to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// FileInfo describes a file attached to a message.
+type FileInfo struct {
+	URL         string
+	Name        string
+	ContentType string
+
+	// Size is the file length in bytes, or -1 when unknown.
+	Size int64
+}
+
+// DownloadedFile is a file saved by DownloadRoom.
+type DownloadedFile struct {
+	FileInfo
+	MessageID string
+	Path      string
+}
+
+// FilesService downloads the files attached to messages. Files are fetched
+// from the content URLs in Message.Files with the client's access token.
+type FilesService struct {
+	session  *core.RestSession
+	messages *MessagesService
+}
+
+func NewFilesService(session *core.RestSession) *FilesService {
+	return &FilesService{
+		session:  session,
+		messages: NewMessagesService(session),
+	}
+}
+
+// Info returns the name, content type and size of a file without
+// downloading it. It returns a FileScanningError while the file is
+// being scanned.
+func (s *FilesService) Info(ctx context.Context, fileURL string) (*FileInfo, error) {
+	ctx = core.WithOperation(ctx, "messaging.Files.Info")
+
+	if fileURL == "" {
+		return nil, core.InvalidParameter("FilesService.Info", "fileURL")
+	}
+
+	header, err := s.session.Head(ctx, fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFileInfo(fileURL, header), nil
+}
+
+// Download streams a file to w and returns its metadata. It returns a
+// FileScanningError while the file is being scanned. The client's request
+// timeout does not apply to the transfer; bound it with ctx instead.
+func (s *FilesService) Download(ctx context.Context, fileURL string, w io.Writer) (*FileInfo, error) {
+	ctx = core.WithOperation(ctx, "messaging.Files.Download")
+
+	switch {
+	case fileURL == "":
+		return nil, core.InvalidParameter("FilesService.Download", "fileURL")
+	case w == nil:
+		return nil, core.InvalidParameter("FilesService.Download", "w")
+	}
+
+	header, err := s.session.Download(ctx, fileURL, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFileInfo(fileURL, header), nil
+}
+
+// DownloadRoom saves every file attached to the messages of a room into dir,
+// which is created if needed. Files are named after their Content-Disposition
+// filename; existing files are not overwritten, a numeric suffix is added
+// instead. On error the files saved so far are returned with it.
+func (s *FilesService) DownloadRoom(ctx context.Context, roomID, dir string) ([]*DownloadedFile, error) {
+	switch {
+	case roomID == "":
+		return nil, core.InvalidParameter("FilesService.DownloadRoom", "roomID")
+	case dir == "":
+		return nil, core.InvalidParameter("FilesService.DownloadRoom", "dir")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	var downloaded []*DownloadedFile
+	for message, err := range s.messages.ListAll(ctx, &MessageListOptions{RoomID: roomID}) {
+		if err != nil {
+			return downloaded, err
+		}
+
+		for _, fileURL := range message.Files {
+			file, err := s.downloadToDir(ctx, fileURL, dir)
+			if err != nil {
+				return downloaded, err
+			}
+
+			file.MessageID = message.ID
+			downloaded = append(downloaded, file)
+		}
+	}
+
+	return downloaded, nil
+}
+
+// downloadToDir downloads into a temporary file first, because the file name
+// is only known from the response headers.
+func (s *FilesService) downloadToDir(ctx context.Context, fileURL, dir string) (*DownloadedFile, error) {
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	info, err := s.Download(ctx, fileURL, tmp)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write file: %w", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := moveUnique(tmp.Name(), dir, info.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadedFile{FileInfo: *info, Path: target}, nil
+}
+
+// moveUnique moves src to name in dir, adding a numeric suffix when the name
+// is taken. The target is reserved with O_EXCL so that an existing file is
+// never replaced.
+func moveUnique(src, dir, name string) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for i := 0; ; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+
+		target := filepath.Join(dir, candidate)
+		reserved, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create file %s: %w", target, err)
+		}
+		reserved.Close()
+
+		if err := os.Rename(src, target); err != nil {
+			os.Remove(target)
+			return "", fmt.Errorf("failed to save file %s: %w", target, err)
+		}
+		return target, nil
+	}
+}
+
+func newFileInfo(fileURL string, header http.Header) *FileInfo {
+	info := &FileInfo{
+		URL:         fileURL,
+		ContentType: header.Get("Content-Type"),
+		Size:        -1,
+	}
+
+	if size, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = size
+	}
+
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		info.Name = params["filename"]
+	}
+
+	// Never let a server supplied name escape the target directory.
+	info.Name = filepath.Base(filepath.Clean("/" + info.Name))
+	if info.Name == "/" || info.Name == "." || info.Name == string(filepath.Separator) {
+		info.Name = path.Base(fileURL)
+	}
+
+	return info
+}
@@ -0,0 +1,162 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+func newFilesTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected bearer token on %s", r.URL.Path)
+		}
+
+		switch r.URL.Path {
+		case "/messages":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"items":[
+				{"id":"m1","files":["` + server.URL + `/contents/1"]},
+				{"id":"m2","files":["` + server.URL + `/contents/2"]}
+			]}`))
+		case "/contents/1", "/contents/2":
+			w.Header().Set("Content-Disposition", `attachment; filename="../report.txt"`)
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("hello"))
+		case "/contents/scanning":
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusLocked)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestFilesServiceDownload(t *testing.T) {
+	server := newFilesTestServer(t)
+	service := NewFilesService(core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	}))
+
+	info, err := service.Info(context.Background(), server.URL+"/contents/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Name != "report.txt" || info.ContentType != "text/plain" || info.Size != 5 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	var buf bytes.Buffer
+	if _, err := service.Download(context.Background(), server.URL+"/contents/1", &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != "hello" {
+		t.Errorf("expected hello, got %q", buf.String())
+	}
+
+	_, err = service.Download(context.Background(), server.URL+"/contents/scanning", &buf)
+	var scanning *core.FileScanningError
+	if !errors.As(err, &scanning) || scanning.RetryAfter != 3*time.Second {
+		t.Errorf("expected FileScanningError with 3s retry, got %v", err)
+	}
+}
+
+func TestFilesServiceDownloadRoom(t *testing.T) {
+	server := newFilesTestServer(t)
+	service := NewFilesService(core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	}))
+
+	dir := t.TempDir()
+	files, err := service.DownloadRoom(context.Background(), "room-1", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"report.txt", "report (1).txt"}
+	if len(files) != len(want) {
+		t.Fatalf("expected %d files, got %d", len(want), len(files))
+	}
+
+	for i, file := range files {
+		if file.Path != filepath.Join(dir, want[i]) {
+			t.Errorf("expected %s, got %s", want[i], file.Path)
+		}
+
+		data, err := os.ReadFile(file.Path)
+		if err != nil || string(data) != "hello" {
+			t.Errorf("unexpected content of %s: %q, %v", file.Path, data, err)
+		}
+	}
+
+	if files[1].MessageID != "m2" {
+		t.Errorf("expected message m2, got %s", files[1].MessageID)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("expected no temporary files to be left, got %d entries", len(entries))
+	}
+}
+
+func TestFilesServiceRejectsForeignURLs(t *testing.T) {
+	server := newFilesTestServer(t)
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to a foreign host with Authorization %q", r.Header.Get("Authorization"))
+	}))
+	defer foreign.Close()
+
+	service := NewFilesService(core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	}))
+
+	if _, err := service.Info(context.Background(), foreign.URL+"/contents/1"); !errors.Is(err, core.ErrForeignURL) {
+		t.Errorf("expected ErrForeignURL from Info, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := service.Download(context.Background(), foreign.URL+"/contents/1", &buf); !errors.Is(err, core.ErrForeignURL) {
+		t.Errorf("expected ErrForeignURL from Download, got %v", err)
+	}
+}
+
+func TestFilesServiceDownloadOutlivesRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for range 5 {
+			w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	service := NewFilesService(core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+		Timeout:     30 * time.Millisecond,
+	}))
+
+	var buf bytes.Buffer
+	if _, err := service.Download(context.Background(), server.URL+"/contents/big", &buf); err != nil || buf.Len() != 25 {
+		t.Errorf("expected the whole file despite the request timeout, got %d bytes, %v", buf.Len(), err)
+	}
+}
@@ -0,0 +1,365 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// MaxMessageLength is the largest text or markdown body, in bytes, Webex
+// accepts for a message.
+const MaxMessageLength = 7439
+
+var (
+	ErrMessageTooLong = errors.New("message exceeds the maximum length")
+)
+
+// markdownBlock is a line or code block. Blocks are the units split between
+// messages; a code block keeps its fence so that it can be reopened.
+type markdownBlock struct {
+	text  string
+	fence string
+	lang  string
+}
+
+// MarkdownBuilder builds message markdown. Text passed to its methods is
+// escaped, except for Raw.
+type MarkdownBuilder struct {
+	blocks []markdownBlock
+	line   strings.Builder
+	limit  int
+}
+
+func NewMarkdownBuilder() *MarkdownBuilder {
+	return &MarkdownBuilder{limit: MaxMessageLength}
+}
+
+// Text appends escaped text.
+func (b *MarkdownBuilder) Text(text string) *MarkdownBuilder {
+	b.line.WriteString(escapeMarkdown(text, b.line.Len() == 0))
+	return b
+}
+
+// Raw appends markdown without escaping.
+func (b *MarkdownBuilder) Raw(markdown string) *MarkdownBuilder {
+	b.line.WriteString(markdown)
+	return b
+}
+
+func (b *MarkdownBuilder) Bold(text string) *MarkdownBuilder {
+	return b.Raw("**" + escapeMarkdown(text, false) + "**")
+}
+
+func (b *MarkdownBuilder) Italic(text string) *MarkdownBuilder {
+	return b.Raw("*" + escapeMarkdown(text, false) + "*")
+}
+
+// Code appends inline code.
+func (b *MarkdownBuilder) Code(code string) *MarkdownBuilder {
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return b.Raw(fence + code + fence)
+}
+
+func (b *MarkdownBuilder) Link(text, url string) *MarkdownBuilder {
+	url = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace(url)
+	return b.Raw("[" + escapeMarkdown(text, false) + "](" + url + ")")
+}
+
+// MentionPerson mentions a person by ID. Name is the text shown in the
+// message.
+func (b *MarkdownBuilder) MentionPerson(personID, name string) *MarkdownBuilder {
+	return b.Raw("<@personId:" + personID + "|" + mentionName(name) + ">")
+}
+
+// MentionEmail mentions a person by email address.
+func (b *MarkdownBuilder) MentionEmail(email, name string) *MarkdownBuilder {
+	return b.Raw("<@personEmail:" + email + "|" + mentionName(name) + ">")
+}
+
+// MentionAll mentions everyone in the room.
+func (b *MarkdownBuilder) MentionAll() *MarkdownBuilder {
+	return b.Raw("<@all>")
+}
+
+// Line ends the current line.
+func (b *MarkdownBuilder) Line() *MarkdownBuilder {
+	b.blocks = append(b.blocks, markdownBlock{text: b.line.String()})
+	b.line.Reset()
+	return b
+}
+
+// Paragraph ends the current line and adds a blank line.
+func (b *MarkdownBuilder) Paragraph() *MarkdownBuilder {
+	return b.Line().Line()
+}
+
+// List appends a bulleted list of escaped items.
+func (b *MarkdownBuilder) List(items ...string) *MarkdownBuilder {
+	b.flush()
+	for _, item := range items {
+		b.blocks = append(b.blocks, markdownBlock{text: "- " + escapeMarkdown(item, false)})
+	}
+	return b
+}
+
+// OrderedList appends a numbered list of escaped items.
+func (b *MarkdownBuilder) OrderedList(items ...string) *MarkdownBuilder {
+	b.flush()
+	for i, item := range items {
+		b.blocks = append(b.blocks, markdownBlock{text: fmt.Sprintf("%d. %s", i+1, escapeMarkdown(item, false))})
+	}
+	return b
+}
+
+// CodeBlock appends a fenced code block. Lang may be empty.
+func (b *MarkdownBuilder) CodeBlock(lang, code string) *MarkdownBuilder {
+	b.flush()
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	b.blocks = append(b.blocks, markdownBlock{text: strings.TrimSuffix(code, "\n"), fence: fence, lang: lang})
+	return b
+}
+
+// flush ends the current line if it has content.
+func (b *MarkdownBuilder) flush() {
+	if b.line.Len() > 0 {
+		b.Line()
+	}
+}
+
+// String returns the markdown regardless of its length.
+func (b *MarkdownBuilder) String() string {
+	return joinBlocks(b.allBlocks())
+}
+
+// Build returns the markdown, or ErrMessageTooLong when it does not fit in a
+// single message.
+func (b *MarkdownBuilder) Build() (string, error) {
+	markdown := b.String()
+	if len(markdown) > b.limit {
+		return "", fmt.Errorf("%w: %d bytes, limit is %d", ErrMessageTooLong, len(markdown), b.limit)
+	}
+	return markdown, nil
+}
+
+// Split returns the markdown split into messages that each fit the limit.
+// Messages break between lines; code blocks that are split are closed and
+// reopened, and single lines longer than the limit are cut.
+func (b *MarkdownBuilder) Split() []string {
+	var (
+		messages []string
+		current  []markdownBlock
+		size     int
+	)
+
+	emit := func() {
+		if len(current) > 0 {
+			messages = append(messages, joinBlocks(current))
+			current, size = nil, 0
+		}
+	}
+
+	for _, block := range b.splitOversized(b.allBlocks()) {
+		n := len(renderBlock(block))
+		if len(current) > 0 && size+1+n > b.limit {
+			emit()
+		}
+
+		if len(current) > 0 {
+			size++
+		}
+		current = append(current, block)
+		size += n
+	}
+	emit()
+
+	return messages
+}
+
+func (b *MarkdownBuilder) allBlocks() []markdownBlock {
+	blocks := b.blocks
+	if b.line.Len() > 0 {
+		blocks = append(blocks[:len(blocks):len(blocks)], markdownBlock{text: b.line.String()})
+	}
+	return blocks
+}
+
+// splitOversized breaks blocks longer than the limit into smaller ones.
+func (b *MarkdownBuilder) splitOversized(blocks []markdownBlock) []markdownBlock {
+	var out []markdownBlock
+	for _, block := range blocks {
+		if len(renderBlock(block)) <= b.limit {
+			out = append(out, block)
+			continue
+		}
+
+		if block.fence == "" {
+			for _, chunk := range cutString(block.text, b.limit) {
+				out = append(out, markdownBlock{text: chunk})
+			}
+			continue
+		}
+
+		// Room for the opening and closing fences of every chunk.
+		limit := b.limit - len(renderBlock(markdownBlock{fence: block.fence, lang: block.lang}))
+		var chunk []string
+		size := 0
+		for _, line := range strings.Split(block.text, "\n") {
+			for _, part := range cutString(line, limit) {
+				if len(chunk) > 0 && size+1+len(part) > limit {
+					out = append(out, markdownBlock{text: strings.Join(chunk, "\n"), fence: block.fence, lang: block.lang})
+					chunk, size = nil, 0
+				}
+				if len(chunk) > 0 {
+					size++
+				}
+				chunk = append(chunk, part)
+				size += len(part)
+			}
+		}
+		if len(chunk) > 0 {
+			out = append(out, markdownBlock{text: strings.Join(chunk, "\n"), fence: block.fence, lang: block.lang})
+		}
+	}
+	return out
+}
+
+func renderBlock(block markdownBlock) string {
+	if block.fence == "" {
+		return block.text
+	}
+	return block.fence + block.lang + "\n" + block.text + "\n" + block.fence
+}
+
+func joinBlocks(blocks []markdownBlock) string {
+	lines := make([]string, len(blocks))
+	for i, block := range blocks {
+		lines[i] = renderBlock(block)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cutString cuts s into pieces of at most limit bytes without splitting
+// UTF-8 sequences.
+func cutString(s string, limit int) []string {
+	if len(s) <= limit {
+		return []string{s}
+	}
+
+	var pieces []string
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		pieces = append(pieces, s[:cut])
+		s = s[cut:]
+	}
+	return append(pieces, s)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"(", `\(`, ")", `\)`, "<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "~", `\~`,
+)
+
+// escapeMarkdown escapes markdown syntax in user supplied text. At the start
+// of a line, list markers are escaped too.
+func escapeMarkdown(text string, lineStart bool) string {
+	lines := strings.Split(markdownEscaper.Replace(text), "\n")
+	for i, line := range lines {
+		if i == 0 && !lineStart {
+			continue
+		}
+		lines[i] = escapeLineStart(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeLineStart(line string) string {
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "+ ") {
+		return `\` + line
+	}
+
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	if digits > 0 && strings.HasPrefix(line[digits:], ". ") {
+		return line[:digits] + `\` + line[digits:]
+	}
+	return line
+}
+
+// mentionName removes characters that would end a mention early.
+func mentionName(name string) string {
+	return strings.NewReplacer("<", "", ">", "", "|", "", "\n", " ").Replace(name)
+}
+
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// MarkdownOptions controls how CreateMarkdown sends long markdown.
+type MarkdownOptions struct {
+	// Split sends markdown that exceeds MaxMessageLength as several messages
+	// instead of returning ErrMessageTooLong.
+	Split bool
+}
+
+// CreateMarkdown posts the builder's markdown using req for the destination,
+// thread, files and attachments. With Split, long markdown is sent as
+// several messages in order; they go to req.ParentID's thread, or reply to
+// the first message when no parent is set. Files and attachments are only
+// sent with the first message.
+func (s *MessagesService) CreateMarkdown(ctx context.Context, req *MessageCreateRequest, b *MarkdownBuilder, opts *MarkdownOptions) ([]*Message, error) {
+	switch {
+	case req == nil:
+		return nil, core.InvalidParameter("MessagesService.CreateMarkdown", "req")
+	case b == nil || b.String() == "":
+		return nil, core.InvalidParameter("MessagesService.CreateMarkdown", "b")
+	}
+
+	var parts []string
+	if opts != nil && opts.Split {
+		parts = b.Split()
+	} else {
+		markdown, err := b.Build()
+		if err != nil {
+			return nil, err
+		}
+		parts = []string{markdown}
+	}
+
+	messages := make([]*Message, 0, len(parts))
+	for i, markdown := range parts {
+		msg := *req
+		msg.Markdown = markdown
+		if i > 0 {
+			msg.Files, msg.Attachments = nil, nil
+			if msg.ParentID == "" {
+				msg.ParentID = messages[0].ID
+			}
+		}
+
+		message, err := s.Create(ctx, &msg)
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,120 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+func TestMarkdownBuilder(t *testing.T) {
+	b := NewMarkdownBuilder().
+		Text("Hi ").MentionPerson("person-1", "Alice <admin>").Text(" and ").MentionEmail("bob@example.com", "Bob").Line().
+		Bold("*deploy*").Text(" of ").Code("a`b").Text(" see ").Link("docs [v2]", "https://example.com/a (b)").Line().
+		Text("- not a list").Line().
+		List("one", "two_2").
+		CodeBlock("go", "fmt.Println(\"```\")\n").
+		MentionAll()
+
+	want := strings.Join([]string{
+		`Hi <@personId:person-1|Alice admin> and <@personEmail:bob@example.com|Bob>`,
+		"**\\*deploy\\*** of ``a`b`` see [docs \\[v2\\]](https://example.com/a%20%28b%29)",
+		`\- not a list`,
+		`- one`,
+		`- two\_2`,
+		"````go",
+		"fmt.Println(\"```\")",
+		"````",
+		`<@all>`,
+	}, "\n")
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownBuilderSplit(t *testing.T) {
+	b := NewMarkdownBuilder()
+	b.limit = 40
+
+	b.Text(strings.Repeat("a", 30)).Line().
+		Text(strings.Repeat("b", 30)).Line().
+		CodeBlock("", strings.Repeat("c", 20)+"\n"+strings.Repeat("d", 20)).
+		Text(strings.Repeat("é", 30))
+
+	if _, err := b.Build(); !errors.Is(err, ErrMessageTooLong) {
+		t.Fatalf("expected ErrMessageTooLong, got %v", err)
+	}
+
+	parts := b.Split()
+	want := []string{
+		strings.Repeat("a", 30),
+		strings.Repeat("b", 30),
+		"```\n" + strings.Repeat("c", 20) + "\n```",
+		"```\n" + strings.Repeat("d", 20) + "\n```",
+		strings.Repeat("é", 20),
+		strings.Repeat("é", 10),
+	}
+
+	if len(parts) != len(want) {
+		t.Fatalf("expected %d parts, got %d: %q", len(want), len(parts), parts)
+	}
+
+	for i := range want {
+		if parts[i] != want[i] {
+			t.Errorf("part %d: expected %q, got %q", i, want[i], parts[i])
+		}
+	}
+}
+
+func TestMessagesServiceCreateMarkdownSplitsIntoThread(t *testing.T) {
+	var sent []MessageCreateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req MessageCreateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req)
+		json.NewEncoder(w).Encode(Message{ID: "message-" + string(rune('0'+len(sent))), ParentID: req.ParentID})
+	}))
+	defer server.Close()
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	b := NewMarkdownBuilder()
+	b.limit = 10
+	b.Text("first line").Line().Text("second").Line().Text("third")
+
+	messages, err := NewMessagesService(session).CreateMarkdown(context.Background(), &MessageCreateRequest{
+		RoomID: "room-1",
+		Files:  []string{"https://example.com/a.png"},
+	}, b, &MarkdownOptions{Split: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(messages) != 3 || len(sent) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(sent))
+	}
+
+	if sent[0].ParentID != "" || len(sent[0].Files) != 1 {
+		t.Errorf("unexpected first message: %+v", sent[0])
+	}
+
+	for _, req := range sent[1:] {
+		if req.ParentID != "message-1" || len(req.Files) != 0 || req.RoomID != "room-1" {
+			t.Errorf("expected follow-up in thread message-1 without files, got %+v", req)
+		}
+	}
+}
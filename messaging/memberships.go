@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -40,6 +41,35 @@ type MembershipListOptions struct {
 }
 
 func (s *MembershipsService) List(ctx context.Context, opts *MembershipListOptions) ([]*Membership, error) {
+	ctx = core.WithOperation(ctx, "messaging.Memberships.List")
+
+	var response struct {
+		Items []*Membership `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "memberships", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all memberships matching opts.
+func (s *MembershipsService) ListPages(opts *MembershipListOptions) (*core.Pager[Membership], error) {
+	return core.NewPager[Membership](s.session, "messaging.Memberships.ListPages", "memberships", opts.params()), nil
+}
+
+// ListAll iterates over all memberships matching opts, following pagination links.
+func (s *MembershipsService) ListAll(ctx context.Context, opts *MembershipListOptions) iter.Seq2[*Membership, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Membership](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *MembershipListOptions) params() url.Values {
 	params := url.Values{}
 	if opts != nil {
 		if opts.RoomID != "" {
@@ -59,15 +89,7 @@ func (s *MembershipsService) List(ctx context.Context, opts *MembershipListOptio
 		}
 	}
 
-	var response struct {
-		Items []*Membership `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "memberships", params, &response); err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	return params
 }
 
 type MembershipCreateOptions struct {
@@ -78,12 +100,14 @@ type MembershipCreateOptions struct {
 }
 
 func (s *MembershipsService) Create(ctx context.Context, req *MembershipCreateOptions) (*Membership, error) {
+	ctx = core.WithOperation(ctx, "messaging.Memberships.Create")
+
 	if req == nil || req.RoomID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MembershipsService.Create", "req.RoomID")
 	}
 
 	if req.PersonID == "" && req.PersonEmail == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MembershipsService.Create", "req.PersonID or req.PersonEmail")
 	}
 
 	var membership Membership
@@ -95,8 +119,10 @@ func (s *MembershipsService) Create(ctx context.Context, req *MembershipCreateOp
 }
 
 func (s *MembershipsService) Get(ctx context.Context, membershipID string) (*Membership, error) {
+	ctx = core.WithOperation(ctx, "messaging.Memberships.Get")
+
 	if membershipID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MembershipsService.Get", "membershipID")
 	}
 
 	var membership Membership
@@ -108,13 +134,18 @@ func (s *MembershipsService) Get(ctx context.Context, membershipID string) (*Mem
 }
 
 type MembershipUpdateRequest struct {
-	IsModerator  bool `json:"isModerator,omitempty"`
-	IsRoomHidden bool `json:"isRoomHidden,omitempty"`
+	IsModerator  core.Optional[bool] `json:"isModerator,omitzero"`
+	IsRoomHidden core.Optional[bool] `json:"isRoomHidden,omitzero"`
 }
 
 func (s *MembershipsService) Update(ctx context.Context, membershipID string, req *MembershipUpdateRequest) (*Membership, error) {
-	if membershipID == "" || req == nil {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.Memberships.Update")
+
+	switch {
+	case membershipID == "":
+		return nil, core.InvalidParameter("MembershipsService.Update", "membershipID")
+	case req == nil:
+		return nil, core.InvalidParameter("MembershipsService.Update", "req")
 	}
 
 	var membership Membership
@@ -126,8 +157,10 @@ func (s *MembershipsService) Update(ctx context.Context, membershipID string, re
 }
 
 func (s *MembershipsService) Delete(ctx context.Context, membershipID string) error {
+	ctx = core.WithOperation(ctx, "messaging.Memberships.Delete")
+
 	if membershipID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("MembershipsService.Delete", "membershipID")
 	}
 
 	return s.session.Delete(ctx, "memberships/"+membershipID)
@@ -158,26 +191,53 @@ type TeamMembershipListOptions struct {
 }
 
 func (s *TeamMembershipsService) List(ctx context.Context, opts *TeamMembershipListOptions) ([]*TeamMembership, error) {
-	if opts == nil || opts.TeamID == "" {
-		return nil, core.ErrInvalidParameter
-	}
+	ctx = core.WithOperation(ctx, "messaging.TeamMemberships.List")
 
-	params := url.Values{}
-	if opts.Max > 0 {
-		params.Set("max", strconv.Itoa(opts.Max))
+	if opts == nil || opts.TeamID == "" {
+		return nil, core.InvalidParameter("TeamMembershipsService.List", "opts.TeamID")
 	}
 
 	var response struct {
 		Items []*TeamMembership `json:"items"`
 	}
 
-	if err := s.session.Get(ctx, "teams/memberships", params, &response); err != nil {
+	if err := s.session.Get(ctx, "team/memberships", opts.params(), &response); err != nil {
 		return nil, err
 	}
 
 	return response.Items, nil
 }
 
+// ListPages returns a pager over all memberships of a team.
+func (s *TeamMembershipsService) ListPages(opts *TeamMembershipListOptions) (*core.Pager[TeamMembership], error) {
+	if opts == nil || opts.TeamID == "" {
+		return nil, core.InvalidParameter("TeamMembershipsService.ListPages", "opts.TeamID")
+	}
+
+	return core.NewPager[TeamMembership](s.session, "messaging.TeamMemberships.ListPages", "team/memberships", opts.params()), nil
+}
+
+// ListAll iterates over all memberships of a team, following pagination links.
+func (s *TeamMembershipsService) ListAll(ctx context.Context, opts *TeamMembershipListOptions) iter.Seq2[*TeamMembership, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[TeamMembership](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *TeamMembershipListOptions) params() url.Values {
+	params := url.Values{}
+	params.Set("teamId", opts.TeamID)
+
+	if opts.Max > 0 {
+		params.Set("max", strconv.Itoa(opts.Max))
+	}
+
+	return params
+}
+
 type TeamMembershipCreateRequest struct {
 	TeamID      string `json:"teamId"`
 	PersonID    string `json:"personId,omitempty"`
@@ -186,12 +246,14 @@ type TeamMembershipCreateRequest struct {
 }
 
 func (s *TeamMembershipsService) Create(ctx context.Context, req *TeamMembershipCreateRequest) (*TeamMembership, error) {
+	ctx = core.WithOperation(ctx, "messaging.TeamMemberships.Create")
+
 	if req == nil || req.TeamID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("TeamMembershipsService.Create", "req.TeamID")
 	}
 
 	if req.PersonID == "" && req.PersonEmail == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("TeamMembershipsService.Create", "req.PersonID or req.PersonEmail")
 	}
 
 	var membership TeamMembership
@@ -203,8 +265,10 @@ func (s *TeamMembershipsService) Create(ctx context.Context, req *TeamMembership
 }
 
 func (s *TeamMembershipsService) Get(ctx context.Context, membershipID string) (*TeamMembership, error) {
+	ctx = core.WithOperation(ctx, "messaging.TeamMemberships.Get")
+
 	if membershipID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("TeamMembershipsService.Get", "membershipID")
 	}
 
 	var membership TeamMembership
@@ -216,12 +280,17 @@ func (s *TeamMembershipsService) Get(ctx context.Context, membershipID string) (
 }
 
 type TeamMembershipUpdateRequest struct {
-	IsModerator bool `json:"isModerator,omitempty"`
+	IsModerator core.Optional[bool] `json:"isModerator,omitzero"`
 }
 
 func (s *TeamMembershipsService) Update(ctx context.Context, membershipID string, req *TeamMembershipUpdateRequest) (*TeamMembership, error) {
-	if membershipID == "" || req == nil {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.TeamMemberships.Update")
+
+	switch {
+	case membershipID == "":
+		return nil, core.InvalidParameter("TeamMembershipsService.Update", "membershipID")
+	case req == nil:
+		return nil, core.InvalidParameter("TeamMembershipsService.Update", "req")
 	}
 
 	var membership TeamMembership
@@ -233,8 +302,10 @@ func (s *TeamMembershipsService) Update(ctx context.Context, membershipID string
 }
 
 func (s *TeamMembershipsService) Delete(ctx context.Context, membershipID string) error {
+	ctx = core.WithOperation(ctx, "messaging.TeamMemberships.Delete")
+
 	if membershipID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("TeamMembershipsService.Delete", "membershipID")
 	}
 
 	return s.session.Delete(ctx, "team/memberships/"+membershipID)
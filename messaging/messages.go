@@ -2,6 +2,8 @@ package messaging
 
 import (
 	"context"
+	"io"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -12,7 +14,7 @@ import (
 type Message struct {
 	ID              string       `json:"id,omitempty"`
 	RoomID          string       `json:"roomId,omitempty"`
-	RoomType        string       `json:"roomType,omitempty"`
+	RoomType        RoomType     `json:"roomType,omitempty"`
 	ParentID        string       `json:"parentId,omitempty"`
 	ToPersonID      string       `json:"toPersonId,omitempty"`
 	ToPersonEmail   string       `json:"toPersonEmail,omitempty"`
@@ -66,10 +68,43 @@ type MessageListOptions struct {
 }
 
 func (s *MessagesService) List(ctx context.Context, opts *MessageListOptions) ([]*Message, error) {
+	ctx = core.WithOperation(ctx, "messaging.Messages.List")
+
+	if opts == nil || opts.RoomID == "" {
+		return nil, core.InvalidParameter("MessagesService.List", "opts.RoomID")
+	}
+
+	var response struct {
+		Items []*Message `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "messages", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all messages in a room, newest first.
+func (s *MessagesService) ListPages(opts *MessageListOptions) (*core.Pager[Message], error) {
 	if opts == nil || opts.RoomID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MessagesService.ListPages", "opts.RoomID")
+	}
+
+	return core.NewPager[Message](s.session, "messaging.Messages.ListPages", "messages", opts.params()), nil
+}
+
+// ListAll iterates over all messages in a room, following pagination links.
+func (s *MessagesService) ListAll(ctx context.Context, opts *MessageListOptions) iter.Seq2[*Message, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Message](err)
 	}
 
+	return pager.All(ctx)
+}
+
+func (opts *MessageListOptions) params() url.Values {
 	params := url.Values{}
 	params.Set("roomId", opts.RoomID)
 
@@ -93,15 +128,7 @@ func (s *MessagesService) List(ctx context.Context, opts *MessageListOptions) ([
 		params.Set("max", strconv.Itoa(opts.Max))
 	}
 
-	var response struct {
-		Items []*Message `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "messages", params, &response); err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	return params
 }
 
 type MessageDirectListOptions struct {
@@ -116,6 +143,8 @@ type MessageDirectListOptions struct {
 }
 
 func (s *MessagesService) ListDirect(ctx context.Context, opts *MessageDirectListOptions) ([]*Message, error) {
+	ctx = core.WithOperation(ctx, "messaging.Messages.ListDirect")
+
 	params := url.Values{}
 
 	if opts != nil {
@@ -155,16 +184,18 @@ type MessageCreateRequest struct {
 }
 
 func (s *MessagesService) Create(ctx context.Context, req *MessageCreateRequest) (*Message, error) {
+	ctx = core.WithOperation(ctx, "messaging.Messages.Create")
+
 	if req == nil {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MessagesService.Create", "req")
 	}
 
 	if req.RoomID == "" && req.ToPersonID == "" && req.ToPersonEmail == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MessagesService.Create", "req.RoomID, req.ToPersonID or req.ToPersonEmail")
 	}
 
 	if req.Text == "" && req.Markdown == "" && len(req.Files) == 0 && len(req.Attachments) == 0 {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MessagesService.Create", "req.Text, req.Markdown, req.Files or req.Attachments")
 	}
 
 	var message Message
@@ -175,10 +206,103 @@ func (s *MessagesService) Create(ctx context.Context, req *MessageCreateRequest)
 	return &message, nil
 }
 
+// MaxFileSize is the largest file Webex accepts in a message.
+const MaxFileSize = 100 << 20
+
+// MessageFile is a local file attached to a message.
+type MessageFile struct {
+	Name        string
+	ContentType string
+	Reader      io.Reader
+
+	// Size is the length of Reader. When zero it is detected for files and
+	// readers such as bytes.Reader; otherwise the limit is enforced while
+	// streaming.
+	Size int64
+}
+
+type MessageFileOptions struct {
+	// Progress is called as file content is uploaded with the bytes sent so
+	// far and the total size, or -1 when a size is unknown.
+	Progress func(sent, total int64)
+}
+
+// CreateWithFile posts a message with files uploaded from readers. The body
+// is streamed, so large files are not held in memory. Webex currently
+// accepts one file per message.
+func (s *MessagesService) CreateWithFile(ctx context.Context, req *MessageCreateRequest, files []MessageFile, opts *MessageFileOptions) (*Message, error) {
+	ctx = core.WithOperation(ctx, "messaging.Messages.CreateWithFile")
+
+	switch {
+	case req == nil:
+		return nil, core.InvalidParameter("MessagesService.CreateWithFile", "req")
+	case len(files) == 0:
+		return nil, core.InvalidParameter("MessagesService.CreateWithFile", "files")
+	case len(req.Files) > 0:
+		return nil, core.InvalidParameter("MessagesService.CreateWithFile", "req.Files")
+	case len(req.Attachments) > 0:
+		return nil, core.InvalidParameter("MessagesService.CreateWithFile", "req.Attachments")
+	}
+
+	if req.RoomID == "" && req.ToPersonID == "" && req.ToPersonEmail == "" {
+		return nil, core.InvalidParameter("MessagesService.CreateWithFile", "req.RoomID, req.ToPersonID or req.ToPersonEmail")
+	}
+
+	upload := &core.MultipartUpload{
+		Fields:      req.fields(),
+		MaxFileSize: MaxFileSize,
+	}
+
+	if opts != nil {
+		upload.Progress = opts.Progress
+	}
+
+	for _, file := range files {
+		if file.Reader == nil || file.Name == "" {
+			return nil, core.InvalidParameter("MessagesService.CreateWithFile", "files")
+		}
+
+		upload.Files = append(upload.Files, core.MultipartFile{
+			FieldName:   "files",
+			FileName:    file.Name,
+			ContentType: file.ContentType,
+			Reader:      file.Reader,
+			Size:        file.Size,
+		})
+	}
+
+	var message Message
+	if err := s.session.PostMultipartStream(ctx, "messages", upload, &message); err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// fields returns the request as multipart form fields.
+func (req *MessageCreateRequest) fields() map[string]string {
+	fields := make(map[string]string)
+	for key, value := range map[string]string{
+		"roomId":        req.RoomID,
+		"toPersonId":    req.ToPersonID,
+		"toPersonEmail": req.ToPersonEmail,
+		"text":          req.Text,
+		"markdown":      req.Markdown,
+		"parentId":      req.ParentID,
+	} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
 // Get returns details of a message by ID.
 func (s *MessagesService) Get(ctx context.Context, messageID string) (*Message, error) {
+	ctx = core.WithOperation(ctx, "messaging.Messages.Get")
+
 	if messageID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MessagesService.Get", "messageID")
 	}
 
 	var message Message
@@ -197,8 +321,10 @@ type MessageUpdateRequest struct {
 
 // Update edits an existing message.
 func (s *MessagesService) Update(ctx context.Context, messageID string, req *MessageUpdateRequest) (*Message, error) {
+	ctx = core.WithOperation(ctx, "messaging.Messages.Update")
+
 	if messageID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("MessagesService.Update", "messageID")
 	}
 
 	var message Message
@@ -211,8 +337,10 @@ func (s *MessagesService) Update(ctx context.Context, messageID string, req *Mes
 
 // Delete removes a message.
 func (s *MessagesService) Delete(ctx context.Context, messageId string) error {
+	ctx = core.WithOperation(ctx, "messaging.Messages.Delete")
+
 	if messageId == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("MessagesService.Delete", "messageId")
 	}
 
 	return s.session.Delete(ctx, "messages/"+messageId)
@@ -3,8 +3,10 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/rainuxhe/webexgosdk/internal/core"
@@ -34,14 +36,14 @@ func TestMessagesServiceCreate(t *testing.T) {
 
 	session := core.NewRestSession(&core.RestSessionConfig{
 		AccessToken: "test-token",
-		BaseURL: server.URL + "/",
+		BaseURL:     server.URL + "/",
 	})
 
 	ctx := context.Background()
 	service := NewMessagesService(session)
 	message, err := service.Create(ctx, &MessageCreateRequest{
 		RoomID: "test-room-id",
-		Text: "Hello World",
+		Text:   "Hello World",
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -52,29 +54,90 @@ func TestMessagesServiceCreate(t *testing.T) {
 	}
 }
 
-
 func TestMessagesService_Create_InvalidParams(t *testing.T) {
 	session := core.NewRestSession(&core.RestSessionConfig{
 		AccessToken: "test-token",
-		BaseURL: "https://example.com",
+		BaseURL:     "https://example.com",
 	})
 	service := NewMessagesService(session)
 	ctx := context.Background()
-	
+
 	// Test missing destination
 	_, err := service.Create(ctx, &MessageCreateRequest{
 		Text: "hello",
 	})
-	if err != core.ErrInvalidParameter {
+	if !errors.Is(err, core.ErrInvalidParameter) {
 		t.Errorf("expected ErrInvalidParameter, got %v", err)
 	}
-	
+
 	// Test missing content
 	_, err = service.Create(ctx, &MessageCreateRequest{
 		RoomID: "test-room",
 	})
-	if err != core.ErrInvalidParameter {
+	if !errors.Is(err, core.ErrInvalidParameter) {
 		t.Errorf("expected ErrInvalidParameter, got %v", err)
 	}
-	
-}
\ No newline at end of file
+}
+
+func TestMessagesService_ListAll_InvalidParams(t *testing.T) {
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     "https://example.com",
+	})
+	service := NewMessagesService(session)
+
+	count := 0
+	for _, err := range service.ListAll(context.Background(), &MessageListOptions{}) {
+		count++
+		if !errors.Is(err, core.ErrInvalidParameter) {
+			t.Errorf("expected ErrInvalidParameter, got %v", err)
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("expected a single error, got %d items", count)
+	}
+}
+
+func TestMessagesServiceCreateWithFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("failed to parse multipart body: %v", err)
+			return
+		}
+
+		if r.FormValue("roomId") != "test-room-id" || r.FormValue("parentId") != "parent-id" {
+			t.Errorf("unexpected fields: %v", r.MultipartForm.Value)
+		}
+
+		if _, ok := r.MultipartForm.Value["toPersonEmail"]; ok {
+			t.Error("empty fields must not be sent")
+		}
+
+		if files := r.MultipartForm.File["files"]; len(files) != 1 || files[0].Filename != "notes.txt" {
+			t.Errorf("unexpected files: %v", files)
+		}
+
+		json.NewEncoder(w).Encode(Message{ID: "test-message-id"})
+	}))
+	defer server.Close()
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	service := NewMessagesService(session)
+	message, err := service.CreateWithFile(context.Background(), &MessageCreateRequest{
+		RoomID:   "test-room-id",
+		ParentID: "parent-id",
+		Text:     "see attached",
+	}, []MessageFile{{Name: "notes.txt", ContentType: "text/plain", Reader: strings.NewReader("hello")}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if message.ID != "test-message-id" {
+		t.Errorf("expected test-message-id, got %s", message.ID)
+	}
+}
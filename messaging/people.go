@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -78,6 +79,34 @@ type PeopleListOptions struct {
 }
 
 func (s *PeopleService) List(ctx context.Context, opts *PeopleListOptions) ([]*Person, error) {
+	ctx = core.WithOperation(ctx, "messaging.People.List")
+
+	var response struct {
+		Items []*Person `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "people", opts.params(), &response); err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all people matching opts.
+func (s *PeopleService) ListPages(opts *PeopleListOptions) (*core.Pager[Person], error) {
+	return core.NewPager[Person](s.session, "messaging.People.ListPages", "people", opts.params()), nil
+}
+
+// ListAll iterates over all people matching opts, following pagination links.
+func (s *PeopleService) ListAll(ctx context.Context, opts *PeopleListOptions) iter.Seq2[*Person, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Person](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *PeopleListOptions) params() url.Values {
 	params := url.Values{}
 
 	if opts != nil {
@@ -104,19 +133,14 @@ func (s *PeopleService) List(ctx context.Context, opts *PeopleListOptions) ([]*P
 		}
 	}
 
-	var response struct {
-		Items []*Person `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "people", params, &response); err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+	return params
 }
 
 func (s *PeopleService) Get(ctx context.Context, personID string) (*Person, error) {
+	ctx = core.WithOperation(ctx, "messaging.People.Get")
+
 	if personID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("PeopleService.Get", "personID")
 	}
 
 	var person Person
@@ -127,6 +151,8 @@ func (s *PeopleService) Get(ctx context.Context, personID string) (*Person, erro
 }
 
 func (s *PeopleService) GetMe(ctx context.Context) (*Person, error) {
+	ctx = core.WithOperation(ctx, "messaging.People.GetMe")
+
 	var person Person
 	if err := s.session.Get(ctx, "people/me", nil, &person); err != nil {
 		return nil, err
@@ -154,8 +180,10 @@ type PersonCreateRequest struct {
 }
 
 func (s *PeopleService) Create(ctx context.Context, req *PersonCreateRequest) (*Person, error) {
+	ctx = core.WithOperation(ctx, "messaging.People.Create")
+
 	if req == nil || len(req.Emails) == 0 {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("PeopleService.Create", "req.Emails")
 	}
 	var person Person
 	if err := s.session.Post(ctx, "people", req, &person); err != nil {
@@ -165,28 +193,33 @@ func (s *PeopleService) Create(ctx context.Context, req *PersonCreateRequest) (*
 }
 
 type PersonUpdateRequest struct {
-	Emails       []string      `json:"emails,omitempty"`
-	DisplayName  string        `json:"displayName,omitempty"`
-	FirstName    string        `json:"firstName,omitempty"`
-	LastName     string        `json:"lastName,omitempty"`
-	Avatar       string        `json:"avatar,omitempty"`
-	OrgID        string        `json:"orgId,omitempty"`
-	Roles        []string      `json:"roles,omitempty"`
-	Licenses     []string      `json:"licenses,omitempty"`
-	Department   string        `json:"department,omitempty"`
-	Manager      string        `json:"manager,omitempty"`
-	ManagerID    string        `json:"managerId,omitempty"`
-	Title        string        `json:"title,omitempty"`
-	Addresses    []Address     `json:"addresses,omitempty"`
-	PhoneNumbers []PhoneNumber `json:"phoneNumbers,omitempty"`
-	SiteUrls     []string      `json:"siteUrls,omitempty"`
-	LocationID   string        `json:"locationId,omitempty"`
-	LoginEnabled *bool         `json:"loginEnabled,omitempty"`
+	Emails       []string              `json:"emails,omitempty"`
+	DisplayName  string                `json:"displayName,omitempty"`
+	FirstName    core.Optional[string] `json:"firstName,omitzero"`
+	LastName     core.Optional[string] `json:"lastName,omitzero"`
+	Avatar       core.Optional[string] `json:"avatar,omitzero"`
+	OrgID        string                `json:"orgId,omitempty"`
+	Roles        []string              `json:"roles,omitempty"`
+	Licenses     []string              `json:"licenses,omitempty"`
+	Department   core.Optional[string] `json:"department,omitzero"`
+	Manager      core.Optional[string] `json:"manager,omitzero"`
+	ManagerID    core.Optional[string] `json:"managerId,omitzero"`
+	Title        core.Optional[string] `json:"title,omitzero"`
+	Addresses    []Address             `json:"addresses,omitempty"`
+	PhoneNumbers []PhoneNumber         `json:"phoneNumbers,omitempty"`
+	SiteUrls     []string              `json:"siteUrls,omitempty"`
+	LocationID   core.Optional[string] `json:"locationId,omitzero"`
+	LoginEnabled core.Optional[bool]   `json:"loginEnabled,omitzero"`
 }
 
 func (s *PeopleService) Update(ctx context.Context, personID string, req *PersonUpdateRequest) (*Person, error) {
-	if personID == "" || req == nil {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.People.Update")
+
+	switch {
+	case personID == "":
+		return nil, core.InvalidParameter("PeopleService.Update", "personID")
+	case req == nil:
+		return nil, core.InvalidParameter("PeopleService.Update", "req")
 	}
 
 	var person Person
@@ -196,9 +229,37 @@ func (s *PeopleService) Update(ctx context.Context, personID string, req *Person
 	return &person, nil
 }
 
+// Modify fetches the person, lets modify change the update request built
+// from their current details, and saves the result. The Webex API replaces
+// a person's details on update, so fields modify leaves alone are resent
+// unchanged.
+func (s *PeopleService) Modify(ctx context.Context, personID string, modify func(req *PersonUpdateRequest)) (*Person, error) {
+	switch {
+	case personID == "":
+		return nil, core.InvalidParameter("PeopleService.Modify", "personID")
+	case modify == nil:
+		return nil, core.InvalidParameter("PeopleService.Modify", "modify")
+	}
+
+	person, err := s.Get(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	var req PersonUpdateRequest
+	if err := core.LoadUpdate(person, &req); err != nil {
+		return nil, err
+	}
+
+	modify(&req)
+	return s.Update(ctx, personID, &req)
+}
+
 func (s *PeopleService) Delete(ctx context.Context, personID string) error {
+	ctx = core.WithOperation(ctx, "messaging.People.Delete")
+
 	if personID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("PeopleService.Delete", "personID")
 	}
 	return s.session.Delete(ctx, "people/"+personID)
 }
@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -12,7 +13,7 @@ import (
 type Room struct {
 	ID                 string    `json:"id,omitempty"`
 	Title              string    `json:"title,omitempty"`
-	Type               string    `json:"type,omitempty"`
+	Type               RoomType  `json:"type,omitempty"`
 	IsLocked           bool      `json:"isLocked,omitempty"`
 	IsPublic           bool      `json:"isPublic,omitempty"`
 	IsAnnouncementOnly bool      `json:"isAnnouncementOnly,omitempty"`
@@ -27,6 +28,49 @@ type Room struct {
 	OwnerID            string    `json:"ownerId,omitempty"`
 }
 
+// RoomType is the kind of a room.
+type RoomType string
+
+const (
+	RoomTypeDirect RoomType = "direct"
+	RoomTypeGroup  RoomType = "group"
+)
+
+// IsValid reports whether t is a known room type.
+func (t RoomType) IsValid() bool {
+	switch t {
+	case RoomTypeDirect, RoomTypeGroup:
+		return true
+	}
+	return false
+}
+
+func (t RoomType) String() string {
+	return string(t)
+}
+
+// RoomSortBy orders the rooms returned by List.
+type RoomSortBy string
+
+const (
+	RoomSortByID           RoomSortBy = "id"
+	RoomSortByLastActivity RoomSortBy = "lastactivity"
+	RoomSortByCreated      RoomSortBy = "created"
+)
+
+// IsValid reports whether s is a known room sort order.
+func (s RoomSortBy) IsValid() bool {
+	switch s {
+	case RoomSortByID, RoomSortByLastActivity, RoomSortByCreated:
+		return true
+	}
+	return false
+}
+
+func (s RoomSortBy) String() string {
+	return string(s)
+}
+
 type RoomMeetingInfo struct {
 	RoomID               string `json:"roomId,omitempty"`
 	MeetingLink          string `json:"meetingLink,omitempty"`
@@ -48,12 +92,61 @@ func NewRoomsService(session *core.RestSession) *RoomsService {
 
 type RoomListOptions struct {
 	TeamID string
-	Type   string
-	SortBy string
+	Type   RoomType
+	SortBy RoomSortBy
 	Max    int
 }
 
 func (s *RoomsService) List(ctx context.Context, opts *RoomListOptions) ([]*Room, error) {
+	ctx = core.WithOperation(ctx, "messaging.Rooms.List")
+
+	if err := opts.validate("RoomsService.List"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Items []*Room `json:"items"`
+	}
+
+	if err := s.session.Get(ctx, "rooms", opts.params(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListPages returns a pager over all rooms visible to the caller.
+func (s *RoomsService) ListPages(opts *RoomListOptions) (*core.Pager[Room], error) {
+	if err := opts.validate("RoomsService.ListPages"); err != nil {
+		return nil, err
+	}
+
+	return core.NewPager[Room](s.session, "messaging.Rooms.ListPages", "rooms", opts.params()), nil
+}
+
+// ListAll iterates over all rooms, following pagination links.
+func (s *RoomsService) ListAll(ctx context.Context, opts *RoomListOptions) iter.Seq2[*Room, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Room](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *RoomListOptions) validate(method string) error {
+	switch {
+	case opts == nil:
+		return nil
+	case opts.Type != "" && !opts.Type.IsValid():
+		return core.InvalidParameter(method, "opts.Type")
+	case opts.SortBy != "" && !opts.SortBy.IsValid():
+		return core.InvalidParameter(method, "opts.SortBy")
+	}
+	return nil
+}
+
+func (opts *RoomListOptions) params() url.Values {
 	params := url.Values{}
 	if opts != nil {
 		if opts.TeamID != "" {
@@ -61,11 +154,11 @@ func (s *RoomsService) List(ctx context.Context, opts *RoomListOptions) ([]*Room
 		}
 
 		if opts.Type != "" {
-			params.Set("type", opts.Type)
+			params.Set("type", string(opts.Type))
 		}
 
 		if opts.SortBy != "" {
-			params.Set("sortBy", opts.SortBy)
+			params.Set("sortBy", string(opts.SortBy))
 		}
 
 		if opts.Max > 0 {
@@ -73,15 +166,7 @@ func (s *RoomsService) List(ctx context.Context, opts *RoomListOptions) ([]*Room
 		}
 	}
 
-	var response struct {
-		Items []*Room `json:"items"`
-	}
-
-	if err := s.session.Get(ctx, "rooms", params, &response); err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	return params
 }
 
 type RoomCreateRequest struct {
@@ -96,8 +181,10 @@ type RoomCreateRequest struct {
 
 // Create creates a new room.
 func (s *RoomsService) Create(ctx context.Context, req *RoomCreateRequest) (*Room, error) {
+	ctx = core.WithOperation(ctx, "messaging.Rooms.Create")
+
 	if req == nil || req.Title == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("RoomsService.Create", "req.Title")
 	}
 
 	var room Room
@@ -109,8 +196,10 @@ func (s *RoomsService) Create(ctx context.Context, req *RoomCreateRequest) (*Roo
 }
 
 func (s *RoomsService) Get(ctx context.Context, roomID string) (*Room, error) {
+	ctx = core.WithOperation(ctx, "messaging.Rooms.Get")
+
 	if roomID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("RoomsService.Get", "roomID")
 	}
 
 	var room Room
@@ -122,8 +211,10 @@ func (s *RoomsService) Get(ctx context.Context, roomID string) (*Room, error) {
 }
 
 func (s *RoomsService) GetMeetingInfo(ctx context.Context, roomID string) (*RoomMeetingInfo, error) {
+	ctx = core.WithOperation(ctx, "messaging.Rooms.GetMeetingInfo")
+
 	if roomID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("RoomsService.GetMeetingInfo", "roomID")
 	}
 
 	var meetingInfo RoomMeetingInfo
@@ -135,19 +226,24 @@ func (s *RoomsService) GetMeetingInfo(ctx context.Context, roomID string) (*Room
 }
 
 type RoomUpdateRequest struct {
-	Title              string `json:"title"`
-	TeamID             string `json:"teamId,omitempty"`
-	ClassificationID   string `json:"classificationId,omitempty"`
-	IsLocked           bool   `json:"isLocked,omitempty"`
-	IsPublic           bool   `json:"isPublic,omitempty"`
-	Description        string `json:"description,omitempty"`
-	IsAnnouncementOnly bool   `json:"isAnnouncementOnly,omitempty"`
-	IsReadOnly         bool   `json:"isReadOnly,omitempty"`
+	Title              string              `json:"title"`
+	TeamID             string              `json:"teamId,omitempty"`
+	ClassificationID   string              `json:"classificationId,omitempty"`
+	IsLocked           core.Optional[bool] `json:"isLocked,omitzero"`
+	IsPublic           core.Optional[bool] `json:"isPublic,omitzero"`
+	Description        string              `json:"description,omitempty"`
+	IsAnnouncementOnly core.Optional[bool] `json:"isAnnouncementOnly,omitzero"`
+	IsReadOnly         core.Optional[bool] `json:"isReadOnly,omitzero"`
 }
 
 func (s *RoomsService) Update(ctx context.Context, roomID string, req *RoomUpdateRequest) (*Room, error) {
-	if roomID == "" || req == nil || req.Title == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.Rooms.Update")
+
+	switch {
+	case roomID == "":
+		return nil, core.InvalidParameter("RoomsService.Update", "roomID")
+	case req == nil || req.Title == "":
+		return nil, core.InvalidParameter("RoomsService.Update", "req.Title")
 	}
 
 	var room Room
@@ -158,9 +254,36 @@ func (s *RoomsService) Update(ctx context.Context, roomID string, req *RoomUpdat
 	return &room, nil
 }
 
+// Modify fetches the room, lets modify change the update request built from
+// its current settings, and saves the result. Settings modify leaves alone
+// keep their current values.
+func (s *RoomsService) Modify(ctx context.Context, roomID string, modify func(req *RoomUpdateRequest)) (*Room, error) {
+	switch {
+	case roomID == "":
+		return nil, core.InvalidParameter("RoomsService.Modify", "roomID")
+	case modify == nil:
+		return nil, core.InvalidParameter("RoomsService.Modify", "modify")
+	}
+
+	room, err := s.Get(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	var req RoomUpdateRequest
+	if err := core.LoadUpdate(room, &req); err != nil {
+		return nil, err
+	}
+
+	modify(&req)
+	return s.Update(ctx, roomID, &req)
+}
+
 func (s *RoomsService) Delete(ctx context.Context, roomID string) error {
+	ctx = core.WithOperation(ctx, "messaging.Rooms.Delete")
+
 	if roomID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("RoomsService.Delete", "roomID")
 	}
 
 	return s.session.Delete(ctx, "rooms/"+roomID)
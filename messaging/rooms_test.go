@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+func TestRoomsServiceListValidatesEnums(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("type") != "group" || r.URL.Query().Get("sortBy") != "lastactivity" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": "room-1", "type": "group"}, {"id": "room-2", "type": "huddle"}]}`))
+	}))
+	defer server.Close()
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+	service := NewRoomsService(session)
+
+	_, err := service.List(context.Background(), &RoomListOptions{Type: "grop"})
+	var validationErr *core.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "opts.Type" || requests != 0 {
+		t.Fatalf("expected the typo to be rejected before sending, got %v", err)
+	}
+
+	rooms, err := service.List(context.Background(), &RoomListOptions{Type: RoomTypeGroup, SortBy: RoomSortByLastActivity})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rooms[0].Type != RoomTypeGroup || !rooms[0].Type.IsValid() {
+		t.Errorf("expected a group room, got %q", rooms[0].Type)
+	}
+
+	if rooms[1].Type != "huddle" || rooms[1].Type.IsValid() {
+		t.Errorf("expected an unknown type to decode as is, got %q", rooms[1].Type)
+	}
+}
@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -32,31 +33,54 @@ type TeamListOptions struct {
 }
 
 func (s *TeamsService) List(ctx context.Context, opts *TeamListOptions) ([]*Team, error) {
-	params := url.Values{}
-	if opts != nil {
-		if opts.Max > 0 {
-			params.Set("max", strconv.Itoa(opts.Max))
-		}
-	}
+	ctx = core.WithOperation(ctx, "messaging.Teams.List")
 
 	var response struct {
 		Items []*Team `json:"items"`
 	}
 
-	if err := s.session.Get(ctx, "teams", params, &response); err != nil {
+	if err := s.session.Get(ctx, "teams", opts.params(), &response); err != nil {
 		return nil, err
 	}
 	return response.Items, nil
 }
 
+// ListPages returns a pager over all teams the caller belongs to.
+func (s *TeamsService) ListPages(opts *TeamListOptions) (*core.Pager[Team], error) {
+	return core.NewPager[Team](s.session, "messaging.Teams.ListPages", "teams", opts.params()), nil
+}
+
+// ListAll iterates over all teams, following pagination links.
+func (s *TeamsService) ListAll(ctx context.Context, opts *TeamListOptions) iter.Seq2[*Team, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Team](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *TeamListOptions) params() url.Values {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Max > 0 {
+			params.Set("max", strconv.Itoa(opts.Max))
+		}
+	}
+
+	return params
+}
+
 type TeamCreateRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 }
 
 func (s *TeamsService) Create(ctx context.Context, req *TeamCreateRequest) (*Team, error) {
+	ctx = core.WithOperation(ctx, "messaging.Teams.Create")
+
 	if req == nil || req.Name == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("TeamsService.Create", "req.Name")
 	}
 
 	var team Team
@@ -67,8 +91,10 @@ func (s *TeamsService) Create(ctx context.Context, req *TeamCreateRequest) (*Tea
 }
 
 func (s *TeamsService) Get(ctx context.Context, teamID string) (*Team, error) {
+	ctx = core.WithOperation(ctx, "messaging.Teams.Get")
+
 	if teamID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("TeamsService.Get", "teamID")
 	}
 
 	var team Team
@@ -84,8 +110,13 @@ type TeamUpdateRequest struct {
 }
 
 func (s *TeamsService) Update(ctx context.Context, teamID string, req *TeamUpdateRequest) (*Team, error) {
-	if teamID == "" || req == nil || req.Name == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.Teams.Update")
+
+	switch {
+	case teamID == "":
+		return nil, core.InvalidParameter("TeamsService.Update", "teamID")
+	case req == nil || req.Name == "":
+		return nil, core.InvalidParameter("TeamsService.Update", "req.Name")
 	}
 
 	var team Team
@@ -96,8 +127,10 @@ func (s *TeamsService) Update(ctx context.Context, teamID string, req *TeamUpdat
 }
 
 func (s *TeamsService) Delete(ctx context.Context, teamID string) error {
+	ctx = core.WithOperation(ctx, "messaging.Teams.Delete")
+
 	if teamID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("TeamsService.Delete", "teamID")
 	}
 
 	return s.session.Delete(ctx, "teams/"+teamID)
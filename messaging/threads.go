@@ -0,0 +1,169 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// Thread is a top-level message and its replies in chronological order.
+type Thread struct {
+	RootID string
+
+	// Root is nil for orphaned threads whose root message was not among the
+	// grouped messages and has not been resolved.
+	Root    *Message
+	Replies []*Message
+}
+
+// Orphaned reports whether the thread's root message is missing.
+func (t *Thread) Orphaned() bool {
+	return t.Root == nil
+}
+
+// Messages returns the root, when known, followed by the replies.
+func (t *Thread) Messages() []*Message {
+	messages := make([]*Message, 0, len(t.Replies)+1)
+	if t.Root != nil {
+		messages = append(messages, t.Root)
+	}
+	return append(messages, t.Replies...)
+}
+
+// Started returns the creation time of the root, or of the first reply for
+// orphaned threads.
+func (t *Thread) Started() time.Time {
+	if t.Root != nil {
+		return t.Root.Created
+	}
+	if len(t.Replies) > 0 {
+		return t.Replies[0].Created
+	}
+	return time.Time{}
+}
+
+// Updated returns the creation time of the latest message in the thread.
+func (t *Thread) Updated() time.Time {
+	if len(t.Replies) > 0 {
+		return t.Replies[len(t.Replies)-1].Created
+	}
+	return t.Started()
+}
+
+// Conversation groups the messages of a room into threads.
+type Conversation struct {
+	// Threads are ordered by the time they started.
+	Threads []*Thread
+
+	byID map[string]*Thread
+}
+
+// NewConversation groups messages, in any order, into threads by ParentID.
+func NewConversation(messages []*Message) *Conversation {
+	sorted := make([]*Message, len(messages))
+	copy(sorted, messages)
+	sortMessages(sorted)
+
+	c := &Conversation{byID: make(map[string]*Thread)}
+	for _, message := range sorted {
+		if message.ParentID == "" {
+			c.thread(message.ID).Root = message
+			continue
+		}
+
+		thread := c.thread(message.ParentID)
+		thread.Replies = append(thread.Replies, message)
+	}
+
+	c.sortThreads()
+	return c
+}
+
+// thread returns the thread rooted at id, creating it when needed.
+func (c *Conversation) thread(id string) *Thread {
+	thread, ok := c.byID[id]
+	if !ok {
+		thread = &Thread{RootID: id}
+		c.byID[id] = thread
+		c.Threads = append(c.Threads, thread)
+	}
+	return thread
+}
+
+func (c *Conversation) sortThreads() {
+	sort.SliceStable(c.Threads, func(i, j int) bool {
+		return c.Threads[i].Started().Before(c.Threads[j].Started())
+	})
+}
+
+// Thread returns the thread rooted at the message with the given ID.
+func (c *Conversation) Thread(rootID string) (*Thread, bool) {
+	thread, ok := c.byID[rootID]
+	return thread, ok
+}
+
+// Orphans returns the threads whose root message is missing.
+func (c *Conversation) Orphans() []*Thread {
+	var orphans []*Thread
+	for _, thread := range c.Threads {
+		if thread.Orphaned() {
+			orphans = append(orphans, thread)
+		}
+	}
+	return orphans
+}
+
+// ResolveOrphans fetches the missing root messages with MessagesService.Get.
+// Roots that no longer exist are left nil.
+func (c *Conversation) ResolveOrphans(ctx context.Context, s *MessagesService) error {
+	for _, thread := range c.Orphans() {
+		root, err := s.Get(ctx, thread.RootID)
+		if err != nil {
+			if errors.Is(err, core.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		thread.Root = root
+	}
+
+	c.sortThreads()
+	return nil
+}
+
+// ListThread returns a thread's root message and all of its replies,
+// following pagination.
+func (s *MessagesService) ListThread(ctx context.Context, roomID, parentID string) (*Thread, error) {
+	switch {
+	case roomID == "":
+		return nil, core.InvalidParameter("MessagesService.ListThread", "roomID")
+	case parentID == "":
+		return nil, core.InvalidParameter("MessagesService.ListThread", "parentID")
+	}
+
+	root, err := s.Get(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &Thread{RootID: parentID, Root: root}
+	for reply, err := range s.ListAll(ctx, &MessageListOptions{RoomID: roomID, ParentID: parentID}) {
+		if err != nil {
+			return nil, err
+		}
+		thread.Replies = append(thread.Replies, reply)
+	}
+
+	sortMessages(thread.Replies)
+	return thread, nil
+}
+
+// sortMessages orders messages chronologically. The API lists newest first.
+func sortMessages(messages []*Message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Created.Before(messages[j].Created)
+	})
+}
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+func TestConversationGroupsThreadsAndResolvesOrphans(t *testing.T) {
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/messages/old-root":
+			json.NewEncoder(w).Encode(Message{ID: "old-root", Text: "older", Created: start.Add(-time.Hour)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Newest first, as returned by the API.
+	messages := []*Message{
+		{ID: "r3", ParentID: "deleted", Created: start.Add(5 * time.Minute)},
+		{ID: "r2", ParentID: "m1", Created: start.Add(4 * time.Minute)},
+		{ID: "r1", ParentID: "old-root", Created: start.Add(3 * time.Minute)},
+		{ID: "m2", Created: start.Add(2 * time.Minute)},
+		{ID: "r0", ParentID: "m1", Created: start.Add(1 * time.Minute)},
+		{ID: "m1", Created: start},
+	}
+
+	conv := NewConversation(messages)
+	if len(conv.Threads) != 4 || len(conv.Orphans()) != 2 {
+		t.Fatalf("expected 4 threads with 2 orphans, got %d and %d", len(conv.Threads), len(conv.Orphans()))
+	}
+
+	thread, ok := conv.Thread("m1")
+	if !ok || thread.Root.ID != "m1" || len(thread.Replies) != 2 || thread.Replies[0].ID != "r0" {
+		t.Errorf("unexpected m1 thread: %+v", thread)
+	}
+
+	service := NewMessagesService(core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	}))
+	if err := conv.ResolveOrphans(context.Background(), service); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var order []string
+	for _, thread := range conv.Threads {
+		order = append(order, thread.RootID)
+	}
+
+	want := []string{"old-root", "m1", "m2", "deleted"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected thread order %v, got %v", want, order)
+		}
+	}
+
+	if orphans := conv.Orphans(); len(orphans) != 1 || orphans[0].RootID != "deleted" {
+		t.Errorf("expected only the deleted root to stay orphaned, got %v", orphans)
+	}
+}
+
+func TestMessagesServiceListThread(t *testing.T) {
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/messages/root":
+			json.NewEncoder(w).Encode(Message{ID: "root", Created: start})
+		case r.URL.Path == "/messages" && r.URL.Query().Get("parentId") != "root":
+			t.Errorf("expected parentId=root, got %s", r.URL.RawQuery)
+		case r.URL.Path == "/messages" && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", "<"+server.URL+"/messages?roomId=room-1&parentId=root&page=2>; rel=\"next\"")
+			json.NewEncoder(w).Encode(map[string]any{"items": []Message{{ID: "r3", Created: start.Add(3 * time.Minute)}, {ID: "r2", Created: start.Add(2 * time.Minute)}}})
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"items": []Message{{ID: "r1", Created: start.Add(time.Minute)}}})
+		}
+	}))
+	defer server.Close()
+
+	service := NewMessagesService(core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	}))
+
+	thread, err := service.ListThread(context.Background(), "room-1", "root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if thread.Root.ID != "root" || len(thread.Replies) != 3 || thread.Replies[0].ID != "r1" || thread.Replies[2].ID != "r3" {
+		t.Errorf("unexpected thread: root=%v replies=%d", thread.Root, len(thread.Replies))
+	}
+}
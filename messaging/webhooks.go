@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -10,19 +11,110 @@ import (
 )
 
 type Webhook struct {
-	ID        string    `json:"id,omitempty"`
-	Name      string    `json:"name,omitempty"`
-	TargetURL string    `json:"targetUrl,omitempty"`
-	Resource  string    `json:"resource,omitempty"`
-	Event     string    `json:"event,omitempty"`
-	OrgID     string    `json:"orgId,omitempty"`
-	CreatedBy string    `json:"createdBy,omitempty"`
-	AppID     string    `json:"appId,omitempty"`
-	OwnedBy   string    `json:"ownedBy,omitempty"`
-	Filter    string    `json:"filter,omitempty"`
-	Secret    string    `json:"secret,omitempty"`
-	Status    string    `json:"status,omitempty"`
-	Created   time.Time `json:"created,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	TargetURL string          `json:"targetUrl,omitempty"`
+	Resource  WebhookResource `json:"resource,omitempty"`
+	Event     WebhookEvent    `json:"event,omitempty"`
+	OrgID     string          `json:"orgId,omitempty"`
+	CreatedBy string          `json:"createdBy,omitempty"`
+	AppID     string          `json:"appId,omitempty"`
+	OwnedBy   string          `json:"ownedBy,omitempty"`
+	Filter    string          `json:"filter,omitempty"`
+	Secret    string          `json:"secret,omitempty"`
+	Status    WebhookStatus   `json:"status,omitempty"`
+	Created   time.Time       `json:"created,omitempty"`
+}
+
+// WebhookResource is the resource a webhook watches.
+type WebhookResource string
+
+const (
+	WebhookResourceAll                 WebhookResource = "all"
+	WebhookResourceMessages            WebhookResource = "messages"
+	WebhookResourceMemberships         WebhookResource = "memberships"
+	WebhookResourceRooms               WebhookResource = "rooms"
+	WebhookResourceMeetings            WebhookResource = "meetings"
+	WebhookResourceRecordings          WebhookResource = "recordings"
+	WebhookResourceMeetingParticipants WebhookResource = "meetingParticipants"
+	WebhookResourceMeetingTranscripts  WebhookResource = "meetingTranscripts"
+	WebhookResourceAttachmentActions   WebhookResource = "attachmentActions"
+	WebhookResourceTelephonyCalls      WebhookResource = "telephony_calls"
+	WebhookResourceConvergedRecordings WebhookResource = "convergedRecordings"
+	WebhookResourceServiceApp          WebhookResource = "serviceApp"
+	WebhookResourceAdminBatchJobs      WebhookResource = "adminBatchJobs"
+	WebhookResourceDataSources         WebhookResource = "dataSources"
+	WebhookResourceTelephonyConference WebhookResource = "telephony_conference"
+	WebhookResourceTelephonyMWI        WebhookResource = "telephony_mwi"
+	WebhookResourceUCCounters          WebhookResource = "uc_counters"
+	WebhookResourceBusinessTexts       WebhookResource = "businessTexts"
+)
+
+// IsValid reports whether r is a known webhook resource.
+func (r WebhookResource) IsValid() bool {
+	switch r {
+	case WebhookResourceAll, WebhookResourceMessages, WebhookResourceMemberships, WebhookResourceRooms, WebhookResourceMeetings, WebhookResourceRecordings, WebhookResourceMeetingParticipants, WebhookResourceMeetingTranscripts, WebhookResourceAttachmentActions, WebhookResourceTelephonyCalls, WebhookResourceConvergedRecordings, WebhookResourceServiceApp, WebhookResourceAdminBatchJobs, WebhookResourceDataSources, WebhookResourceTelephonyConference, WebhookResourceTelephonyMWI, WebhookResourceUCCounters, WebhookResourceBusinessTexts:
+		return true
+	}
+	return false
+}
+
+func (r WebhookResource) String() string {
+	return string(r)
+}
+
+// WebhookEvent is the event on a resource that triggers a webhook.
+type WebhookEvent string
+
+const (
+	WebhookEventAll     WebhookEvent = "all"
+	WebhookEventCreated WebhookEvent = "created"
+	WebhookEventUpdated WebhookEvent = "updated"
+	WebhookEventDeleted WebhookEvent = "deleted"
+	WebhookEventStarted WebhookEvent = "started"
+	WebhookEventEnded   WebhookEvent = "ended"
+	WebhookEventJoined  WebhookEvent = "joined"
+	WebhookEventLeft    WebhookEvent = "left"
+
+	WebhookEventMigrated      WebhookEvent = "migrated"
+	WebhookEventAuthorized    WebhookEvent = "authorized"
+	WebhookEventDeauthorized  WebhookEvent = "deauthorized"
+	WebhookEventStatusChanged WebhookEvent = "statusChanged"
+)
+
+// IsValid reports whether e is a known webhook event.
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookEventAll, WebhookEventCreated, WebhookEventUpdated, WebhookEventDeleted, WebhookEventStarted, WebhookEventEnded, WebhookEventJoined, WebhookEventLeft, WebhookEventMigrated, WebhookEventAuthorized, WebhookEventDeauthorized, WebhookEventStatusChanged:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEvent) String() string {
+	return string(e)
+}
+
+// WebhookStatus reports whether Webex delivers a webhook's notifications.
+type WebhookStatus string
+
+const (
+	WebhookStatusActive   WebhookStatus = "active"
+	WebhookStatusInactive WebhookStatus = "inactive"
+	WebhookStatusDisabled WebhookStatus = "disabled"
+)
+
+// IsValid reports whether s is a known webhook status.
+func (s WebhookStatus) IsValid() bool {
+	switch s {
+	case WebhookStatusActive, WebhookStatusInactive, WebhookStatusDisabled:
+		return true
+	}
+	return false
+}
+
+func (s WebhookStatus) String() string {
+	return string(s)
 }
 
 type WebhooksService struct {
@@ -40,37 +132,67 @@ type WebhookListOptions struct {
 }
 
 func (s *WebhooksService) List(ctx context.Context, opts *WebhookListOptions) ([]*Webhook, error) {
-	params := url.Values{}
-	if opts != nil {
-		if opts.Max > 0 {
-			params.Set("max", strconv.Itoa(opts.Max))
-		}
-	}
+	ctx = core.WithOperation(ctx, "messaging.Webhooks.List")
 
 	var response struct {
 		Items []*Webhook `json:"items"`
 	}
 
-	if err := s.session.Get(ctx, "webhooks", params, &response); err != nil {
+	if err := s.session.Get(ctx, "webhooks", opts.params(), &response); err != nil {
 		return nil, err
 	}
 
 	return response.Items, nil
 }
 
+// ListPages returns a pager over all webhooks owned by the caller.
+func (s *WebhooksService) ListPages(opts *WebhookListOptions) (*core.Pager[Webhook], error) {
+	return core.NewPager[Webhook](s.session, "messaging.Webhooks.ListPages", "webhooks", opts.params()), nil
+}
+
+// ListAll iterates over all webhooks, following pagination links.
+func (s *WebhooksService) ListAll(ctx context.Context, opts *WebhookListOptions) iter.Seq2[*Webhook, error] {
+	pager, err := s.ListPages(opts)
+	if err != nil {
+		return core.ErrorSeq[Webhook](err)
+	}
+
+	return pager.All(ctx)
+}
+
+func (opts *WebhookListOptions) params() url.Values {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Max > 0 {
+			params.Set("max", strconv.Itoa(opts.Max))
+		}
+	}
+
+	return params
+}
+
 type WebhookCreateRequest struct {
-	Name      string `json:"name"`
-	TargetURL string `json:"targetUrl"`
-	Resource  string `json:"resource"`
-	Event     string `json:"event"`
-	Filter    string `json:"filter,omitempty"`
-	Secret    string `json:"secret,omitempty"`
-	OwnedBy   string `json:"ownedBy,omitempty"`
+	Name      string          `json:"name"`
+	TargetURL string          `json:"targetUrl"`
+	Resource  WebhookResource `json:"resource"`
+	Event     WebhookEvent    `json:"event"`
+	Filter    string          `json:"filter,omitempty"`
+	Secret    string          `json:"secret,omitempty"`
+	OwnedBy   string          `json:"ownedBy,omitempty"`
 }
 
 func (s *WebhooksService) Create(ctx context.Context, req *WebhookCreateRequest) (*Webhook, error) {
-	if req == nil || req.Name == "" || req.TargetURL == "" || req.Resource == "" || req.Event == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.Webhooks.Create")
+
+	switch {
+	case req == nil || req.Name == "":
+		return nil, core.InvalidParameter("WebhooksService.Create", "req.Name")
+	case req.TargetURL == "":
+		return nil, core.InvalidParameter("WebhooksService.Create", "req.TargetURL")
+	case req.Resource == "":
+		return nil, core.InvalidParameter("WebhooksService.Create", "req.Resource")
+	case req.Event == "":
+		return nil, core.InvalidParameter("WebhooksService.Create", "req.Event")
 	}
 
 	var webhook Webhook
@@ -82,12 +204,14 @@ func (s *WebhooksService) Create(ctx context.Context, req *WebhookCreateRequest)
 }
 
 func (s *WebhooksService) Get(ctx context.Context, webhookID string) (*Webhook, error) {
+	ctx = core.WithOperation(ctx, "messaging.Webhooks.Get")
+
 	if webhookID == "" {
-		return nil, core.ErrInvalidParameter
+		return nil, core.InvalidParameter("WebhooksService.Get", "webhookID")
 	}
 
 	var webhook Webhook
-	if err := s.session.Get(ctx, "webhook/"+webhookID, nil, &webhook); err != nil {
+	if err := s.session.Get(ctx, "webhooks/"+webhookID, nil, &webhook); err != nil {
 		return nil, err
 	}
 
@@ -95,16 +219,25 @@ func (s *WebhooksService) Get(ctx context.Context, webhookID string) (*Webhook,
 }
 
 type WebhookUpdateRequest struct {
-	Name      string `json:"name"`
-	TargetURL string `json:"targetUrl"`
-	Secret    string `json:"secret,omitempty"`
-	OwnedBy   string `json:"ownedBy,omitempty"`
-	Status    string `json:"status,omitempty"`
+	Name      string        `json:"name"`
+	TargetURL string        `json:"targetUrl"`
+	Secret    string        `json:"secret,omitempty"`
+	OwnedBy   string        `json:"ownedBy,omitempty"`
+	Status    WebhookStatus `json:"status,omitempty"`
 }
 
 func (s *WebhooksService) Update(ctx context.Context, webhookID string, req *WebhookUpdateRequest) (*Webhook, error) {
-	if webhookID == "" || req == nil || req.Name == "" || req.TargetURL == "" {
-		return nil, core.ErrInvalidParameter
+	ctx = core.WithOperation(ctx, "messaging.Webhooks.Update")
+
+	switch {
+	case webhookID == "":
+		return nil, core.InvalidParameter("WebhooksService.Update", "webhookID")
+	case req == nil || req.Name == "":
+		return nil, core.InvalidParameter("WebhooksService.Update", "req.Name")
+	case req.TargetURL == "":
+		return nil, core.InvalidParameter("WebhooksService.Update", "req.TargetURL")
+	case req.Status != "" && !req.Status.IsValid():
+		return nil, core.InvalidParameter("WebhooksService.Update", "req.Status")
 	}
 
 	var webhook Webhook
@@ -116,8 +249,10 @@ func (s *WebhooksService) Update(ctx context.Context, webhookID string, req *Web
 }
 
 func (s *WebhooksService) Delete(ctx context.Context, webhookID string) error {
+	ctx = core.WithOperation(ctx, "messaging.Webhooks.Delete")
+
 	if webhookID == "" {
-		return core.ErrInvalidParameter
+		return core.InvalidParameter("WebhooksService.Delete", "webhookID")
 	}
 
 	return s.session.Delete(ctx, "webhooks/"+webhookID)
@@ -0,0 +1,221 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// WebhookAction describes the change a reconciliation makes to a webhook.
+type WebhookAction string
+
+const (
+	WebhookActionCreate WebhookAction = "create"
+	WebhookActionUpdate WebhookAction = "update"
+	WebhookActionEnable WebhookAction = "enable"
+	WebhookActionDelete WebhookAction = "delete"
+)
+
+// WebhookReconcileOptions controls how Reconcile applies the desired state.
+type WebhookReconcileOptions struct {
+	// DryRun computes the plan without creating, updating or deleting anything.
+	DryRun bool
+
+	// DeleteUnmanaged deletes existing webhooks that match no desired entry.
+	DeleteUnmanaged bool
+
+	// Managed reports whether an existing webhook belongs to this
+	// reconciliation. Only webhooks it accepts are considered for matching
+	// and deletion. When nil every webhook owned by the caller is managed.
+	Managed func(*Webhook) bool
+}
+
+// WebhookChange is a single step of a reconciliation plan.
+type WebhookChange struct {
+	Action WebhookAction
+
+	// Desired is the requested webhook; nil for deletions.
+	Desired *WebhookCreateRequest
+
+	// Existing is the webhook currently registered; nil for creations.
+	Existing *Webhook
+
+	// Result is the webhook returned by the API once the change is applied.
+	Result *Webhook
+}
+
+func (c *WebhookChange) String() string {
+	switch c.Action {
+	case WebhookActionCreate:
+		return fmt.Sprintf("create %q (%s/%s) -> %s", c.Desired.Name, c.Desired.Resource, c.Desired.Event, c.Desired.TargetURL)
+	case WebhookActionDelete:
+		return fmt.Sprintf("delete %q (%s/%s) id=%s", c.Existing.Name, c.Existing.Resource, c.Existing.Event, c.Existing.ID)
+	default:
+		return fmt.Sprintf("%s %q (%s/%s) id=%s -> %s", c.Action, c.Desired.Name, c.Desired.Resource, c.Desired.Event, c.Existing.ID, c.Desired.TargetURL)
+	}
+}
+
+// WebhookPlan is the outcome of a reconciliation.
+type WebhookPlan struct {
+	Changes []*WebhookChange
+
+	// Unchanged lists existing webhooks that already match the desired state.
+	Unchanged []*Webhook
+
+	// Applied is false for dry runs.
+	Applied bool
+}
+
+// Empty reports whether the plan contains no changes.
+func (p *WebhookPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *WebhookPlan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+
+	var b strings.Builder
+	for i, change := range p.Changes {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(change.String())
+	}
+
+	return b.String()
+}
+
+type webhookKey struct {
+	name     string
+	resource WebhookResource
+	event    WebhookEvent
+	filter   string
+}
+
+// Reconcile makes the caller's webhooks match desired. Webhooks are matched
+// by name, resource, event and filter. Missing webhooks are created, matches
+// with a different target URL or secret are updated, and disabled matches are
+// re-enabled. Additional webhooks sharing a desired key are duplicates and are
+// always deleted; other unmatched webhooks are deleted only when
+// DeleteUnmanaged is set.
+//
+// The returned plan is populated even when applying fails part-way, with
+// Result set on the changes that succeeded.
+func (s *WebhooksService) Reconcile(ctx context.Context, desired []WebhookCreateRequest, opts *WebhookReconcileOptions) (*WebhookPlan, error) {
+	if opts == nil {
+		opts = &WebhookReconcileOptions{}
+	}
+
+	wanted := make(map[webhookKey]*WebhookCreateRequest, len(desired))
+	for i := range desired {
+		req := &desired[i]
+		switch {
+		case req.Name == "":
+			return nil, core.InvalidParameter("WebhooksService.Reconcile", "desired.Name")
+		case req.TargetURL == "":
+			return nil, core.InvalidParameter("WebhooksService.Reconcile", "desired.TargetURL")
+		case req.Resource == "":
+			return nil, core.InvalidParameter("WebhooksService.Reconcile", "desired.Resource")
+		case req.Event == "":
+			return nil, core.InvalidParameter("WebhooksService.Reconcile", "desired.Event")
+		}
+
+		key := webhookKey{name: req.Name, resource: req.Resource, event: req.Event, filter: req.Filter}
+		if _, ok := wanted[key]; ok {
+			return nil, fmt.Errorf("%w: duplicate webhook %q", core.InvalidParameter("WebhooksService.Reconcile", "desired"), req.Name)
+		}
+		wanted[key] = req
+	}
+
+	matched := make(map[webhookKey]bool, len(desired))
+	plan := &WebhookPlan{}
+	for existing, err := range s.ListAll(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.Managed != nil && !opts.Managed(existing) {
+			continue
+		}
+
+		key := webhookKey{name: existing.Name, resource: existing.Resource, event: existing.Event, filter: existing.Filter}
+		req, ok := wanted[key]
+		if !ok {
+			if opts.DeleteUnmanaged {
+				plan.Changes = append(plan.Changes, &WebhookChange{Action: WebhookActionDelete, Existing: existing})
+			}
+			continue
+		}
+
+		if matched[key] {
+			plan.Changes = append(plan.Changes, &WebhookChange{Action: WebhookActionDelete, Existing: existing})
+			continue
+		}
+		matched[key] = true
+
+		if action, ok := webhookDiff(existing, req); ok {
+			plan.Changes = append(plan.Changes, &WebhookChange{Action: action, Desired: req, Existing: existing})
+		} else {
+			plan.Unchanged = append(plan.Unchanged, existing)
+		}
+	}
+
+	for i := range desired {
+		req := &desired[i]
+		key := webhookKey{name: req.Name, resource: req.Resource, event: req.Event, filter: req.Filter}
+		if !matched[key] {
+			plan.Changes = append(plan.Changes, &WebhookChange{Action: WebhookActionCreate, Desired: req})
+		}
+	}
+
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	for _, change := range plan.Changes {
+		if err := s.apply(ctx, change); err != nil {
+			return plan, fmt.Errorf("%s: %w", change, err)
+		}
+	}
+	plan.Applied = true
+
+	return plan, nil
+}
+
+// webhookDiff returns the action needed to bring existing in line with req.
+// Secrets are only compared when req sets one.
+func webhookDiff(existing *Webhook, req *WebhookCreateRequest) (WebhookAction, bool) {
+	if existing.TargetURL != req.TargetURL || (req.Secret != "" && existing.Secret != req.Secret) {
+		return WebhookActionUpdate, true
+	}
+
+	if existing.Status == WebhookStatusDisabled {
+		return WebhookActionEnable, true
+	}
+
+	return "", false
+}
+
+func (s *WebhooksService) apply(ctx context.Context, change *WebhookChange) error {
+	var err error
+	switch change.Action {
+	case WebhookActionCreate:
+		change.Result, err = s.Create(ctx, change.Desired)
+	case WebhookActionUpdate, WebhookActionEnable:
+		change.Result, err = s.Update(ctx, change.Existing.ID, &WebhookUpdateRequest{
+			Name:      change.Desired.Name,
+			TargetURL: change.Desired.TargetURL,
+			Secret:    change.Desired.Secret,
+			OwnedBy:   change.Desired.OwnedBy,
+			Status:    WebhookStatusActive,
+		})
+	case WebhookActionDelete:
+		err = s.Delete(ctx, change.Existing.ID)
+	}
+
+	return err
+}
@@ -0,0 +1,155 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+type fakeWebhookAPI struct {
+	mu       sync.Mutex
+	existing []*Webhook
+	calls    []string
+}
+
+func (f *fakeWebhookAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]any{"items": f.existing})
+	case http.MethodPost, http.MethodPut:
+		var webhook Webhook
+		json.NewDecoder(r.Body).Decode(&webhook)
+		webhook.ID = "new"
+		json.NewEncoder(w).Encode(&webhook)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newReconcileTestService(t *testing.T, existing []*Webhook) (*WebhooksService, *fakeWebhookAPI) {
+	api := &fakeWebhookAPI{existing: existing}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	session := core.NewRestSession(&core.RestSessionConfig{
+		AccessToken: "test-token",
+		BaseURL:     server.URL + "/",
+	})
+
+	return NewWebhooksService(session), api
+}
+
+func TestWebhooksServiceReconcile(t *testing.T) {
+	service, api := newReconcileTestService(t, []*Webhook{
+		{ID: "w1", Name: "msgs", Resource: "messages", Event: "created", TargetURL: "https://old.example.com", Status: "active"},
+		{ID: "w2", Name: "msgs", Resource: "messages", Event: "created", TargetURL: "https://old.example.com", Status: "active"},
+		{ID: "w3", Name: "rooms", Resource: "rooms", Event: "all", TargetURL: "https://example.com/rooms", Status: "disabled"},
+		{ID: "w4", Name: "members", Resource: "memberships", Event: "all", TargetURL: "https://example.com/members", Status: "active"},
+		{ID: "w5", Name: "stale", Resource: "messages", Event: "deleted", TargetURL: "https://example.com/stale", Status: "active"},
+	})
+
+	desired := []WebhookCreateRequest{
+		{Name: "msgs", Resource: "messages", Event: "created", TargetURL: "https://new.example.com"},
+		{Name: "rooms", Resource: "rooms", Event: "all", TargetURL: "https://example.com/rooms"},
+		{Name: "members", Resource: "memberships", Event: "all", TargetURL: "https://example.com/members"},
+		{Name: "meetings", Resource: "meetings", Event: "started", TargetURL: "https://example.com/meetings"},
+	}
+
+	plan, err := service.Reconcile(context.Background(), desired, &WebhookReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"update w1", "delete w2", "enable w3", "create "}
+	if len(plan.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d:\n%s", len(want), len(plan.Changes), plan)
+	}
+	for i, change := range plan.Changes {
+		got := string(change.Action) + " "
+		if change.Existing != nil {
+			got += change.Existing.ID
+		}
+		if got != want[i] {
+			t.Errorf("change %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+
+	if len(plan.Unchanged) != 1 || plan.Unchanged[0].ID != "w4" {
+		t.Errorf("expected w4 unchanged, got %v", plan.Unchanged)
+	}
+
+	if plan.Applied || len(api.calls) != 1 {
+		t.Errorf("dry run should only list webhooks, got calls %v", api.calls)
+	}
+
+	plan, err = service.Reconcile(context.Background(), desired, &WebhookReconcileOptions{DeleteUnmanaged: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !plan.Applied || len(plan.Changes) != 5 {
+		t.Fatalf("expected 5 applied changes, got:\n%s", plan)
+	}
+
+	wantCalls := []string{"PUT /webhooks/w1", "DELETE /webhooks/w2", "PUT /webhooks/w3", "DELETE /webhooks/w5", "POST /webhooks"}
+	gotCalls := api.calls[2:]
+	if len(gotCalls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, gotCalls)
+	}
+	for i := range wantCalls {
+		if gotCalls[i] != wantCalls[i] {
+			t.Errorf("call %d: expected %s, got %s", i, wantCalls[i], gotCalls[i])
+		}
+	}
+
+	if plan.Changes[4].Result == nil || plan.Changes[4].Result.Name != "meetings" {
+		t.Errorf("expected created webhook result, got %+v", plan.Changes[4].Result)
+	}
+}
+
+func TestWebhooksServiceReconcileDuplicateDesired(t *testing.T) {
+	service, _ := newReconcileTestService(t, nil)
+
+	desired := []WebhookCreateRequest{
+		{Name: "msgs", Resource: "messages", Event: "created", TargetURL: "https://a.example.com"},
+		{Name: "msgs", Resource: "messages", Event: "created", TargetURL: "https://b.example.com"},
+	}
+
+	if _, err := service.Reconcile(context.Background(), desired, nil); err == nil {
+		t.Error("expected error for duplicate desired webhooks")
+	}
+}
+
+func TestWebhooksServiceCreateAcceptsUnlistedValues(t *testing.T) {
+	service, api := newReconcileTestService(t, nil)
+
+	webhook, err := service.Create(context.Background(), &WebhookCreateRequest{
+		Name:      "future",
+		TargetURL: "https://example.com/hook",
+		Resource:  "futureResource",
+		Event:     "futureEvent",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if webhook.Resource != "futureResource" || len(api.calls) != 1 {
+		t.Errorf("unexpected webhook %+v after calls %v", webhook, api.calls)
+	}
+
+	_, err = service.Create(context.Background(), &WebhookCreateRequest{Name: "empty", TargetURL: "https://example.com/hook", Event: WebhookEventAll})
+	if !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("expected ErrInvalidParameter for an empty resource, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package webexgosdk
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/internal/core"
+)
+
+// Option configures a Client. ClientOptions is itself an Option, so
+// NewClient(token, ClientOptions{...}) and NewClient(token, WithBaseURL(...))
+// can be mixed; later options override earlier ones.
+type Option interface {
+	apply(*ClientOptions)
+}
+
+// apply copies the fields that are set in o onto dst. Middlewares are
+// appended.
+func (o ClientOptions) apply(dst *ClientOptions) {
+	if o.BaseURL != "" {
+		dst.BaseURL = o.BaseURL
+	}
+	if o.RequestTimeout > 0 {
+		dst.RequestTimeout = o.RequestTimeout
+	}
+	if o.UserAgent != "" {
+		dst.UserAgent = o.UserAgent
+	}
+	if o.Retry != nil {
+		dst.Retry = o.Retry
+	}
+	if o.TokenSource != nil {
+		dst.TokenSource = o.TokenSource
+	}
+	dst.Middlewares = append(dst.Middlewares, o.Middlewares...)
+	if o.Tracer != nil {
+		dst.Tracer = o.Tracer
+	}
+	if o.Metrics != nil {
+		dst.Metrics = o.Metrics
+	}
+	if o.HTTPClient != nil {
+		dst.HTTPClient = o.HTTPClient
+	}
+	if o.Transport != nil {
+		dst.Transport = o.Transport
+	}
+	if o.ProxyURL != "" {
+		dst.ProxyURL = o.ProxyURL
+	}
+	if o.TLSConfig != nil {
+		dst.TLSConfig = o.TLSConfig
+	}
+	if o.MaxIdleConns > 0 {
+		dst.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		dst.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+}
+
+// WithClientOptions applies each of opts in turn. It adapts callers of the
+// earlier NewClient(token, opts...) form, where opts is a []ClientOptions:
+//
+//	client, err := webexgosdk.NewClient(token, webexgosdk.WithClientOptions(opts...))
+func WithClientOptions(opts ...ClientOptions) Option {
+	return optionFunc(func(dst *ClientOptions) {
+		for _, o := range opts {
+			o.apply(dst)
+		}
+	})
+}
+
+type optionFunc func(*ClientOptions)
+
+func (f optionFunc) apply(o *ClientOptions) {
+	f(o)
+}
+
+// WithBaseURL sets the API base URL, which must end in a slash.
+func WithBaseURL(baseURL string) Option {
+	return optionFunc(func(o *ClientOptions) { o.BaseURL = baseURL })
+}
+
+// WithRequestTimeout limits each attempt of a request. ContextWithTimeout
+// overrides it for a single call.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return optionFunc(func(o *ClientOptions) { o.RequestTimeout = timeout })
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return optionFunc(func(o *ClientOptions) { o.UserAgent = userAgent })
+}
+
+// WithRetry enables automatic retries.
+func WithRetry(policy *RetryPolicy) Option {
+	return optionFunc(func(o *ClientOptions) { o.Retry = policy })
+}
+
+// WithTokenSource supplies access tokens, for example a RefreshTokenSource.
+func WithTokenSource(source TokenSource) Option {
+	return optionFunc(func(o *ClientOptions) { o.TokenSource = source })
+}
+
+// WithMiddleware appends middlewares to the client's chain.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return optionFunc(func(o *ClientOptions) { o.Middlewares = append(o.Middlewares, middlewares...) })
+}
+
+// WithTracer instruments every API call with tracer.
+func WithTracer(tracer Tracer) Option {
+	return optionFunc(func(o *ClientOptions) { o.Tracer = tracer })
+}
+
+// WithMetrics records every API call with metrics.
+func WithMetrics(metrics Metrics) Option {
+	return optionFunc(func(o *ClientOptions) { o.Metrics = metrics })
+}
+
+// WithHTTPClient sends requests through client. It cannot be combined with
+// the transport options.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(o *ClientOptions) { o.HTTPClient = client })
+}
+
+// WithTransport sends requests through transport.
+func WithTransport(transport http.RoundTripper) Option {
+	return optionFunc(func(o *ClientOptions) { o.Transport = transport })
+}
+
+// WithProxyURL sends requests through the proxy at proxyURL.
+func WithProxyURL(proxyURL string) Option {
+	return optionFunc(func(o *ClientOptions) { o.ProxyURL = proxyURL })
+}
+
+// WithTLSConfig sets the TLS configuration, for example a custom CA pool or
+// client certificates.
+func WithTLSConfig(config *tls.Config) Option {
+	return optionFunc(func(o *ClientOptions) { o.TLSConfig = config })
+}
+
+// WithMaxIdleConns limits the idle connections kept open in total and per
+// host. Zero keeps the transport's default.
+func WithMaxIdleConns(total, perHost int) Option {
+	return optionFunc(func(o *ClientOptions) {
+		o.MaxIdleConns = total
+		o.MaxIdleConnsPerHost = perHost
+	})
+}
+
+// ContextWithTimeout overrides the client's RequestTimeout for calls made
+// with the returned context. A zero timeout disables the limit.
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return core.WithTimeout(ctx, timeout)
+}
+
+// httpClient builds the HTTP client described by the options, or returns nil
+// to let the session create its default client.
+func (o *ClientOptions) httpClient() (*http.Client, error) {
+	customTransport := o.ProxyURL != "" || o.TLSConfig != nil || o.MaxIdleConns > 0 || o.MaxIdleConnsPerHost > 0
+
+	if o.HTTPClient != nil {
+		if o.Transport != nil || customTransport {
+			return nil, InvalidParameter("NewClient", "HTTPClient")
+		}
+		return o.HTTPClient, nil
+	}
+
+	if !customTransport {
+		if o.Transport == nil {
+			return nil, nil
+		}
+		return &http.Client{Transport: o.Transport}, nil
+	}
+
+	var transport *http.Transport
+	switch base := o.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = base.Clone()
+	default:
+		// Proxy and TLS settings need an *http.Transport to apply to.
+		return nil, InvalidParameter("NewClient", "Transport")
+	}
+
+	if o.ProxyURL != "" {
+		proxy, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if o.TLSConfig != nil {
+		transport.TLSClientConfig = o.TLSConfig.Clone()
+	}
+
+	if o.MaxIdleConns > 0 {
+		transport.MaxIdleConns = o.MaxIdleConns
+	}
+
+	if o.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
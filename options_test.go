@@ -0,0 +1,120 @@
+package webexgosdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientOptionsAndFunctionalOptions(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		if r.URL.Host != "webex.invalid" {
+			t.Errorf("expected a proxied request, got %s", r.URL)
+		}
+		if r.Header.Get("User-Agent") != "options-test" {
+			t.Errorf("expected the user agent from ClientOptions, got %q", r.Header.Get("User-Agent"))
+		}
+		proxied.Add(1)
+		w.Write([]byte(`{"id":"me"}`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient("test-token",
+		ClientOptions{UserAgent: "options-test"},
+		WithBaseURL("http://webex.invalid/v1/"),
+		WithProxyURL(proxy.URL),
+		WithMaxIdleConns(10, 2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Messaging.People.Get(context.Background(), "me"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxied.Load() != 1 {
+		t.Errorf("expected 1 proxied request, got %d", proxied.Load())
+	}
+}
+
+func TestWithClientOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "adapted" {
+			t.Errorf("expected the user agent from the later ClientOptions, got %q", r.Header.Get("User-Agent"))
+		}
+		w.Write([]byte(`{"id":"me"}`))
+	}))
+	defer server.Close()
+
+	opts := []ClientOptions{
+		{BaseURL: server.URL + "/v1/", UserAgent: "first"},
+		{UserAgent: "adapted"},
+	}
+
+	client, err := NewClient("test-token", WithClientOptions(opts...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Messaging.People.Get(context.Background(), "me"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client, err = NewClientWithOptions("test-token", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Messaging.People.Get(context.Background(), "me"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRequestTimeoutOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id":"me"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL+"/"), WithRequestTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.Messaging.People.Get(ctx, "me"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to time out, got %v", err)
+	}
+
+	if _, err := client.Messaging.People.Get(ContextWithTimeout(ctx, time.Second), "me"); err != nil {
+		t.Errorf("expected the override to allow the request, got %v", err)
+	}
+}
+
+func TestHTTPClientConflictsWithTransportOptions(t *testing.T) {
+	_, err := NewClient("test-token", WithHTTPClient(http.DefaultClient), WithProxyURL("http://proxy.invalid"))
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "HTTPClient" {
+		t.Errorf("expected a validation error for HTTPClient, got %v", err)
+	}
+
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) { return nil, errors.New("unused") })
+	if _, err := NewClient("test-token", WithTransport(transport), WithTLSConfig(nil), WithMaxIdleConns(1, 1)); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("expected limits on a custom RoundTripper to be rejected, got %v", err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
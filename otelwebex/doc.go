@@ -0,0 +1,5 @@
+// Package otelwebex instruments the Webex client with OpenTelemetry.
+// It provides a Tracer that creates a client span per API call and a Metrics
+// implementation that records request latency, error and retry counters.
+
+package otelwebex
@@ -0,0 +1,146 @@
+package otelwebex
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rainuxhe/webexgosdk"
+)
+
+const (
+	ScopeName = "github.com/rainuxhe/webexgosdk"
+
+	AttrOperation = "webex.operation"
+)
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a webexgosdk.Tracer backed by provider. A nil provider
+// uses the global tracer provider.
+func NewTracer(provider trace.TracerProvider) webexgosdk.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return &tracer{
+		tracer: provider.Tracer(ScopeName, trace.WithInstrumentationVersion(webexgosdk.Version)),
+	}
+}
+
+func (t *tracer) Start(ctx context.Context, operation string) (context.Context, webexgosdk.Span) {
+	ctx, s := t.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SetAttributes(attrs ...webexgosdk.Attribute) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		kvs = append(kvs, keyValue(attr))
+	}
+	s.span.SetAttributes(kvs...)
+}
+
+func (s *span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.span.End()
+}
+
+// keyValue converts an SDK attribute. Durations are recorded in seconds.
+func keyValue(attr webexgosdk.Attribute) attribute.KeyValue {
+	switch v := attr.Value.(type) {
+	case string:
+		return attribute.String(attr.Key, v)
+	case int:
+		return attribute.Int(attr.Key, v)
+	case int64:
+		return attribute.Int64(attr.Key, v)
+	case bool:
+		return attribute.Bool(attr.Key, v)
+	case time.Duration:
+		return attribute.Float64(attr.Key, v.Seconds())
+	default:
+		return attribute.String(attr.Key, "")
+	}
+}
+
+type metrics struct {
+	duration metric.Float64Histogram
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	retries  metric.Int64Counter
+}
+
+// NewMetrics returns a webexgosdk.Metrics backed by provider. A nil provider
+// uses the global meter provider.
+func NewMetrics(provider metric.MeterProvider) (webexgosdk.Metrics, error) {
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	meter := provider.Meter(ScopeName, metric.WithInstrumentationVersion(webexgosdk.Version))
+
+	var m metrics
+	var err error
+
+	m.duration, err = meter.Float64Histogram("webex.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Webex API calls, including retries."))
+	if err != nil {
+		return nil, err
+	}
+
+	m.requests, err = meter.Int64Counter("webex.client.requests",
+		metric.WithDescription("Number of Webex API calls."))
+	if err != nil {
+		return nil, err
+	}
+
+	m.errors, err = meter.Int64Counter("webex.client.errors",
+		metric.WithDescription("Number of Webex API calls that failed."))
+	if err != nil {
+		return nil, err
+	}
+
+	m.retries, err = meter.Int64Counter("webex.client.retries",
+		metric.WithDescription("Number of retried Webex API requests."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (m *metrics) RecordRequest(ctx context.Context, request webexgosdk.RequestMetrics) {
+	attrs := metric.WithAttributes(
+		attribute.String(AttrOperation, request.Operation),
+		attribute.String(webexgosdk.AttrHTTPMethod, request.Method),
+		attribute.Int(webexgosdk.AttrHTTPStatusCode, request.StatusCode),
+	)
+
+	m.duration.Record(ctx, request.Duration.Seconds(), attrs)
+	m.requests.Add(ctx, 1, attrs)
+
+	if request.Err != nil {
+		m.errors.Add(ctx, 1, attrs)
+	}
+
+	if request.Retries > 0 {
+		m.retries.Add(ctx, int64(request.Retries), attrs)
+	}
+}
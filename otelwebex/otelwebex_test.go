@@ -0,0 +1,111 @@
+package otelwebex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+func TestInstrumentation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trackingid", "ROUTER_123")
+		switch r.URL.Path {
+		case "/messages":
+			w.Write([]byte(`{"id":"message-1"}`))
+		case "/rooms/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		default:
+			w.Write([]byte(`{"items":[{"id":"room-1"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := NewMetrics(meterProvider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client, err := webexgosdk.NewClient("test-token", webexgosdk.ClientOptions{
+		BaseURL: server.URL + "/",
+		Tracer:  NewTracer(tracerProvider),
+		Metrics: metrics,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.Messaging.Messages.Create(ctx, &messaging.MessageCreateRequest{RoomID: "room-1", Text: "hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, err := range client.Messaging.Rooms.ListAll(ctx, nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := client.Messaging.Rooms.Get(ctx, "missing"); err == nil {
+		t.Fatal("expected error for missing room")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	wantNames := []string{"messaging.Messages.Create", "messaging.Rooms.ListPages", "messaging.Rooms.Get"}
+	for i, want := range wantNames {
+		if spans[i].Name != want {
+			t.Errorf("expected span %q, got %q", want, spans[i].Name)
+		}
+	}
+
+	attrs := attribute.NewSet(spans[0].Attributes...)
+	if v, _ := attrs.Value(webexgosdk.AttrTrackingID); v.AsString() != "ROUTER_123" {
+		t.Errorf("expected tracking ID attribute, got %q", v.AsString())
+	}
+	if v, _ := attrs.Value(webexgosdk.AttrHTTPStatusCode); v.AsInt64() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", v.AsInt64())
+	}
+
+	if spans[2].Status.Code.String() != "Error" {
+		t.Errorf("expected failed call to set error status, got %s", spans[2].Status.Code)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts := map[string]int64{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, point := range sum.DataPoints {
+					counts[m.Name] += point.Value
+				}
+			}
+		}
+	}
+
+	if counts["webex.client.requests"] != 3 || counts["webex.client.errors"] != 1 {
+		t.Errorf("unexpected counters: %v", counts)
+	}
+}
@@ -0,0 +1,12 @@
+// Package provisioning reconciles Webex users with a roster exported from an
+// HR system. It reads the roster from CSV or JSON, plans the people to
+// create, update and deactivate, and applies the plan with bounded
+// concurrency, recording the outcome of every row.
+
+package provisioning
+
+// This file serves as the package documentation for the provisioning package.
+// All types are defined in their respective files:
+// - roster.go: Roster entries and the CSV and JSON readers.
+// - plan.go: Plans, changes and the machine-readable result.
+// - provisioner.go: Provisioner, options, planning and applying.
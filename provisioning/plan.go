@@ -0,0 +1,187 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+// Action describes the change a plan makes to a person.
+type Action string
+
+const (
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionDeactivate Action = "deactivate"
+)
+
+// Change is a single step of a provisioning plan.
+type Change struct {
+	Action Action
+
+	// Entry is the roster entry; nil for deactivations.
+	Entry *Entry
+
+	// Existing is the person currently in Webex; nil for creations.
+	Existing *messaging.Person
+
+	// Fields lists the fields an update changes, named as in the API.
+	Fields []string
+
+	// Result is the person returned by the API once the change is applied.
+	Result *messaging.Person
+
+	// Err is the reason applying the change failed.
+	Err error
+
+	// licenses are the resolved license IDs of Entry.
+	licenses []string
+}
+
+// Email returns the address of the person the change is about.
+func (c *Change) Email() string {
+	if c.Entry != nil {
+		return c.Entry.Email
+	}
+	if len(c.Existing.Emails) > 0 {
+		return c.Existing.Emails[0]
+	}
+	return c.Existing.ID
+}
+
+// Row returns the roster row of the change, or 0 for deactivations.
+func (c *Change) Row() int {
+	if c.Entry == nil {
+		return 0
+	}
+	return c.Entry.Row
+}
+
+func (c *Change) String() string {
+	switch c.Action {
+	case ActionCreate:
+		return fmt.Sprintf("create %s (row %d)", c.Email(), c.Row())
+	case ActionUpdate:
+		return fmt.Sprintf("update %s (row %d): %s", c.Email(), c.Row(), strings.Join(c.Fields, ", "))
+	default:
+		return fmt.Sprintf("%s %s id=%s", c.Action, c.Email(), c.Existing.ID)
+	}
+}
+
+// Status values of a change in the result file.
+const (
+	StatusPlanned = "planned"
+	StatusApplied = "applied"
+	StatusFailed  = "failed"
+)
+
+// Status reports whether the change is only planned, was applied or failed.
+func (c *Change) Status() string {
+	switch {
+	case c.Err != nil:
+		return StatusFailed
+	case c.Result != nil:
+		return StatusApplied
+	default:
+		return StatusPlanned
+	}
+}
+
+// changeRecord is the machine-readable form of a change.
+type changeRecord struct {
+	Row      int      `json:"row,omitempty"`
+	Email    string   `json:"email"`
+	Action   Action   `json:"action"`
+	Fields   []string `json:"fields,omitempty"`
+	PersonID string   `json:"personId,omitempty"`
+	Status   string   `json:"status"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func (c *Change) MarshalJSON() ([]byte, error) {
+	record := changeRecord{
+		Row:    c.Row(),
+		Email:  c.Email(),
+		Action: c.Action,
+		Fields: c.Fields,
+		Status: c.Status(),
+	}
+
+	switch {
+	case c.Result != nil:
+		record.PersonID = c.Result.ID
+	case c.Existing != nil:
+		record.PersonID = c.Existing.ID
+	}
+
+	if c.Err != nil {
+		record.Error = c.Err.Error()
+	}
+
+	return json.Marshal(&record)
+}
+
+// Plan is the outcome of comparing a roster with Webex.
+type Plan struct {
+	Changes []*Change
+
+	// Unchanged counts the roster entries that already match.
+	Unchanged int
+
+	// Applied is false for dry runs.
+	Applied bool
+
+	// personIDs maps lower-cased email addresses to person IDs. Apply adds
+	// the people it creates so that their reports can reference them.
+	personIDs map[string]string
+}
+
+// Empty reports whether the plan contains no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Failed returns the changes that could not be applied.
+func (p *Plan) Failed() []*Change {
+	var failed []*Change
+	for _, change := range p.Changes {
+		if change.Err != nil {
+			failed = append(failed, change)
+		}
+	}
+	return failed
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+
+	var b strings.Builder
+	for i, change := range p.Changes {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(change.String())
+		if change.Err != nil {
+			fmt.Fprintf(&b, ": %v", change.Err)
+		}
+	}
+
+	return b.String()
+}
+
+// WriteResults writes one JSON object per change, as JSON Lines, with the
+// row, email, action, changed fields, person ID, status and error.
+func (p *Plan) WriteResults(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, change := range p.Changes {
+		if err := encoder.Encode(change); err != nil {
+			return fmt.Errorf("failed to write result for %s: %w", change.Email(), err)
+		}
+	}
+	return nil
+}
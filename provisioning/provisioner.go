@@ -0,0 +1,375 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/admin"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+const DefaultConcurrency = 4
+
+var (
+	ErrUnknownLicense = errors.New("unknown license")
+	ErrUnknownManager = errors.New("manager is neither in Webex nor in the roster")
+	ErrApplyFailed    = errors.New("some changes failed")
+)
+
+type Options struct {
+	// OrgID provisions people in this organization instead of the caller's
+	// own.
+	OrgID string
+
+	// DryRun makes Sync return the plan without applying it.
+	DryRun bool
+
+	// Concurrency bounds the number of changes applied at once. Defaults to
+	// DefaultConcurrency.
+	Concurrency int
+
+	// DeactivateMissing plans to disable the login of people who are not
+	// in the roster. People whose login is already disabled, and the caller,
+	// are left alone.
+	DeactivateMissing bool
+
+	// Managed reports whether a person missing from the roster may be
+	// deactivated. When nil every such person may be.
+	Managed func(*messaging.Person) bool
+
+	// Progress is called after each change has been applied or has failed.
+	// Calls are serialized.
+	Progress func(change *Change)
+}
+
+// Provisioner reconciles the people of an organization with a roster.
+type Provisioner struct {
+	client  *webexgosdk.Client
+	options Options
+}
+
+func New(client *webexgosdk.Client, opts *Options) *Provisioner {
+	p := &Provisioner{client: client}
+
+	if opts != nil {
+		p.options = *opts
+	}
+
+	if p.options.Concurrency <= 0 {
+		p.options.Concurrency = DefaultConcurrency
+	}
+
+	return p
+}
+
+// Sync plans the changes needed to match roster and applies them unless
+// DryRun is set. The plan is returned even when applying fails, with the
+// outcome recorded on every change.
+func (p *Provisioner) Sync(ctx context.Context, roster []Entry) (*Plan, error) {
+	plan, err := p.Plan(ctx, roster)
+	if err != nil || p.options.DryRun {
+		return plan, err
+	}
+
+	return plan, p.Apply(ctx, plan)
+}
+
+// Plan compares roster with the people in Webex. People are matched by any
+// of their email addresses, case-insensitively. Roster problems, such as
+// unknown licenses or managers, are reported together as RowErrors.
+func (p *Provisioner) Plan(ctx context.Context, roster []Entry) (*Plan, error) {
+	if err := validateRoster(roster); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{personIDs: make(map[string]string)}
+	existing := make(map[string]*messaging.Person)
+	var people []*messaging.Person
+	for person, err := range p.client.Messaging.People.ListAll(ctx, &messaging.PeopleListOptions{OrgID: p.options.OrgID}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list people: %w", err)
+		}
+
+		people = append(people, person)
+		for _, email := range person.Emails {
+			existing[strings.ToLower(email)] = person
+			plan.personIDs[strings.ToLower(email)] = person.ID
+		}
+	}
+
+	licenses, err := p.licenseIDs(ctx, roster)
+	if err != nil {
+		return nil, err
+	}
+
+	inRoster := make(map[string]bool, len(roster))
+	for _, entry := range roster {
+		inRoster[strings.ToLower(entry.Email)] = true
+	}
+
+	var errs []error
+	var creates, updates []*Change
+	for i := range roster {
+		entry := &roster[i]
+		change := &Change{Entry: entry, Existing: existing[strings.ToLower(entry.Email)]}
+
+		for _, license := range entry.Licenses {
+			id, ok := licenses[strings.ToLower(license)]
+			if !ok {
+				errs = append(errs, &RowError{Row: entry.Row, Email: entry.Email, Err: fmt.Errorf("%w %q", ErrUnknownLicense, license)})
+				continue
+			}
+			change.licenses = append(change.licenses, id)
+		}
+
+		manager := strings.ToLower(entry.Manager)
+		if manager != "" && existing[manager] == nil && !inRoster[manager] {
+			errs = append(errs, &RowError{Row: entry.Row, Email: entry.Email, Err: ErrUnknownManager})
+		}
+
+		if change.Existing == nil {
+			change.Action = ActionCreate
+			creates = append(creates, change)
+			continue
+		}
+
+		change.Fields = diff(change.Existing, entry, change.licenses, plan.personIDs[manager])
+		if len(change.Fields) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		change.Action = ActionUpdate
+		updates = append(updates, change)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	plan.Changes = append(creates, updates...)
+
+	if p.options.DeactivateMissing {
+		me, err := p.client.Messaging.People.GetMe(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the caller: %w", err)
+		}
+
+		for _, person := range people {
+			if person.ID == me.ID || !person.LoginEnabled || slices.ContainsFunc(person.Emails, func(email string) bool { return inRoster[strings.ToLower(email)] }) {
+				continue
+			}
+
+			if p.options.Managed != nil && !p.options.Managed(person) {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, &Change{Action: ActionDeactivate, Existing: person})
+		}
+	}
+
+	return plan, nil
+}
+
+// licenseIDs maps the lower-cased ID and name of every license to its ID.
+// Licenses are only listed when the roster assigns any.
+func (p *Provisioner) licenseIDs(ctx context.Context, roster []Entry) (map[string]string, error) {
+	if !slices.ContainsFunc(roster, func(entry Entry) bool { return len(entry.Licenses) > 0 }) {
+		return nil, nil
+	}
+
+	licenses, err := p.client.Admin.Licenses.List(ctx, &admin.LicenseListOptions{OrgID: p.options.OrgID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list licenses: %w", err)
+	}
+
+	ids := make(map[string]string, 2*len(licenses))
+	for _, license := range licenses {
+		ids[strings.ToLower(license.Name)] = license.ID
+	}
+	for _, license := range licenses {
+		ids[strings.ToLower(license.ID)] = license.ID
+	}
+	return ids, nil
+}
+
+// diff returns the API names of the fields where entry differs from person.
+// An empty managerID means the manager is still to be created.
+func diff(person *messaging.Person, entry *Entry, licenses []string, managerID string) []string {
+	var fields []string
+	for _, field := range []struct {
+		name           string
+		current, value string
+	}{
+		{"firstName", person.FirstName, entry.FirstName},
+		{"lastName", person.LastName, entry.LastName},
+		{"displayName", person.DisplayName, entry.DisplayName},
+		{"department", person.Department, entry.Department},
+	} {
+		if field.value != "" && field.value != field.current {
+			fields = append(fields, field.name)
+		}
+	}
+
+	if entry.Manager != "" && (managerID == "" || managerID != person.ManagerID) {
+		fields = append(fields, "managerId")
+	}
+
+	if len(licenses) > 0 && !sameSet(licenses, person.Licenses) {
+		fields = append(fields, "licenses")
+	}
+
+	return fields
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// Apply applies the plan with bounded concurrency and records the outcome on
+// every change. People whose manager is created by the same plan are applied
+// after their manager. When any change fails, the returned error wraps
+// ErrApplyFailed and the failures are listed by Plan.Failed. Calling Apply
+// again retries the changes that have not been applied.
+func (p *Provisioner) Apply(ctx context.Context, plan *Plan) error {
+	if plan.personIDs == nil {
+		plan.personIDs = make(map[string]string)
+	}
+
+	var mu sync.Mutex
+	var pending []*Change
+	for _, change := range plan.Changes {
+		if change.Result == nil {
+			change.Err = nil
+			pending = append(pending, change)
+		}
+	}
+
+	for len(pending) > 0 {
+		var ready, waiting []*Change
+		for _, change := range pending {
+			if manager := change.manager(); manager == "" || plan.personIDs[manager] != "" {
+				ready = append(ready, change)
+			} else {
+				waiting = append(waiting, change)
+			}
+		}
+
+		if len(ready) == 0 {
+			for _, change := range waiting {
+				change.Err = ErrUnknownManager
+				p.progress(&mu, change)
+			}
+			break
+		}
+
+		sem := make(chan struct{}, p.options.Concurrency)
+		var wg sync.WaitGroup
+		for _, change := range ready {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				mu.Lock()
+				managerID := plan.personIDs[change.manager()]
+				mu.Unlock()
+
+				if err := ctx.Err(); err != nil {
+					change.Err = err
+				} else {
+					change.Result, change.Err = p.apply(ctx, change, managerID)
+				}
+
+				mu.Lock()
+				if change.Action == ActionCreate && change.Err == nil {
+					plan.personIDs[strings.ToLower(change.Entry.Email)] = change.Result.ID
+				}
+				mu.Unlock()
+
+				p.progress(&mu, change)
+			}()
+		}
+		wg.Wait()
+
+		pending = waiting
+	}
+	plan.Applied = true
+
+	if failed := len(plan.Failed()); failed > 0 {
+		return fmt.Errorf("%w: %d of %d changes failed", ErrApplyFailed, failed, len(plan.Changes))
+	}
+	return nil
+}
+
+func (p *Provisioner) apply(ctx context.Context, change *Change, managerID string) (*messaging.Person, error) {
+	people := p.client.Messaging.People
+	entry := change.Entry
+
+	switch change.Action {
+	case ActionCreate:
+		return people.Create(ctx, &messaging.PersonCreateRequest{
+			Emails:      []string{entry.Email},
+			FirstName:   entry.FirstName,
+			LastName:    entry.LastName,
+			DisplayName: entry.DisplayName,
+			Department:  entry.Department,
+			ManagerID:   managerID,
+			Licenses:    change.licenses,
+			OrgID:       p.options.OrgID,
+		})
+	case ActionUpdate:
+		return people.Modify(ctx, change.Existing.ID, func(req *messaging.PersonUpdateRequest) {
+			for _, field := range change.Fields {
+				switch field {
+				case "firstName":
+					req.FirstName = webexgosdk.Some(entry.FirstName)
+				case "lastName":
+					req.LastName = webexgosdk.Some(entry.LastName)
+				case "displayName":
+					req.DisplayName = entry.DisplayName
+				case "department":
+					req.Department = webexgosdk.Some(entry.Department)
+				case "managerId":
+					req.ManagerID = webexgosdk.Some(managerID)
+				case "licenses":
+					req.Licenses = change.licenses
+				}
+			}
+		})
+	case ActionDeactivate:
+		return people.Modify(ctx, change.Existing.ID, func(req *messaging.PersonUpdateRequest) {
+			req.LoginEnabled = webexgosdk.Some(false)
+		})
+	}
+
+	return nil, fmt.Errorf("unknown action %q", change.Action)
+}
+
+// manager returns the lower-cased email of the change's manager, if any.
+func (c *Change) manager() string {
+	if c.Entry == nil {
+		return ""
+	}
+	return strings.ToLower(c.Entry.Manager)
+}
+
+func (p *Provisioner) progress(mu *sync.Mutex, change *Change) {
+	if p.options.Progress == nil {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	p.options.Progress(change)
+}
@@ -0,0 +1,152 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk/admin"
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webextest"
+)
+
+const testRoster = `email,First Name,last_name,department,manager,licenses,notes
+alice@example.com,Alice,Smith,Engineering,dana@example.com,Messaging;calling,new hire
+Bob@Example.com,,,Support,,messaging,
+dana@example.com,Dana,Lee,Engineering,,,
+`
+
+func TestProvisionerSync(t *testing.T) {
+	server := webextest.NewServer(nil)
+	defer server.Close()
+
+	messagingLicense := server.AddLicense(admin.License{Name: "Messaging", TotalUnits: 10})
+	callingLicense := server.AddLicense(admin.License{Name: "Calling", TotalUnits: 10})
+	bob := server.AddPerson(messaging.Person{Emails: []string{"bob@example.com"}, Department: "Sales", Licenses: []string{messagingLicense.ID}, LoginEnabled: true})
+	carol := server.AddPerson(messaging.Person{Emails: []string{"carol@example.com"}, LoginEnabled: true})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roster, err := ReadCSV(strings.NewReader(testRoster))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	dryRun := New(client, &Options{DryRun: true, DeactivateMissing: true})
+	plan, err := dryRun.Sync(ctx, roster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "create alice@example.com (row 2)\n" +
+		"create dana@example.com (row 4)\n" +
+		"update Bob@Example.com (row 3): department\n" +
+		"deactivate carol@example.com id=" + carol.ID
+	if plan.String() != want {
+		t.Errorf("unexpected plan:\n%s", plan)
+	}
+
+	if plan.Applied {
+		t.Error("expected a dry run not to be applied")
+	}
+
+	var progress []string
+	provisioner := New(client, &Options{Concurrency: 2, Progress: func(change *Change) {
+		progress = append(progress, change.Email())
+	}})
+	if err := provisioner.Apply(ctx, plan); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(progress) != 4 || progress[len(progress)-1] != "alice@example.com" {
+		t.Errorf("expected alice to be created after her manager, got %v", progress)
+	}
+
+	people := client.Messaging.People
+	alice, _ := people.List(ctx, &messaging.PeopleListOptions{Email: "alice@example.com"})
+	dana, _ := people.List(ctx, &messaging.PeopleListOptions{Email: "dana@example.com"})
+	if len(alice) != 1 || len(dana) != 1 || alice[0].ManagerID != dana[0].ID {
+		t.Fatalf("expected alice to report to dana, got %+v", alice)
+	}
+
+	if !sameSet(alice[0].Licenses, []string{messagingLicense.ID, callingLicense.ID}) {
+		t.Errorf("unexpected licenses %v", alice[0].Licenses)
+	}
+
+	if updated, _ := people.Get(ctx, bob.ID); updated.Department != "Support" || len(updated.Licenses) != 1 {
+		t.Errorf("expected only bob's department to change, got %+v", updated)
+	}
+
+	if deactivated, _ := people.Get(ctx, carol.ID); deactivated.LoginEnabled {
+		t.Error("expected carol's login to be disabled")
+	}
+
+	var results bytes.Buffer
+	if err := plan.WriteResults(&results); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(results.String()), "\n")
+	var record map[string]any
+	if len(lines) != 4 || json.Unmarshal([]byte(lines[2]), &record) != nil {
+		t.Fatalf("expected 4 results, got %s", results.String())
+	}
+
+	if record["row"] != 3.0 || record["action"] != "update" || record["personId"] != bob.ID || record["status"] != StatusApplied {
+		t.Errorf("unexpected result %v", record)
+	}
+
+	if again, _ := provisioner.Plan(ctx, roster); !again.Empty() || again.Unchanged != 3 {
+		t.Errorf("expected the roster to be in sync, got:\n%s", again)
+	}
+}
+
+func TestProvisionerPlanReportsRowErrors(t *testing.T) {
+	server := webextest.NewServer(nil)
+	defer server.Close()
+
+	server.AddLicense(admin.License{Name: "Messaging"})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = New(client, nil).Plan(context.Background(), []Entry{
+		{Row: 2, Email: "alice@example.com", Licenses: []string{"Messaging", "Gold"}},
+		{Row: 3, Email: "bob@example.com", Manager: "nobody@example.com"},
+	})
+
+	if !errors.Is(err, ErrUnknownLicense) || !errors.Is(err, ErrUnknownManager) {
+		t.Fatalf("expected unknown license and manager errors, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), `row 2 (alice@example.com): unknown license "Gold"`) || !strings.Contains(err.Error(), "row 3 (bob@example.com)") {
+		t.Errorf("expected the rows in the error, got %v", err)
+	}
+}
+
+func TestReadRoster(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("email,department\n,Sales\nann@example.com,\nANN@example.com,\n"))
+
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Row != 2 || !errors.Is(err, ErrMissingEmail) || !errors.Is(err, ErrDuplicateEmail) {
+		t.Errorf("expected missing and duplicate email errors, got %v", err)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("name\nAnn\n")); err == nil {
+		t.Error("expected a roster without an email column to be rejected")
+	}
+
+	roster, err := ReadJSON(strings.NewReader(`[{"email": " ann@example.com ", "licenses": ["Messaging"]}]`))
+	if err != nil || roster[0].Email != "ann@example.com" || roster[0].Row != 1 {
+		t.Errorf("unexpected roster %+v, %v", roster, err)
+	}
+}
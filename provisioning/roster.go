@@ -0,0 +1,157 @@
+package provisioning
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Entry is one person in a roster. Empty fields are left unchanged on
+// existing people, so a roster only needs the columns it manages.
+type Entry struct {
+	Email       string `json:"email"`
+	FirstName   string `json:"firstName,omitempty"`
+	LastName    string `json:"lastName,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Department  string `json:"department,omitempty"`
+
+	// Manager is the email address of the person's manager, who must
+	// already exist or be part of the roster.
+	Manager string `json:"manager,omitempty"`
+
+	// Licenses are license IDs or names. Names are matched case-insensitively.
+	Licenses []string `json:"licenses,omitempty"`
+
+	// Row is the position of the entry in its source: the line of a CSV
+	// file, or the 1-based index in a JSON array.
+	Row int `json:"-"`
+}
+
+// RowError reports a problem with one roster entry.
+type RowError struct {
+	Row   int
+	Email string
+	Err   error
+}
+
+func (e *RowError) Error() string {
+	if e.Email == "" {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d (%s): %v", e.Row, e.Email, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	ErrMissingEmail   = errors.New("email is required")
+	ErrDuplicateEmail = errors.New("email appears more than once")
+)
+
+// csvColumns maps normalized CSV headers to the fields they fill.
+var csvColumns = map[string]func(*Entry, string){
+	"email":       func(e *Entry, v string) { e.Email = v },
+	"firstname":   func(e *Entry, v string) { e.FirstName = v },
+	"lastname":    func(e *Entry, v string) { e.LastName = v },
+	"displayname": func(e *Entry, v string) { e.DisplayName = v },
+	"department":  func(e *Entry, v string) { e.Department = v },
+	"manager":     func(e *Entry, v string) { e.Manager = v },
+	"licenses":    func(e *Entry, v string) { e.Licenses = splitList(v) },
+}
+
+// ReadCSV reads a roster whose first line names the columns: email,
+// firstName, lastName, displayName, department, manager and licenses, in any
+// order and case. Multiple licenses are separated by semicolons. Other
+// columns are ignored.
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster header: %w", err)
+	}
+
+	fields := make([]func(*Entry, string), len(header))
+	hasEmail := false
+	for i, name := range header {
+		key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.TrimSpace(name)))
+		fields[i] = csvColumns[key]
+		hasEmail = hasEmail || key == "email"
+	}
+
+	if !hasEmail {
+		return nil, fmt.Errorf("roster has no email column")
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read roster: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := Entry{Row: line}
+		for i, value := range record {
+			if fields[i] != nil {
+				fields[i](&entry, strings.TrimSpace(value))
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, validateRoster(entries)
+}
+
+// ReadJSON reads a roster from a JSON array of entries.
+func ReadJSON(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode roster: %w", err)
+	}
+
+	for i := range entries {
+		entries[i].Row = i + 1
+		entries[i].Email = strings.TrimSpace(entries[i].Email)
+	}
+
+	return entries, validateRoster(entries)
+}
+
+// validateRoster reports every entry without an email address and every
+// repeated address.
+func validateRoster(entries []Entry) error {
+	var errs []error
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Email)
+		switch {
+		case key == "":
+			errs = append(errs, &RowError{Row: entry.Row, Err: ErrMissingEmail})
+		case seen[key]:
+			errs = append(errs, &RowError{Row: entry.Row, Email: entry.Email, Err: ErrDuplicateEmail})
+		}
+		seen[key] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,10 +1,15 @@
 package webexgosdk
 
 import (
+	"crypto/tls"
 	"errors"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
+	"github.com/rainuxhe/webexgosdk/admin"
 	"github.com/rainuxhe/webexgosdk/calling"
 	"github.com/rainuxhe/webexgosdk/internal/core"
 	"github.com/rainuxhe/webexgosdk/meeting"
@@ -20,16 +25,203 @@ const (
 
 var (
 	ErrAccessTokenRequired = errors.New("access token is required")
+	ErrInvalidParameter    = core.ErrInvalidParameter
+	ErrNoMorePages         = core.ErrNoMorePages
+	ErrFileTooLarge        = core.ErrFileTooLarge
+	ErrFileSizeMismatch    = core.ErrFileSizeMismatch
+	ErrForeignURL          = core.ErrForeignURL
+
+	// API errors match these with errors.Is by status code.
+	ErrUnauthorized = core.ErrUnauthorized
+	ErrForbidden    = core.ErrForbidden
+	ErrNotFound     = core.ErrNotFound
+	ErrConflict     = core.ErrConflict
+	ErrRateLimited  = core.ErrRateLimited
+)
+
+type (
+	// APIError is returned when the API answers with an error status.
+	APIError = core.APIError
+
+	// RateLimitError is returned for 429 responses.
+	RateLimitError = core.RateLimitError
+
+	// FileScanningError is returned for 423 responses while a file is being
+	// scanned for malware.
+	FileScanningError = core.FileScanningError
+
+	// ErrorDetail is one entry of APIError.Errors.
+	ErrorDetail = core.ErrorDetail
+
+	// ValidationError names the argument a method rejected before sending a
+	// request. It matches ErrInvalidParameter.
+	ValidationError = core.ValidationError
+)
+
+// InvalidParameter returns a ValidationError for field of method.
+func InvalidParameter(method, field string) error {
+	return core.InvalidParameter(method, field)
+}
+
+// IsUnauthorized reports whether err is a 401 response, usually an expired
+// or revoked access token.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 response.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is a 409 response.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsRateLimited reports whether err is a 429 response.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsRetryable reports whether err is worth retrying: a rate limit, a
+// temporary server error or a dropped connection.
+func IsRetryable(err error) bool {
+	return core.IsRetryable(err)
+}
+
+// Pager walks a paginated list endpoint one page at a time. It is returned
+// by the ListPages methods of every service.
+type Pager[T any] = core.Pager[T]
+
+// Optional is an update request field that distinguishes unset, null and
+// a value, so that fields can be set to false, zero or empty.
+type Optional[T any] = core.Optional[T]
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return core.Some(v)
+}
+
+// Null returns an Optional that is sent as JSON null.
+func Null[T any]() Optional[T] {
+	return core.Null[T]()
+}
+
+// RetryPolicy controls automatic retries of rate-limited and failed requests.
+type RetryPolicy = core.RetryPolicy
+
+// RetryEvent is passed to RetryPolicy.OnRetry before each retry.
+type RetryEvent = core.RetryEvent
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults.
+func DefaultRetryPolicy() *RetryPolicy {
+	return core.DefaultRetryPolicy()
+}
+
+type (
+	// Token is an OAuth2 access token.
+	Token = core.Token
+
+	// TokenSource supplies access tokens to the client.
+	TokenSource = core.TokenSource
+
+	// TokenStore persists refreshed tokens between runs.
+	TokenStore = core.TokenStore
+
+	// RefreshTokenConfig configures a RefreshTokenSource.
+	RefreshTokenConfig = core.RefreshTokenConfig
+
+	// RefreshTokenSource keeps an integration's access token fresh using its
+	// refresh token.
+	RefreshTokenSource = core.RefreshTokenSource
+
+	// FileTokenStore stores a token as JSON on disk.
+	FileTokenStore = core.FileTokenStore
 )
 
+type (
+	// Doer sends HTTP requests. *http.Client implements Doer.
+	Doer = core.Doer
+
+	// DoerFunc adapts a function to the Doer interface.
+	DoerFunc = core.DoerFunc
+
+	// Middleware wraps every request sent by the client.
+	Middleware = core.Middleware
+)
+
+// Attribute keys recorded on API call spans.
+const (
+	AttrHTTPMethod     = core.AttrHTTPMethod
+	AttrHTTPStatusCode = core.AttrHTTPStatusCode
+	AttrURL            = core.AttrURL
+	AttrTrackingID     = core.AttrTrackingID
+	AttrRetryCount     = core.AttrRetryCount
+	AttrRateLimitWait  = core.AttrRateLimitWait
+)
+
+type (
+	// Tracer starts a span for every API call.
+	Tracer = core.Tracer
+
+	// Span is a single traced API call.
+	Span = core.Span
+
+	// Attribute is a key/value pair recorded on a span.
+	Attribute = core.Attribute
+
+	// Metrics receives one record per completed API call.
+	Metrics = core.Metrics
+
+	// RequestMetrics describes a completed API call.
+	RequestMetrics = core.RequestMetrics
+)
+
+// LoggingMiddleware logs every request through logger, or slog.Default()
+// when logger is nil.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return core.LoggingMiddleware(logger)
+}
+
+// HeaderMiddleware sets header on every request.
+func HeaderMiddleware(header http.Header) Middleware {
+	return core.HeaderMiddleware(header)
+}
+
+// DumpMiddleware writes requests and responses to w with the Authorization
+// header redacted.
+func DumpMiddleware(w io.Writer, body bool) Middleware {
+	return core.DumpMiddleware(w, body)
+}
+
+// NewRefreshTokenSource creates a token source that refreshes an integration
+// token through the access_token endpoint.
+func NewRefreshTokenSource(config *RefreshTokenConfig) (*RefreshTokenSource, error) {
+	return core.NewRefreshTokenSource(config)
+}
+
+// StaticTokenSource returns a token source that always returns accessToken.
+func StaticTokenSource(accessToken string) TokenSource {
+	return core.StaticTokenSource(accessToken)
+}
+
 type MessagingAPI struct {
-	Messages        *messaging.MessagesService
-	People          *messaging.PeopleService
-	Webhooks        *messaging.WebhooksService
-	Memberships     *messaging.MembershipsService
-	TeamMemberships *messaging.TeamMembershipsService
-	Teams           *messaging.TeamsService
-	Rooms           *messaging.RoomsService
+	Messages          *messaging.MessagesService
+	AttachmentActions *messaging.AttachmentActionsService
+	Files             *messaging.FilesService
+	People            *messaging.PeopleService
+	Webhooks          *messaging.WebhooksService
+	Memberships       *messaging.MembershipsService
+	TeamMemberships   *messaging.TeamMembershipsService
+	Teams             *messaging.TeamsService
+	Rooms             *messaging.RoomsService
+	Events            *messaging.EventsService
 }
 
 type MeetingAPI struct {
@@ -44,47 +236,110 @@ type CallingAPI struct {
 	Voicemail   *calling.VoicemailService
 }
 
+type AdminAPI struct {
+	Organizations *admin.OrganizationsService
+	Roles         *admin.RolesService
+	Licenses      *admin.LicensesService
+
+	// Directory caches roles and licenses to expand people's role and
+	// license IDs.
+	Directory *admin.Directory
+}
+
 type Client struct {
 	Messaging *MessagingAPI
 	Meeting   *MeetingAPI
 	Calling   *CallingAPI
+	Admin     *AdminAPI
 
 	session *core.RestSession
 }
 
 type ClientOptions struct {
-	BaseURL        string
+	BaseURL string
+
+	// RequestTimeout limits each attempt of a request. It defaults to
+	// DefaultRequestTimeout seconds unless HTTPClient is set.
 	RequestTimeout time.Duration
-	UserAgent      string
+
+	UserAgent string
+
+	// Retry enables automatic retries. Nil disables retries.
+	Retry *RetryPolicy
+
+	// TokenSource supplies access tokens, for example a RefreshTokenSource.
+	// When set, the accessToken argument of NewClient may be empty.
+	TokenSource TokenSource
+
+	// Middlewares wrap every request. The first middleware is the outermost.
+	Middlewares []Middleware
+
+	// Tracer and Metrics instrument every API call. See the otelwebex
+	// package for OpenTelemetry implementations.
+	Tracer  Tracer
+	Metrics Metrics
+
+	// HTTPClient sends the requests instead of a client built from the
+	// transport fields below, which must then be left unset.
+	HTTPClient *http.Client
+
+	// Transport sends the requests. ProxyURL, TLSConfig and the idle
+	// connection limits are applied to a clone of it, so it must be an
+	// *http.Transport when they are set. It defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// ProxyURL routes requests through a proxy, such as
+	// "http://proxy.example.com:3128". By default the environment's
+	// HTTP_PROXY and HTTPS_PROXY settings apply.
+	ProxyURL string
+
+	// TLSConfig sets a custom CA pool or client certificates for mTLS.
+	TLSConfig *tls.Config
+
+	// MaxIdleConns and MaxIdleConnsPerHost size the connection pool.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
 }
 
-func NewClient(accessToken string, opts ...ClientOptions) (*Client, error) {
-	if accessToken == "" {
+// NewClientWithOptions creates a client from ClientOptions values, later
+// values overriding earlier ones. It keeps the parameters NewClient had
+// before it accepted functional options.
+//
+// Deprecated: Use NewClient with WithClientOptions or the With functions.
+func NewClientWithOptions(accessToken string, opts ...ClientOptions) (*Client, error) {
+	return NewClient(accessToken, WithClientOptions(opts...))
+}
+
+// NewClient creates a client. Options may be a ClientOptions value, the
+// With functions, or both.
+func NewClient(accessToken string, opts ...Option) (*Client, error) {
+	var options ClientOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&options)
+		}
+	}
+
+	if accessToken == "" && options.TokenSource == nil {
 		accessToken = os.Getenv(AccessTokenEnvVar)
 	}
 
-	if accessToken == "" {
+	if accessToken == "" && options.TokenSource == nil {
 		return nil, ErrAccessTokenRequired
 	}
 
-	options := ClientOptions{
-		BaseURL:        DefaultBaseURL,
-		RequestTimeout: DefaultRequestTimeout * time.Second,
+	if options.BaseURL == "" {
+		options.BaseURL = DefaultBaseURL
 	}
 
-	if len(opts) > 0 {
-		opt := opts[0]
-		if opt.BaseURL != "" {
-			options.BaseURL = opt.BaseURL
-		}
-
-		if opt.RequestTimeout > 0 {
-			options.RequestTimeout = opt.RequestTimeout
-		}
+	if options.RequestTimeout == 0 && options.HTTPClient == nil {
+		options.RequestTimeout = DefaultRequestTimeout * time.Second
+	}
 
-		if opt.UserAgent != "" {
-			options.UserAgent = opt.UserAgent
-		}
+	httpClient, err := options.httpClient()
+	if err != nil {
+		return nil, err
 	}
 
 	session := core.NewRestSession(&core.RestSessionConfig{
@@ -92,6 +347,12 @@ func NewClient(accessToken string, opts ...ClientOptions) (*Client, error) {
 		BaseURL:     options.BaseURL,
 		Timeout:     options.RequestTimeout,
 		UserAgent:   options.UserAgent,
+		HTTPClient:  httpClient,
+		TokenSource: options.TokenSource,
+		Retry:       options.Retry,
+		Middlewares: options.Middlewares,
+		Tracer:      options.Tracer,
+		Metrics:     options.Metrics,
 	})
 
 	client := &Client{
@@ -99,13 +360,16 @@ func NewClient(accessToken string, opts ...ClientOptions) (*Client, error) {
 	}
 
 	client.Messaging = &MessagingAPI{
-		Messages:        messaging.NewMessagesService(session),
-		People:          messaging.NewPeopleService(session),
-		Webhooks:        messaging.NewWebhooksService(session),
-		Rooms:           messaging.NewRoomsService(session),
-		Teams:           messaging.NewTeamsService(session),
-		TeamMemberships: messaging.NewTeamMembershipsService(session),
-		Memberships:     messaging.NewMembershipsService(session),
+		Messages:          messaging.NewMessagesService(session),
+		AttachmentActions: messaging.NewAttachmentActionsService(session),
+		Files:             messaging.NewFilesService(session),
+		People:            messaging.NewPeopleService(session),
+		Webhooks:          messaging.NewWebhooksService(session),
+		Rooms:             messaging.NewRoomsService(session),
+		Teams:             messaging.NewTeamsService(session),
+		TeamMemberships:   messaging.NewTeamMembershipsService(session),
+		Memberships:       messaging.NewMembershipsService(session),
+		Events:            messaging.NewEventsService(session),
 	}
 
 	client.Meeting = &MeetingAPI{
@@ -120,6 +384,13 @@ func NewClient(accessToken string, opts ...ClientOptions) (*Client, error) {
 		Voicemail:   calling.NewVoicemailService(session),
 	}
 
+	client.Admin = &AdminAPI{
+		Organizations: admin.NewOrganizationsService(session),
+		Roles:         admin.NewRolesService(session),
+		Licenses:      admin.NewLicensesService(session),
+	}
+	client.Admin.Directory = admin.NewDirectory(client.Admin.Roles, client.Admin.Licenses)
+
 	return client, nil
 }
 
@@ -130,3 +401,13 @@ func (c *Client) AccessToken() string {
 func (c *Client) SetAccessToken(token string) {
 	c.session.SetAccessToken(token)
 }
+
+// Use appends middlewares to the client's request chain.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.session.Use(middlewares...)
+}
+
+// SetTokenSource replaces the client's credentials with a token source.
+func (c *Client) SetTokenSource(source TokenSource) {
+	c.session.SetTokenSource(source)
+}
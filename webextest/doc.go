@@ -0,0 +1,18 @@
+// Package webextest provides an in-memory fake of the Webex REST API for
+// tests. It keeps rooms, memberships, messages, people, teams, webhooks,
+// meetings, invitees, registrants, calls, voicemail and licenses in memory,
+// returns Webex-style IDs, pagination links and error payloads, can inject
+// 429 responses and delivers webhook notifications to registered target URLs.
+
+package webextest
+
+// This file serves as the package documentation for the webextest package.
+// All types are defined in their respective files:
+// - server.go: Server, authentication, rate limits, routing and pagination.
+// - people.go: People endpoints.
+// - rooms.go: Rooms, memberships, teams and team memberships endpoints.
+// - messages.go: Messages endpoints, mentions and file contents.
+// - webhooks.go: Webhooks endpoints and notification delivery.
+// - meetings.go: Meetings, invitees and registrants endpoints.
+// - telephony.go: Calls, call history and voicemail endpoints.
+// - licenses.go: Licenses endpoints.
@@ -0,0 +1,54 @@
+package webextest
+
+import (
+	"slices"
+
+	"github.com/rainuxhe/webexgosdk/admin"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+func (s *Server) licenseRoutes(handle func(string, handlerFunc)) {
+	handle("GET licenses", s.listLicenses)
+	handle("GET licenses/{id}", s.getLicense)
+}
+
+// AddLicense adds a license to the organization and returns it with its ID
+// filled in. ConsumedUnits is computed from the people holding the license.
+func (s *Server) AddLicense(l admin.License) *admin.License {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	license := l
+	if license.ID == "" {
+		license.ID = newID("LICENSE")
+	}
+
+	s.licenses.add(license.ID, &license)
+	return s.licenseUsage(&license)
+}
+
+// licenseUsage returns a copy of license with the consumed units counted.
+func (s *Server) licenseUsage(license *admin.License) *admin.License {
+	usage := *license
+	usage.ConsumedByUsers = len(s.people.filter(func(p *messaging.Person) bool {
+		return slices.Contains(p.Licenses, license.ID)
+	}))
+	usage.ConsumedUnits = usage.ConsumedByUsers + usage.ConsumedByWorkspaces
+	return &usage
+}
+
+func (s *Server) listLicenses(r *request) (any, error) {
+	var licenses []*admin.License
+	for _, license := range s.licenses.list() {
+		licenses = append(licenses, s.licenseUsage(license))
+	}
+	return &items[admin.License]{Items: licenses}, nil
+}
+
+func (s *Server) getLicense(r *request) (any, error) {
+	license := s.licenses.get(r.PathValue("id"))
+	if license == nil {
+		return nil, errNotFound("License not found.")
+	}
+	return s.licenseUsage(license), nil
+}
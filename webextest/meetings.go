@@ -0,0 +1,544 @@
+package webextest
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/meeting"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+func (s *Server) meetingRoutes(handle func(string, handlerFunc)) {
+	handle("GET meetings", s.listMeetings)
+	handle("POST meetings", s.createMeeting)
+	handle("POST meetings/join", s.joinMeeting)
+	handle("GET meetings/{id}", s.getMeeting)
+	handle("PUT meetings/{id}", s.updateMeeting)
+	handle("DELETE meetings/{id}", s.deleteMeeting)
+
+	handle("GET meetingInvitees", s.listInvitees)
+	handle("POST meetingInvitees", s.createInvitees)
+	handle("GET meetingInvitees/{id}", s.getInvitee)
+	handle("PUT meetingInvitees/{id}", s.updateInvitee)
+	handle("DELETE meetingInvitees/{id}", s.deleteInvitee)
+
+	handle("GET meetingRegistrants", s.listRegistrants)
+	handle("POST meetingRegistrants", s.createRegistrants)
+	handle("GET meetingRegistrants/{id}", s.getRegistrant)
+	handle("PUT meetingRegistrants/{id}", s.updateRegistrant)
+	handle("DELETE meetingRegistrants/{id}", s.deleteRegistrant)
+}
+
+// meeting returns a meeting hosted by the caller.
+func (s *Server) meeting(r *request, id string) (*meeting.Meeting, error) {
+	m := s.meetings.get(id)
+	if m == nil || m.HostUserID != r.caller.ID {
+		return nil, errNotFound("Meeting not found.")
+	}
+	return m, nil
+}
+
+func (s *Server) listMeetings(r *request) (any, error) {
+	query := r.URL.Query()
+	number := query.Get("meetingNumber")
+	state := meeting.MeetingState(query.Get("state"))
+	meetingType := meeting.MeetingType(query.Get("meetingType"))
+
+	var from, to time.Time
+	for name, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, errBadRequest("Invalid %s parameter %q.", name, value)
+			}
+			*t = parsed
+		}
+	}
+
+	meetings := s.meetings.filter(func(m *meeting.Meeting) bool {
+		switch {
+		case m.HostUserID != r.caller.ID:
+			return false
+		case number != "" && m.MeetingNumber != number:
+			return false
+		case state != "" && m.State != state:
+			return false
+		case meetingType != "" && m.MeetingType != meetingType:
+			return false
+		case !from.IsZero() && m.Start.Before(from):
+			return false
+		case !to.IsZero() && !m.Start.Before(to):
+			return false
+		}
+		return true
+	})
+
+	slices.SortStableFunc(meetings, func(a, b *meeting.Meeting) int { return a.Start.Compare(b.Start) })
+	return page(r, meetings)
+}
+
+// validateMeeting checks the fields shared by create and update.
+func validateMeeting(req *meeting.MeetingRequestBase) error {
+	switch {
+	case req.Title == "":
+		return errBadRequest("title is required.")
+	case req.Start.IsZero() || req.End.IsZero():
+		return errBadRequest("start and end are required.")
+	case !req.End.After(req.Start):
+		return errBadRequest("end must be after start.")
+	}
+	return nil
+}
+
+// applyMeeting copies the fields of a create or update request to m.
+func applyMeeting(m *meeting.Meeting, req *meeting.MeetingRequestBase) {
+	m.Title = req.Title
+	m.Agenda = req.Agenda
+	m.Password = req.Password
+	m.Start = req.Start.UTC()
+	m.End = req.End.UTC()
+	m.Timezone = req.Timezone
+	m.Recurrence = req.Recurrence
+	set(&m.EnabledAutoRecordMeeting, req.EnabledAutoRecordMeeting)
+	set(&m.AllowAnyUserToBeCoHost, req.AllowAnyUserToBeCoHost)
+	set(&m.AllowFirstUserToBeCoHost, req.AllowFirstUserToBeCoHost)
+	set(&m.AllowAuthenticatedDevices, req.AllowAuthenticateDevices)
+	set(&m.EnabledJoinBeforeHost, req.EnabledJoinBeforeHost)
+	set(&m.JoinBeforeHostMinutes, req.JoinBeforeHostMinutes)
+	set(&m.EnableConnectAudioBeforeHost, req.EnableConnectAudioBeforeHost)
+	set(&m.ExcludePassword, req.ExcludePassword)
+	set(&m.PublicMeeting, req.PublicMeeting)
+	set(&m.ReminderTime, req.ReminderTime)
+	m.UnlockedMeetingJoinSecurity = req.UnlockedMeetingJoinSecurity
+	m.SessionTypeID = req.SessionTypeID
+	set(&m.EnabledWebcastView, req.EnabledWebcastView)
+	m.PanelistPassword = req.PanelistPassword
+	set(&m.EnableAutomaticLockMinutes, req.AutomaticLockMinutes)
+	if req.MeetingOptions != nil {
+		if m.MeetingOptions == nil {
+			m.MeetingOptions = &meeting.MeetingOptions{}
+		}
+		merge(m.MeetingOptions, req.MeetingOptions)
+	}
+	if req.AudioConnectionOptions != nil {
+		if m.AudioConnectionOptions == nil {
+			m.AudioConnectionOptions = &meeting.AudioConnectionOptions{}
+		}
+		merge(m.AudioConnectionOptions, req.AudioConnectionOptions)
+	}
+	m.IntegrationTags = req.IntegrationTags
+
+	if req.ScheduledType != "" {
+		m.ScheduledType = req.ScheduledType
+	}
+	if m.Timezone == "" {
+		m.Timezone = "UTC"
+	}
+}
+
+func (s *Server) createMeeting(r *request) (any, error) {
+	var req meeting.MeetingCreateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if err := validateMeeting(&req.MeetingRequestBase); err != nil {
+		return nil, err
+	}
+
+	number := strconv.Itoa(s.nextMeetingNumber())
+	m := &meeting.Meeting{
+		ID:              strings.ReplaceAll(newUUID(), "-", ""),
+		MeetingNumber:   number,
+		MeetingType:     meeting.MeetingTypeMeetingSeries,
+		State:           meeting.MeetingStateActive,
+		ScheduledType:   "meeting",
+		HostUserID:      r.caller.ID,
+		HostDisplayName: r.caller.DisplayName,
+		HostEmail:       primaryEmail(r.caller),
+		HostKey:         number[len(number)-6:],
+		SiteURL:         SiteURL,
+		WebLink:         "https://" + SiteURL + "/j.php?MTID=" + newUUID(),
+		SipAddress:      number + "@" + SiteURL,
+		Invitees:        req.Invitees,
+		Registration:    req.Registration,
+	}
+	m.MeetingSeriesID = m.ID
+	applyMeeting(m, &req.MeetingRequestBase)
+	s.meetings.add(m.ID, m)
+
+	for _, invitee := range req.Invitees {
+		s.addInvitee(&meeting.MeetingInvitee{
+			MeetingID:   m.ID,
+			Email:       invitee.Email,
+			DisplayName: invitee.DisplayName,
+			CoHost:      invitee.CoHost,
+			Panelist:    invitee.Panelist,
+		})
+	}
+
+	r.notify(webhook.ResourceMeetings, webhook.EventCreated, meetingData(m), nil, r.caller.ID)
+	return m, nil
+}
+
+func meetingData(m *meeting.Meeting) *webhook.MeetingData {
+	return &webhook.MeetingData{
+		ID:                 m.ID,
+		MeetingSeriesID:    m.MeetingSeriesID,
+		ScheduledMeetingID: m.ScheduleMeetingID,
+		MeetingNumber:      m.MeetingNumber,
+		Title:              m.Title,
+		MeetingType:        string(m.MeetingType),
+		ScheduledType:      m.ScheduledType,
+		State:              string(m.State),
+		Timezone:           m.Timezone,
+		Start:              m.Start,
+		End:                m.End,
+		HostUserID:         m.HostUserID,
+		HostDisplayName:    m.HostDisplayName,
+		HostEmail:          m.HostEmail,
+		SiteURL:            m.SiteURL,
+		WebLink:            m.WebLink,
+	}
+}
+
+func (s *Server) getMeeting(r *request) (any, error) {
+	return s.meeting(r, r.PathValue("id"))
+}
+
+func (s *Server) updateMeeting(r *request) (any, error) {
+	m, err := s.meeting(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req meeting.MeetingUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if err := validateMeeting(&req.MeetingRequestBase); err != nil {
+		return nil, err
+	}
+
+	applyMeeting(m, &req.MeetingRequestBase)
+
+	r.notify(webhook.ResourceMeetings, webhook.EventUpdated, meetingData(m), nil, r.caller.ID)
+	return m, nil
+}
+
+func (s *Server) deleteMeeting(r *request) (any, error) {
+	m, err := s.meeting(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invitee := range s.invitees.filter(func(i *meeting.MeetingInvitee) bool { return i.MeetingID == m.ID }) {
+		s.invitees.remove(invitee.ID)
+	}
+	for _, registrant := range s.registrants.filter(func(reg *meeting.MeetingRegistrant) bool { return reg.MeetingID == m.ID }) {
+		s.registrants.remove(registrant.ID)
+	}
+	s.meetings.remove(m.ID)
+
+	r.notify(webhook.ResourceMeetings, webhook.EventDeleted, meetingData(m), nil, r.caller.ID)
+	return nil, nil
+}
+
+func (s *Server) joinMeeting(r *request) (any, error) {
+	var req struct {
+		MeetingID     string `json:"meetingId"`
+		MeetingNumber string `json:"meetingNumber"`
+		Email         string `json:"email"`
+		DisplayName   string `json:"displayName"`
+	}
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	var m *meeting.Meeting
+	for _, candidate := range s.meetings.list() {
+		if (req.MeetingID != "" && candidate.ID == req.MeetingID) ||
+			(req.MeetingNumber != "" && candidate.MeetingNumber == req.MeetingNumber) {
+			m = candidate
+			break
+		}
+	}
+	if m == nil {
+		return nil, errNotFound("Meeting not found.")
+	}
+
+	return &meeting.MeetingJoinInfo{
+		JoinLink:       m.WebLink + "&token=" + newUUID(),
+		ExpirationTime: now().Add(15 * time.Minute),
+	}, nil
+}
+
+func (s *Server) listInvitees(r *request) (any, error) {
+	query := r.URL.Query()
+	meetingID := query.Get("meetingId")
+	if meetingID == "" {
+		return nil, errBadRequest("meetingId is required.")
+	}
+
+	if _, err := s.meeting(r, meetingID); err != nil {
+		return nil, err
+	}
+
+	panelistOnly := query.Get("panelistOnly") == "true"
+	invitees := s.invitees.filter(func(i *meeting.MeetingInvitee) bool {
+		return i.MeetingID == meetingID && (!panelistOnly || i.Panelist)
+	})
+	return page(r, invitees)
+}
+
+// createInvitees creates one invitee, or several when the request has items.
+func (s *Server) createInvitees(r *request) (any, error) {
+	var req struct {
+		meeting.InviteeCreateRequest
+		Items []meeting.BulkInviteeItem `json:"items"`
+	}
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	m, err := s.meeting(r, req.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Items == nil {
+		if req.Email == "" {
+			return nil, errBadRequest("email is required.")
+		}
+		return s.addInvitee(&meeting.MeetingInvitee{
+			MeetingID:   m.ID,
+			Email:       req.Email,
+			DisplayName: req.DisplayName,
+			CoHost:      req.CoHost,
+			Panelist:    req.Panelist,
+			SendEmail:   req.SendEmail,
+		}), nil
+	}
+
+	created := &items[meeting.MeetingInvitee]{}
+	for _, item := range req.Items {
+		if item.Email == "" {
+			return nil, errBadRequest("email is required.")
+		}
+		created.Items = append(created.Items, s.addInvitee(&meeting.MeetingInvitee{
+			MeetingID:   m.ID,
+			Email:       item.Email,
+			DisplayName: item.DisplayName,
+			CoHost:      item.CoHost,
+			Panelist:    item.Panelist,
+			SendEmail:   req.SendEmail,
+		}))
+	}
+	return created, nil
+}
+
+func (s *Server) addInvitee(invitee *meeting.MeetingInvitee) *meeting.MeetingInvitee {
+	invitee.ID = newUUID()
+	if invitee.DisplayName == "" {
+		invitee.DisplayName = invitee.Email
+	}
+	s.invitees.add(invitee.ID, invitee)
+	return invitee
+}
+
+// invitee returns an invitee of a meeting hosted by the caller.
+func (s *Server) invitee(r *request) (*meeting.MeetingInvitee, error) {
+	invitee := s.invitees.get(r.PathValue("id"))
+	if invitee == nil {
+		return nil, errNotFound("Meeting invitee not found.")
+	}
+
+	if _, err := s.meeting(r, invitee.MeetingID); err != nil {
+		return nil, errNotFound("Meeting invitee not found.")
+	}
+	return invitee, nil
+}
+
+func (s *Server) getInvitee(r *request) (any, error) {
+	return s.invitee(r)
+}
+
+func (s *Server) updateInvitee(r *request) (any, error) {
+	invitee, err := s.invitee(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req meeting.InviteeUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Email != "" {
+		invitee.Email = req.Email
+	}
+	if req.DisplayName != "" {
+		invitee.DisplayName = req.DisplayName
+	}
+	set(&invitee.CoHost, req.CoHost)
+	set(&invitee.Panelist, req.Panelist)
+	set(&invitee.SendEmail, req.SendEmail)
+	return invitee, nil
+}
+
+func (s *Server) deleteInvitee(r *request) (any, error) {
+	invitee, err := s.invitee(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invitees.remove(invitee.ID)
+	return nil, nil
+}
+
+func (s *Server) listRegistrants(r *request) (any, error) {
+	query := r.URL.Query()
+	meetingID := query.Get("meetingId")
+	if meetingID == "" {
+		return nil, errBadRequest("meetingId is required.")
+	}
+
+	if _, err := s.meeting(r, meetingID); err != nil {
+		return nil, err
+	}
+
+	email := query.Get("email")
+	registrants := s.registrants.filter(func(reg *meeting.MeetingRegistrant) bool {
+		return reg.MeetingID == meetingID && (email == "" || strings.EqualFold(reg.Email, email))
+	})
+	return page(r, registrants)
+}
+
+// createRegistrants registers one person, or several when the request has
+// items.
+func (s *Server) createRegistrants(r *request) (any, error) {
+	var req struct {
+		meeting.RegistrantCreateRequest
+		Items []meeting.BulkRegistrantItem `json:"items"`
+	}
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	m, err := s.meeting(r, req.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Items == nil {
+		registrant := &meeting.MeetingRegistrant{
+			FirstName:           req.FirstName,
+			LastName:            req.LastName,
+			Email:               req.Email,
+			JobTitle:            req.JobTitle,
+			CompanyName:         req.CompanyName,
+			Address1:            req.Address1,
+			Address2:            req.Address2,
+			City:                req.City,
+			State:               req.State,
+			ZipCode:             req.ZipCode,
+			CountryRegion:       req.CountryRegion,
+			WorkPhone:           req.WorkPhone,
+			Fax:                 req.Fax,
+			SendEmail:           req.SendEmail,
+			CustomizedQuestions: req.CustomizedQuestions,
+		}
+		return s.addRegistrant(m, registrant)
+	}
+
+	created := &items[meeting.MeetingRegistrant]{}
+	for _, item := range req.Items {
+		registrant, err := s.addRegistrant(m, &meeting.MeetingRegistrant{
+			FirstName:   item.FirstName,
+			LastName:    item.LastName,
+			Email:       item.Email,
+			JobTitle:    item.JobTitle,
+			CompanyName: item.CompanyName,
+			SendEmail:   req.SendEmail,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created.Items = append(created.Items, registrant)
+	}
+	return created, nil
+}
+
+func (s *Server) addRegistrant(m *meeting.Meeting, registrant *meeting.MeetingRegistrant) (*meeting.MeetingRegistrant, error) {
+	if registrant.FirstName == "" || registrant.LastName == "" || registrant.Email == "" {
+		return nil, errBadRequest("firstName, lastName and email are required.")
+	}
+
+	for _, existing := range s.registrants.list() {
+		if existing.MeetingID == m.ID && strings.EqualFold(existing.Email, registrant.Email) {
+			return nil, errConflict("%s is already registered.", registrant.Email)
+		}
+	}
+
+	registrant.ID = newUUID()
+	registrant.MeetingID = m.ID
+	registrant.RegisterTime = now()
+	registrant.Status = meeting.RegistrantStatusPending
+	if m.Registration == nil || m.Registration.AutoAcceptRequest {
+		registrant.Status = meeting.RegistrantStatusApproved
+	}
+
+	s.registrants.add(registrant.ID, registrant)
+	return registrant, nil
+}
+
+// registrant returns a registrant of a meeting hosted by the caller.
+func (s *Server) registrant(r *request) (*meeting.MeetingRegistrant, error) {
+	registrant := s.registrants.get(r.PathValue("id"))
+	if registrant == nil {
+		return nil, errNotFound("Meeting registrant not found.")
+	}
+
+	if _, err := s.meeting(r, registrant.MeetingID); err != nil {
+		return nil, errNotFound("Meeting registrant not found.")
+	}
+	return registrant, nil
+}
+
+func (s *Server) getRegistrant(r *request) (any, error) {
+	return s.registrant(r)
+}
+
+func (s *Server) updateRegistrant(r *request) (any, error) {
+	registrant, err := s.registrant(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req meeting.RegistrantUpdateStatusRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	switch req.Status {
+	case "approved", "rejected", "pending":
+	default:
+		return nil, errBadRequest("Invalid status %q.", req.Status)
+	}
+
+	registrant.Status = req.Status
+	return registrant, nil
+}
+
+func (s *Server) deleteRegistrant(r *request) (any, error) {
+	registrant, err := s.registrant(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.registrants.remove(registrant.ID)
+	return nil, nil
+}
@@ -0,0 +1,401 @@
+package webextest
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+// maxUploadMemory is the part of a multipart upload kept in memory before
+// spilling to disk while parsing.
+const maxUploadMemory = 32 << 20
+
+func (s *Server) messageRoutes(handle func(string, handlerFunc)) {
+	handle("GET messages", s.listMessages)
+	handle("GET messages/direct", s.listDirectMessages)
+	handle("POST messages", s.createMessage)
+	handle("GET messages/{id}", s.getMessage)
+	handle("PUT messages/{id}", s.updateMessage)
+	handle("DELETE messages/{id}", s.deleteMessage)
+
+	handle("GET contents/{id}", s.getContent)
+}
+
+// content is a file uploaded with a message.
+type content struct {
+	name        string
+	contentType string
+	data        []byte
+}
+
+// serve writes the file with the headers Webex sends for downloads.
+func (c *content) serve(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", c.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": c.name}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(c.data)))
+	w.Write(c.data)
+}
+
+func (s *Server) getContent(r *request) (any, error) {
+	file := s.contents.get(r.PathValue("id"))
+	if file == nil {
+		return nil, errNotFound("File not found.")
+	}
+	return file, nil
+}
+
+// message returns a message in a room the caller is a member of.
+func (s *Server) message(r *request, id string) (*messaging.Message, error) {
+	message := s.messages.get(id)
+	if message == nil || s.membership(message.RoomID, r.caller.ID) == nil {
+		return nil, errNotFound("Could not find a message with provided ID.")
+	}
+	return message, nil
+}
+
+// directRoom returns the 1:1 room between two people, or nil.
+func (s *Server) directRoom(a, b string) *messaging.Room {
+	for _, room := range s.rooms.list() {
+		if room.Type == messaging.RoomTypeDirect && s.membership(room.ID, a) != nil && s.membership(room.ID, b) != nil {
+			return room
+		}
+	}
+	return nil
+}
+
+// sortNewestFirst orders messages like the API: most recent first.
+func sortNewestFirst(messages []*messaging.Message) []*messaging.Message {
+	slices.Reverse(messages)
+	return messages
+}
+
+func (s *Server) listMessages(r *request) (any, error) {
+	query := r.URL.Query()
+	roomID := query.Get("roomId")
+	if roomID == "" {
+		return nil, errBadRequest("roomId is required.")
+	}
+
+	if _, err := s.room(r, roomID); err != nil {
+		return nil, err
+	}
+
+	parentID := query.Get("parentId")
+	mentioned := query.Get("mentionedPeople")
+	if mentioned == "me" {
+		mentioned = r.caller.ID
+	}
+
+	var before time.Time
+	if value := query.Get("before"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errBadRequest("Invalid before parameter %q.", value)
+		}
+		before = t
+	}
+
+	if id := query.Get("beforeMessage"); id != "" {
+		message, err := s.message(r, id)
+		if err != nil {
+			return nil, err
+		}
+		before = message.Created
+	}
+
+	messages := s.messages.filter(func(m *messaging.Message) bool {
+		switch {
+		case m.RoomID != roomID:
+			return false
+		case parentID != "" && m.ParentID != parentID:
+			return false
+		case mentioned != "" && !slices.Contains(m.MentionedPeople, mentioned):
+			return false
+		case !before.IsZero() && !m.Created.Before(before):
+			return false
+		}
+		return true
+	})
+	return page(r, sortNewestFirst(messages))
+}
+
+func (s *Server) listDirectMessages(r *request) (any, error) {
+	query := r.URL.Query()
+	personID := query.Get("personId")
+	personEmail := query.Get("personEmail")
+	if personID == "" && personEmail == "" {
+		return nil, errBadRequest("personId or personEmail is required.")
+	}
+
+	var person *messaging.Person
+	if personID != "" {
+		person = s.people.get(personID)
+	} else {
+		person = s.personByEmail(personEmail)
+	}
+	if person == nil {
+		return nil, errNotFound("Person not found.")
+	}
+
+	room := s.directRoom(r.caller.ID, person.ID)
+	if room == nil {
+		return &items[messaging.Message]{Items: []*messaging.Message{}}, nil
+	}
+
+	parentID := query.Get("parentId")
+	messages := s.messages.filter(func(m *messaging.Message) bool {
+		return m.RoomID == room.ID && (parentID == "" || m.ParentID == parentID)
+	})
+	return &items[messaging.Message]{Items: sortNewestFirst(messages)}, nil
+}
+
+func (s *Server) createMessage(r *request) (any, error) {
+	var req messaging.MessageCreateRequest
+	var uploads []*content
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		var err error
+		if req, uploads, err = parseMultipartMessage(r); err != nil {
+			return nil, err
+		}
+	} else if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Text == "" && req.Markdown == "" && len(req.Files) == 0 && len(uploads) == 0 && len(req.Attachments) == 0 {
+		return nil, errBadRequest("text, markdown, files or attachments is required.")
+	}
+
+	room, err := s.messageRoom(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != "" {
+		parent := s.messages.get(req.ParentID)
+		if parent == nil || parent.RoomID != room.ID {
+			return nil, errBadRequest("Parent message not found in the room.")
+		}
+		if parent.ParentID != "" {
+			return nil, errBadRequest("Replies cannot be threaded.")
+		}
+	}
+
+	if room.IsAnnouncementOnly && !s.membership(room.ID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only moderators can post in an announcement room.")
+	}
+
+	message := &messaging.Message{
+		ID:            newID("MESSAGE"),
+		RoomID:        room.ID,
+		RoomType:      room.Type,
+		ParentID:      req.ParentID,
+		ToPersonID:    req.ToPersonID,
+		ToPersonEmail: req.ToPersonEmail,
+		Text:          req.Text,
+		Markdown:      req.Markdown,
+		Files:         req.Files,
+		PersonID:      r.caller.ID,
+		PersonEmail:   primaryEmail(r.caller),
+		Attachments:   req.Attachments,
+		Created:       now(),
+	}
+	if message.Text == "" {
+		message.Text = req.Markdown
+	}
+	message.MentionedPeople, message.MentionedGroups = s.mentions(req.Markdown)
+
+	for _, upload := range uploads {
+		id := newID("CONTENT")
+		s.contents.add(id, upload)
+		message.Files = append(message.Files, r.s.URL+apiPrefix+"contents/"+id)
+	}
+
+	s.messages.add(message.ID, message)
+	room.LastActivity = message.Created
+
+	r.notify(webhook.ResourceMessages, webhook.EventCreated, messageData(message), messageFilter(message),
+		s.roomMembers(room.ID)...)
+	return message, nil
+}
+
+// messageRoom returns the room a new message is posted to, creating the
+// direct room for messages sent to a person.
+func (s *Server) messageRoom(r *request, req *messaging.MessageCreateRequest) (*messaging.Room, error) {
+	if req.RoomID != "" {
+		return s.room(r, req.RoomID)
+	}
+
+	if req.ToPersonID == "" && req.ToPersonEmail == "" {
+		return nil, errBadRequest("roomId, toPersonId or toPersonEmail is required.")
+	}
+
+	person, err := s.resolvePerson(req.ToPersonID, req.ToPersonEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if person.ID == r.caller.ID {
+		return nil, errBadRequest("Messages cannot be sent to yourself.")
+	}
+
+	if room := s.directRoom(r.caller.ID, person.ID); room != nil {
+		return room, nil
+	}
+
+	room := s.addRoom(r, &messaging.Room{Title: person.DisplayName, Type: messaging.RoomTypeDirect})
+	s.addMembership(r, room, r.caller, false)
+	s.addMembership(r, room, person, false)
+	return room, nil
+}
+
+func parseMultipartMessage(r *request) (messaging.MessageCreateRequest, []*content, error) {
+	var req messaging.MessageCreateRequest
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		return req, nil, errBadRequest("Invalid multipart body: %v", err)
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	req.RoomID = r.FormValue("roomId")
+	req.ToPersonID = r.FormValue("toPersonId")
+	req.ToPersonEmail = r.FormValue("toPersonEmail")
+	req.Text = r.FormValue("text")
+	req.Markdown = r.FormValue("markdown")
+	req.ParentID = r.FormValue("parentId")
+
+	var uploads []*content
+	for _, header := range r.MultipartForm.File["files"] {
+		file, err := header.Open()
+		if err != nil {
+			return req, nil, err
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return req, nil, err
+		}
+
+		contentType := header.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		uploads = append(uploads, &content{name: header.Filename, contentType: contentType, data: data})
+	}
+
+	if len(uploads) > 1 {
+		return req, nil, errBadRequest("Only one file can be attached to a message.")
+	}
+	return req, uploads, nil
+}
+
+var mentionPattern = regexp.MustCompile(`<@(personId|personEmail):([^|>]+)(?:\|[^>]*)?>|<@all>`)
+
+// mentions returns the people and groups mentioned in markdown.
+func (s *Server) mentions(markdown string) (people, groups []string) {
+	for _, match := range mentionPattern.FindAllStringSubmatch(markdown, -1) {
+		switch match[1] {
+		case "":
+			groups = append(groups, "all")
+		case "personId":
+			people = append(people, match[2])
+		case "personEmail":
+			if person := s.personByEmail(match[2]); person != nil {
+				people = append(people, person.ID)
+			}
+		}
+	}
+	return people, groups
+}
+
+func messageData(m *messaging.Message) *webhook.MessageData {
+	return &webhook.MessageData{
+		ID:              m.ID,
+		RoomID:          m.RoomID,
+		RoomType:        string(m.RoomType),
+		ParentID:        m.ParentID,
+		PersonID:        m.PersonID,
+		PersonEmail:     m.PersonEmail,
+		MentionedPeople: m.MentionedPeople,
+		MentionedGroups: m.MentionedGroups,
+		Files:           m.Files,
+		Created:         m.Created,
+		Updated:         m.Updated,
+	}
+}
+
+func messageFilter(m *messaging.Message) url.Values {
+	return url.Values{
+		"roomId":          {m.RoomID},
+		"roomType":        {string(m.RoomType)},
+		"personId":        {m.PersonID},
+		"personEmail":     {m.PersonEmail},
+		"mentionedPeople": m.MentionedPeople,
+		"hasFiles":        {strconv.FormatBool(len(m.Files) > 0)},
+	}
+}
+
+func (s *Server) getMessage(r *request) (any, error) {
+	return s.message(r, r.PathValue("id"))
+}
+
+func (s *Server) updateMessage(r *request) (any, error) {
+	message, err := s.message(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.MessageUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.RoomID != "" && req.RoomID != message.RoomID {
+		return nil, errBadRequest("roomId does not match the message.")
+	}
+
+	if message.PersonID != r.caller.ID {
+		return nil, errForbidden("Only the sender can edit a message.")
+	}
+
+	if req.Text == "" && req.Markdown == "" {
+		return nil, errBadRequest("text or markdown is required.")
+	}
+
+	message.Text = req.Text
+	if message.Text == "" {
+		message.Text = req.Markdown
+	}
+	message.Markdown = req.Markdown
+	message.MentionedPeople, message.MentionedGroups = s.mentions(req.Markdown)
+	message.Updated = now()
+
+	r.notify(webhook.ResourceMessages, webhook.EventUpdated, messageData(message), messageFilter(message),
+		s.roomMembers(message.RoomID)...)
+	return message, nil
+}
+
+func (s *Server) deleteMessage(r *request) (any, error) {
+	message, err := s.message(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	if message.PersonID != r.caller.ID && !s.membership(message.RoomID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only the sender or a moderator can delete a message.")
+	}
+
+	s.messages.remove(message.ID)
+
+	r.notify(webhook.ResourceMessages, webhook.EventDeleted, messageData(message), messageFilter(message),
+		s.roomMembers(message.RoomID)...)
+	return nil, nil
+}
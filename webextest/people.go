@@ -0,0 +1,180 @@
+package webextest
+
+import (
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+func (s *Server) peopleRoutes(handle func(string, handlerFunc)) {
+	handle("GET people", s.listPeople)
+	handle("POST people", s.createPerson)
+	handle("GET people/me", func(r *request) (any, error) { return r.caller, nil })
+	handle("GET people/{id}", s.getPerson)
+	handle("PUT people/{id}", s.updatePerson)
+	handle("DELETE people/{id}", s.deletePerson)
+}
+
+func (s *Server) listPeople(r *request) (any, error) {
+	query := r.URL.Query()
+	email := query.Get("email")
+	name := strings.ToLower(query.Get("displayName"))
+	orgID := query.Get("orgId")
+
+	var ids map[string]bool
+	if id := query.Get("id"); id != "" {
+		ids = make(map[string]bool)
+		for _, id := range strings.Split(id, ",") {
+			ids[id] = true
+		}
+	}
+
+	people := s.people.filter(func(p *messaging.Person) bool {
+		switch {
+		case email != "" && !hasEmail(p, email):
+			return false
+		case name != "" && !strings.HasPrefix(strings.ToLower(p.DisplayName), name):
+			return false
+		case orgID != "" && p.OrgID != orgID:
+			return false
+		case ids != nil && !ids[p.ID]:
+			return false
+		}
+		return true
+	})
+	return page(r, people)
+}
+
+func (s *Server) person(id string) (*messaging.Person, error) {
+	if id == "me" {
+		return s.people.get(s.me), nil
+	}
+
+	person := s.people.get(id)
+	if person == nil {
+		return nil, errNotFound("Person not found.")
+	}
+	return person, nil
+}
+
+func (s *Server) getPerson(r *request) (any, error) {
+	return s.person(r.PathValue("id"))
+}
+
+func (s *Server) createPerson(r *request) (any, error) {
+	var req messaging.PersonCreateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if len(req.Emails) == 0 {
+		return nil, errBadRequest("emails is required.")
+	}
+
+	for _, email := range req.Emails {
+		if s.personByEmail(email) != nil {
+			return nil, errConflict("User with email %s already exists.", email)
+		}
+	}
+
+	return s.addPerson(messaging.Person{
+		Emails:       req.Emails,
+		DisplayName:  req.DisplayName,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Avatar:       req.Avatar,
+		OrgID:        req.OrgID,
+		Roles:        req.Roles,
+		Licenses:     req.Licenses,
+		Department:   req.Department,
+		Manager:      req.Manager,
+		ManagerID:    req.ManagerID,
+		Title:        req.Title,
+		Addresses:    req.Addresses,
+		PhoneNumbers: req.PhoneNumbers,
+		LoginEnabled: true,
+	}), nil
+}
+
+func (s *Server) updatePerson(r *request) (any, error) {
+	person, err := s.person(r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.PersonUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if len(req.Emails) > 0 {
+		person.Emails = req.Emails
+	}
+	if req.DisplayName != "" {
+		person.DisplayName = req.DisplayName
+	}
+	set(&person.FirstName, req.FirstName)
+	set(&person.LastName, req.LastName)
+	set(&person.Avatar, req.Avatar)
+	if req.Roles != nil {
+		person.Roles = req.Roles
+	}
+	if req.Licenses != nil {
+		person.Licenses = req.Licenses
+	}
+	set(&person.Department, req.Department)
+	set(&person.Manager, req.Manager)
+	set(&person.ManagerID, req.ManagerID)
+	set(&person.Title, req.Title)
+	if req.Addresses != nil {
+		person.Addresses = req.Addresses
+	}
+	if req.PhoneNumbers != nil {
+		person.PhoneNumbers = req.PhoneNumbers
+	}
+	set(&person.LoginEnabled, req.LoginEnabled)
+	person.LastModified = now()
+
+	return person, nil
+}
+
+func (s *Server) deletePerson(r *request) (any, error) {
+	person, err := s.person(r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	s.people.remove(person.ID)
+	for token, id := range s.tokens {
+		if id == person.ID {
+			delete(s.tokens, token)
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) personByEmail(email string) *messaging.Person {
+	for _, p := range s.people.list() {
+		if hasEmail(p, email) {
+			return p
+		}
+	}
+	return nil
+}
+
+// resolvePerson finds a person by ID or email. Unknown email addresses are
+// invited, which adds them to the organization.
+func (s *Server) resolvePerson(id, email string) (*messaging.Person, error) {
+	if id != "" {
+		return s.person(id)
+	}
+
+	if email == "" {
+		return nil, errBadRequest("personId or personEmail is required.")
+	}
+
+	if person := s.personByEmail(email); person != nil {
+		return person, nil
+	}
+	return s.addPerson(messaging.Person{Emails: []string{email}, InvitePending: true}), nil
+}
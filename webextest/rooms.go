@@ -0,0 +1,582 @@
+package webextest
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+func (s *Server) roomRoutes(handle func(string, handlerFunc)) {
+	handle("GET rooms", s.listRooms)
+	handle("POST rooms", s.createRoom)
+	handle("GET rooms/{id}", s.getRoom)
+	handle("GET rooms/{id}/meetingInfo", s.getRoomMeetingInfo)
+	handle("PUT rooms/{id}", s.updateRoom)
+	handle("DELETE rooms/{id}", s.deleteRoom)
+
+	handle("GET memberships", s.listMemberships)
+	handle("POST memberships", s.createMembership)
+	handle("GET memberships/{id}", s.getMembership)
+	handle("PUT memberships/{id}", s.updateMembership)
+	handle("DELETE memberships/{id}", s.deleteMembership)
+
+	handle("GET teams", s.listTeams)
+	handle("POST teams", s.createTeam)
+	handle("GET teams/{id}", s.getTeam)
+	handle("PUT teams/{id}", s.updateTeam)
+	handle("DELETE teams/{id}", s.deleteTeam)
+
+	handle("GET team/memberships", s.listTeamMemberships)
+	handle("POST team/memberships", s.createTeamMembership)
+	handle("GET team/memberships/{id}", s.getTeamMembership)
+	handle("PUT team/memberships/{id}", s.updateTeamMembership)
+	handle("DELETE team/memberships/{id}", s.deleteTeamMembership)
+}
+
+// room returns a room the caller is a member of.
+func (s *Server) room(r *request, id string) (*messaging.Room, error) {
+	room := s.rooms.get(id)
+	if room == nil || s.membership(id, r.caller.ID) == nil {
+		return nil, errNotFound("Could not find a room with provided ID.")
+	}
+	return room, nil
+}
+
+func (s *Server) membership(roomID, personID string) *messaging.Membership {
+	for _, m := range s.memberships.list() {
+		if m.RoomID == roomID && m.PersonID == personID {
+			return m
+		}
+	}
+	return nil
+}
+
+// roomMembers returns the IDs of the people in a room.
+func (s *Server) roomMembers(roomID string) []string {
+	var ids []string
+	for _, m := range s.memberships.list() {
+		if m.RoomID == roomID {
+			ids = append(ids, m.PersonID)
+		}
+	}
+	return ids
+}
+
+func (s *Server) listRooms(r *request) (any, error) {
+	query := r.URL.Query()
+	teamID := query.Get("teamId")
+	roomType := messaging.RoomType(query.Get("type"))
+
+	rooms := s.rooms.filter(func(room *messaging.Room) bool {
+		switch {
+		case s.membership(room.ID, r.caller.ID) == nil:
+			return false
+		case teamID != "" && room.TeamID != teamID:
+			return false
+		case roomType != "" && room.Type != roomType:
+			return false
+		}
+		return true
+	})
+
+	switch query.Get("sortBy") {
+	case "", "id":
+	case "lastactivity":
+		sort.SliceStable(rooms, func(i, j int) bool { return rooms[i].LastActivity.After(rooms[j].LastActivity) })
+	case "created":
+		sort.SliceStable(rooms, func(i, j int) bool { return rooms[i].Created.After(rooms[j].Created) })
+	default:
+		return nil, errBadRequest("Invalid sortBy parameter.")
+	}
+
+	return page(r, rooms)
+}
+
+func (s *Server) createRoom(r *request) (any, error) {
+	var req messaging.RoomCreateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Title == "" {
+		return nil, errBadRequest("title is required.")
+	}
+
+	if req.TeamID != "" && s.teamMembership(req.TeamID, r.caller.ID) == nil {
+		return nil, errNotFound("Could not find a team with provided ID.")
+	}
+
+	room := s.addRoom(r, &messaging.Room{
+		Title:              req.Title,
+		Type:               messaging.RoomTypeGroup,
+		TeamID:             req.TeamID,
+		ClassificationID:   req.ClassificationID,
+		IsLocked:           req.IsLocked,
+		IsPublic:           req.IsPublic,
+		Description:        req.Description,
+		IsAnnouncementOnly: req.IsAnnouncementOnly,
+	})
+	s.addMembership(r, room, r.caller, req.IsLocked)
+	return room, nil
+}
+
+// addRoom stores a new room created by the caller.
+func (s *Server) addRoom(r *request, room *messaging.Room) *messaging.Room {
+	room.ID = newID("ROOM")
+	room.CreatorID = r.caller.ID
+	room.OwnerID = r.caller.OrgID
+	room.Created = now()
+	room.LastActivity = room.Created
+	room.SipAddress = fmt.Sprintf("%d@%s", s.nextMeetingNumber(), SiteURL)
+	s.rooms.add(room.ID, room)
+
+	r.notify(webhook.ResourceRooms, webhook.EventCreated, roomData(room),
+		url.Values{"type": {string(room.Type)}, "isLocked": {strconv.FormatBool(room.IsLocked)}},
+		r.caller.ID)
+	return room
+}
+
+func roomData(room *messaging.Room) *webhook.RoomData {
+	return &webhook.RoomData{
+		ID:           room.ID,
+		Title:        room.Title,
+		Type:         string(room.Type),
+		IsLocked:     room.IsLocked,
+		IsPublic:     room.IsPublic,
+		TeamID:       room.TeamID,
+		CreatorID:    room.CreatorID,
+		OwnerID:      room.OwnerID,
+		LastActivity: room.LastActivity,
+		Created:      room.Created,
+	}
+}
+
+func (s *Server) getRoom(r *request) (any, error) {
+	return s.room(r, r.PathValue("id"))
+}
+
+func (s *Server) getRoomMeetingInfo(r *request) (any, error) {
+	room, err := s.room(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	number, _, _ := strings.Cut(room.SipAddress, "@")
+	return &messaging.RoomMeetingInfo{
+		RoomID:           room.ID,
+		MeetingLink:      "https://" + SiteURL + "/m/" + number,
+		SipAddress:       room.SipAddress,
+		MeetingNumber:    number,
+		CallInTollNumber: "+1-415-555-0100",
+	}, nil
+}
+
+func (s *Server) updateRoom(r *request) (any, error) {
+	room, err := s.room(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.RoomUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Title == "" {
+		return nil, errBadRequest("title is required.")
+	}
+
+	room.Title = req.Title
+	room.TeamID = req.TeamID
+	room.ClassificationID = req.ClassificationID
+	set(&room.IsLocked, req.IsLocked)
+	set(&room.IsPublic, req.IsPublic)
+	room.Description = req.Description
+	set(&room.IsAnnouncementOnly, req.IsAnnouncementOnly)
+	set(&room.IsReadOnly, req.IsReadOnly)
+
+	r.notify(webhook.ResourceRooms, webhook.EventUpdated, roomData(room),
+		url.Values{"type": {string(room.Type)}, "isLocked": {strconv.FormatBool(room.IsLocked)}},
+		s.roomMembers(room.ID)...)
+	return room, nil
+}
+
+func (s *Server) deleteRoom(r *request) (any, error) {
+	room, err := s.room(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	if room.IsLocked && !s.membership(room.ID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only moderators can delete a locked room.")
+	}
+
+	for _, m := range s.memberships.filter(func(m *messaging.Membership) bool { return m.RoomID == room.ID }) {
+		s.memberships.remove(m.ID)
+	}
+	for _, m := range s.messages.filter(func(m *messaging.Message) bool { return m.RoomID == room.ID }) {
+		s.messages.remove(m.ID)
+	}
+	s.rooms.remove(room.ID)
+	return nil, nil
+}
+
+func (s *Server) listMemberships(r *request) (any, error) {
+	query := r.URL.Query()
+	roomID := query.Get("roomId")
+	personID := query.Get("personId")
+	personEmail := query.Get("personEmail")
+
+	if roomID != "" {
+		if _, err := s.room(r, roomID); err != nil {
+			return nil, err
+		}
+	}
+
+	memberships := s.memberships.filter(func(m *messaging.Membership) bool {
+		switch {
+		case roomID == "" && m.PersonID != r.caller.ID:
+			// Without a room only the caller's memberships are listed.
+			return false
+		case roomID != "" && m.RoomID != roomID:
+			return false
+		case personID != "" && m.PersonID != personID:
+			return false
+		case personEmail != "" && m.PersonEmail != personEmail:
+			return false
+		}
+		return true
+	})
+	return page(r, memberships)
+}
+
+func (s *Server) createMembership(r *request) (any, error) {
+	var req messaging.MembershipCreateOptions
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	room, err := s.room(r, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Type == messaging.RoomTypeDirect {
+		return nil, errForbidden("People cannot be added to a direct room.")
+	}
+
+	if room.IsLocked && !s.membership(room.ID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only moderators can add people to a locked room.")
+	}
+
+	person, err := s.resolvePerson(req.PersonID, req.PersonEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.membership(room.ID, person.ID) != nil {
+		return nil, errConflict("%s is already a participant.", person.DisplayName)
+	}
+
+	return s.addMembership(r, room, person, req.IsModerator), nil
+}
+
+func (s *Server) addMembership(r *request, room *messaging.Room, person *messaging.Person, moderator bool) *messaging.Membership {
+	m := &messaging.Membership{
+		ID:                newID("MEMBERSHIP"),
+		RoomID:            room.ID,
+		PersonID:          person.ID,
+		PersonEmail:       primaryEmail(person),
+		PersonDisplayName: person.DisplayName,
+		PersonOrgID:       person.OrgID,
+		IsModerator:       moderator,
+		Created:           now(),
+	}
+	s.memberships.add(m.ID, m)
+
+	r.notify(webhook.ResourceMemberships, webhook.EventCreated, membershipData(room, m), membershipFilter(m),
+		s.roomMembers(room.ID)...)
+	return m
+}
+
+func membershipData(room *messaging.Room, m *messaging.Membership) *webhook.MembershipData {
+	return &webhook.MembershipData{
+		ID:                m.ID,
+		RoomID:            m.RoomID,
+		RoomType:          string(room.Type),
+		PersonID:          m.PersonID,
+		PersonEmail:       m.PersonEmail,
+		PersonDisplayName: m.PersonDisplayName,
+		PersonOrgID:       m.PersonOrgID,
+		IsModerator:       m.IsModerator,
+		IsMonitor:         m.IsMonitor,
+		IsRoomHidden:      m.IsRoomHidden,
+		Created:           m.Created,
+	}
+}
+
+func membershipFilter(m *messaging.Membership) url.Values {
+	return url.Values{
+		"roomId":      {m.RoomID},
+		"personId":    {m.PersonID},
+		"personEmail": {m.PersonEmail},
+		"isModerator": {strconv.FormatBool(m.IsModerator)},
+	}
+}
+
+// visibleMembership returns a membership of a room the caller is in.
+func (s *Server) visibleMembership(r *request) (*messaging.Membership, *messaging.Room, error) {
+	m := s.memberships.get(r.PathValue("id"))
+	if m == nil {
+		return nil, nil, errNotFound("Membership not found.")
+	}
+
+	room, err := s.room(r, m.RoomID)
+	if err != nil {
+		return nil, nil, errNotFound("Membership not found.")
+	}
+	return m, room, nil
+}
+
+func (s *Server) getMembership(r *request) (any, error) {
+	m, _, err := s.visibleMembership(r)
+	return m, err
+}
+
+func (s *Server) updateMembership(r *request) (any, error) {
+	m, room, err := s.visibleMembership(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.MembershipUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if m.IsModerator != req.IsModerator.Or(m.IsModerator) && room.IsLocked && !s.membership(room.ID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only moderators can change moderators.")
+	}
+
+	set(&m.IsModerator, req.IsModerator)
+	set(&m.IsRoomHidden, req.IsRoomHidden)
+
+	r.notify(webhook.ResourceMemberships, webhook.EventUpdated, membershipData(room, m), membershipFilter(m),
+		s.roomMembers(room.ID)...)
+	return m, nil
+}
+
+func (s *Server) deleteMembership(r *request) (any, error) {
+	m, room, err := s.visibleMembership(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.PersonID != r.caller.ID && room.IsLocked && !s.membership(room.ID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only moderators can remove people from a locked room.")
+	}
+
+	audience := s.roomMembers(room.ID)
+	s.memberships.remove(m.ID)
+
+	r.notify(webhook.ResourceMemberships, webhook.EventDeleted, membershipData(room, m), membershipFilter(m),
+		audience...)
+	return nil, nil
+}
+
+func (s *Server) teamMembership(teamID, personID string) *messaging.TeamMembership {
+	for _, m := range s.teamMemberships.list() {
+		if m.TeamID == teamID && m.PersonID == personID {
+			return m
+		}
+	}
+	return nil
+}
+
+// team returns a team the caller is a member of.
+func (s *Server) team(r *request, id string) (*messaging.Team, error) {
+	team := s.teams.get(id)
+	if team == nil || s.teamMembership(id, r.caller.ID) == nil {
+		return nil, errNotFound("Could not find a team with provided ID.")
+	}
+	return team, nil
+}
+
+func (s *Server) listTeams(r *request) (any, error) {
+	teams := s.teams.filter(func(t *messaging.Team) bool {
+		return s.teamMembership(t.ID, r.caller.ID) != nil
+	})
+	return page(r, teams)
+}
+
+// createTeam creates a team and its general room, like Webex does.
+func (s *Server) createTeam(r *request) (any, error) {
+	var req messaging.TeamCreateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, errBadRequest("name is required.")
+	}
+
+	team := &messaging.Team{
+		ID:          newID("TEAM"),
+		Name:        req.Name,
+		Description: req.Description,
+		Created:     now(),
+		CreatorID:   r.caller.ID,
+	}
+	s.teams.add(team.ID, team)
+	s.addTeamMembership(team, r.caller, true)
+
+	room := s.addRoom(r, &messaging.Room{Title: team.Name, Type: messaging.RoomTypeGroup, TeamID: team.ID})
+	s.addMembership(r, room, r.caller, false)
+
+	return team, nil
+}
+
+func (s *Server) getTeam(r *request) (any, error) {
+	return s.team(r, r.PathValue("id"))
+}
+
+func (s *Server) updateTeam(r *request) (any, error) {
+	team, err := s.team(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.TeamUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		team.Name = req.Name
+	}
+	if req.Description != "" {
+		team.Description = req.Description
+	}
+	return team, nil
+}
+
+func (s *Server) deleteTeam(r *request) (any, error) {
+	team, err := s.team(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.teamMembership(team.ID, r.caller.ID).IsModerator {
+		return nil, errForbidden("Only moderators can delete a team.")
+	}
+
+	for _, m := range s.teamMemberships.filter(func(m *messaging.TeamMembership) bool { return m.TeamID == team.ID }) {
+		s.teamMemberships.remove(m.ID)
+	}
+	for _, room := range s.rooms.filter(func(room *messaging.Room) bool { return room.TeamID == team.ID }) {
+		room.TeamID = ""
+	}
+	s.teams.remove(team.ID)
+	return nil, nil
+}
+
+func (s *Server) listTeamMemberships(r *request) (any, error) {
+	teamID := r.URL.Query().Get("teamId")
+	if teamID == "" {
+		return nil, errBadRequest("teamId is required.")
+	}
+
+	if _, err := s.team(r, teamID); err != nil {
+		return nil, err
+	}
+
+	memberships := s.teamMemberships.filter(func(m *messaging.TeamMembership) bool { return m.TeamID == teamID })
+	return page(r, memberships)
+}
+
+func (s *Server) createTeamMembership(r *request) (any, error) {
+	var req messaging.TeamMembershipCreateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	team, err := s.team(r, req.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	person, err := s.resolvePerson(req.PersonID, req.PersonEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.teamMembership(team.ID, person.ID) != nil {
+		return nil, errConflict("%s is already a team member.", person.DisplayName)
+	}
+
+	return s.addTeamMembership(team, person, req.IsModerator), nil
+}
+
+func (s *Server) addTeamMembership(team *messaging.Team, person *messaging.Person, moderator bool) *messaging.TeamMembership {
+	m := &messaging.TeamMembership{
+		ID:                newID("TEAM_MEMBERSHIP"),
+		TeamID:            team.ID,
+		PersonID:          person.ID,
+		PersonEmail:       primaryEmail(person),
+		PersonDisplayName: person.DisplayName,
+		PersonOrgID:       person.OrgID,
+		IsModerator:       moderator,
+		Created:           now(),
+	}
+	s.teamMemberships.add(m.ID, m)
+	return m
+}
+
+// visibleTeamMembership returns a membership of a team the caller is in.
+func (s *Server) visibleTeamMembership(r *request) (*messaging.TeamMembership, error) {
+	m := s.teamMemberships.get(r.PathValue("id"))
+	if m == nil || s.teamMembership(m.TeamID, r.caller.ID) == nil {
+		return nil, errNotFound("Team membership not found.")
+	}
+	return m, nil
+}
+
+func (s *Server) getTeamMembership(r *request) (any, error) {
+	return s.visibleTeamMembership(r)
+}
+
+func (s *Server) updateTeamMembership(r *request) (any, error) {
+	m, err := s.visibleTeamMembership(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.TeamMembershipUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	set(&m.IsModerator, req.IsModerator)
+	return m, nil
+}
+
+func (s *Server) deleteTeamMembership(r *request) (any, error) {
+	m, err := s.visibleTeamMembership(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.teamMemberships.remove(m.ID)
+	return nil, nil
+}
+
+// nextMeetingNumber returns a unique ten digit meeting number.
+func (s *Server) nextMeetingNumber() int {
+	s.meetingNumber++
+	return s.meetingNumber
+}
@@ -0,0 +1,572 @@
+package webextest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/admin"
+	"github.com/rainuxhe/webexgosdk/meeting"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+const (
+	// DefaultAccessToken authenticates requests as the server's own user.
+	DefaultAccessToken = "test-token"
+
+	// DefaultPageSize is the page size of list endpoints when no max is
+	// given.
+	DefaultPageSize = 100
+
+	// OrgID is the organization every person belongs to.
+	OrgID = "Y2lzY29zcGFyazovL3VzL09SR0FOSVpBVElPTi93ZWJleHRlc3Q"
+
+	// SiteURL is the Webex site hosting meetings.
+	SiteURL = "webextest.webex.com"
+
+	apiPrefix = "/v1/"
+)
+
+// Options configures a Server.
+type Options struct {
+	// Me is the person authenticated by DefaultAccessToken. Defaults to
+	// "Test User" <test.user@example.com>.
+	Me *messaging.Person
+
+	// PageSize overrides DefaultPageSize.
+	PageSize int
+
+	// WebhookClient delivers webhook notifications. Defaults to a client with
+	// a ten second timeout.
+	WebhookClient *http.Client
+}
+
+// Server is a stateful in-memory fake of the Webex REST API. Point
+// ClientOptions.BaseURL at BaseURL, or use NewClient.
+type Server struct {
+	*httptest.Server
+
+	options Options
+
+	mu         sync.Mutex
+	me         string
+	tokens     map[string]string
+	rateLimits []*rateLimit
+	deliveries []Delivery
+
+	people          *table[messaging.Person]
+	rooms           *table[messaging.Room]
+	memberships     *table[messaging.Membership]
+	messages        *table[messaging.Message]
+	contents        *table[content]
+	teams           *table[messaging.Team]
+	teamMemberships *table[messaging.TeamMembership]
+	webhooks        *table[messaging.Webhook]
+	meetings        *table[meeting.Meeting]
+	invitees        *table[meeting.MeetingInvitee]
+	registrants     *table[meeting.MeetingRegistrant]
+	calls           *table[call]
+	callHistory     *table[historyRecord]
+	voicemail       *table[voiceMessage]
+	licenses        *table[admin.License]
+
+	meetingNumber int
+}
+
+// NewServer starts a fake Webex API. The caller must call Close when
+// finished with it.
+func NewServer(opts *Options) *Server {
+	s := &Server{
+		tokens:          make(map[string]string),
+		people:          newTable[messaging.Person](),
+		rooms:           newTable[messaging.Room](),
+		memberships:     newTable[messaging.Membership](),
+		messages:        newTable[messaging.Message](),
+		contents:        newTable[content](),
+		teams:           newTable[messaging.Team](),
+		teamMemberships: newTable[messaging.TeamMembership](),
+		webhooks:        newTable[messaging.Webhook](),
+		meetings:        newTable[meeting.Meeting](),
+		invitees:        newTable[meeting.MeetingInvitee](),
+		registrants:     newTable[meeting.MeetingRegistrant](),
+		calls:           newTable[call](),
+		callHistory:     newTable[historyRecord](),
+		voicemail:       newTable[voiceMessage](),
+		licenses:        newTable[admin.License](),
+		meetingNumber:   2550000000,
+	}
+
+	if opts != nil {
+		s.options = *opts
+	}
+
+	if s.options.PageSize <= 0 {
+		s.options.PageSize = DefaultPageSize
+	}
+
+	if s.options.WebhookClient == nil {
+		s.options.WebhookClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	me := messaging.Person{DisplayName: "Test User", Emails: []string{"test.user@example.com"}}
+	if s.options.Me != nil {
+		me = *s.options.Me
+	}
+	s.me = s.AddPerson(me).ID
+	s.tokens[DefaultAccessToken] = s.me
+
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// BaseURL is the value for ClientOptions.BaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
+
+// NewClient returns a client authenticated as Me.
+func (s *Server) NewClient(opts ...webexgosdk.Option) (*webexgosdk.Client, error) {
+	return s.NewClientAs(s.me, opts...)
+}
+
+// NewClientAs returns a client authenticated as the person with the given
+// ID.
+func (s *Server) NewClientAs(personID string, opts ...webexgosdk.Option) (*webexgosdk.Client, error) {
+	token, err := s.AccessToken(personID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts[:len(opts):len(opts)], webexgosdk.WithBaseURL(s.BaseURL()))
+	return webexgosdk.NewClient(token, opts...)
+}
+
+// Me returns the person authenticated by DefaultAccessToken.
+func (s *Server) Me() messaging.Person {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.people.get(s.me)
+}
+
+// AddPerson adds a person to the organization and returns it with its ID
+// and defaults filled in.
+func (s *Server) AddPerson(p messaging.Person) *messaging.Person {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	person := s.addPerson(p)
+	copied := *person
+	return &copied
+}
+
+func (s *Server) addPerson(p messaging.Person) *messaging.Person {
+	person := p
+	if person.ID == "" {
+		person.ID = newID("PEOPLE")
+	}
+	if person.DisplayName == "" && len(person.Emails) > 0 {
+		person.DisplayName = person.Emails[0]
+	}
+	if person.OrgID == "" {
+		person.OrgID = OrgID
+	}
+	if person.Type == "" {
+		person.Type = "person"
+	}
+	if person.Status == "" {
+		person.Status = "active"
+	}
+	if person.Created.IsZero() {
+		person.Created = now()
+	}
+
+	s.people.add(person.ID, &person)
+	return &person
+}
+
+// AccessToken returns a token that authenticates as the person with the
+// given ID.
+func (s *Server) AccessToken(personID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.people.get(personID) == nil {
+		return "", fmt.Errorf("webextest: unknown person %q", personID)
+	}
+
+	for token, id := range s.tokens {
+		if id == personID {
+			return token, nil
+		}
+	}
+
+	token := "token-" + newUUID()
+	s.tokens[token] = personID
+	return token, nil
+}
+
+type rateLimit struct {
+	prefix     string
+	remaining  int
+	retryAfter time.Duration
+}
+
+// RateLimit makes the next count requests whose API path starts with prefix,
+// such as "messages", fail with 429 Too Many Requests and the given
+// Retry-After. An empty prefix matches every request.
+func (s *Server) RateLimit(prefix string, count int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimits = append(s.rateLimits, &rateLimit{
+		prefix:     strings.TrimPrefix(prefix, "/"),
+		remaining:  count,
+		retryAfter: retryAfter,
+	})
+}
+
+// takeRateLimit consumes a matching rate limit, if any.
+func (s *Server) takeRateLimit(path string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, limit := range s.rateLimits {
+		if !strings.HasPrefix(path, limit.prefix) {
+			continue
+		}
+
+		limit.remaining--
+		if limit.remaining <= 0 {
+			s.rateLimits = append(s.rateLimits[:i], s.rateLimits[i+1:]...)
+		}
+		return limit.retryAfter, true
+	}
+	return 0, false
+}
+
+// apiError is an error response.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func errBadRequest(format string, args ...any) error {
+	return &apiError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+func errNotFound(format string, args ...any) error {
+	return &apiError{status: http.StatusNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func errForbidden(format string, args ...any) error {
+	return &apiError{status: http.StatusForbidden, message: fmt.Sprintf(format, args...)}
+}
+
+func errConflict(format string, args ...any) error {
+	return &apiError{status: http.StatusConflict, message: fmt.Sprintf(format, args...)}
+}
+
+// request is an authenticated API request.
+type request struct {
+	*http.Request
+
+	s      *Server
+	w      http.ResponseWriter
+	caller *messaging.Person
+
+	// events are delivered to webhooks once the state lock is released.
+	events []*event
+}
+
+type handlerFunc func(r *request) (any, error)
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h handlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.Handle(method+" "+apiPrefix+path, s.handler(h))
+	}
+
+	s.peopleRoutes(handle)
+	s.roomRoutes(handle)
+	s.messageRoutes(handle)
+	s.webhookRoutes(handle)
+	s.meetingRoutes(handle)
+	s.telephonyRoutes(handle)
+	s.licenseRoutes(handle)
+
+	mux.Handle("/", s.handler(func(r *request) (any, error) {
+		return nil, errNotFound("The requested resource could not be found.")
+	}))
+	return mux
+}
+
+// handler authenticates, applies rate limits, runs h under the state lock,
+// delivers webhook notifications and writes the response. Notifications are
+// delivered before the response, so they have arrived once a client call
+// returns.
+func (s *Server) handler(h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trackingID := "ROUTER_" + strings.ToUpper(newUUID())
+		w.Header().Set("Trackingid", trackingID)
+
+		if retryAfter, ok := s.takeRateLimit(strings.TrimPrefix(r.URL.Path, apiPrefix)); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeError(w, trackingID, &apiError{status: http.StatusTooManyRequests, message: "Too many requests have been sent in a given amount of time."})
+			return
+		}
+
+		req := &request{Request: r, s: s, w: w}
+
+		s.mu.Lock()
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if personID, found := s.tokens[token]; ok && found {
+			req.caller = s.people.get(personID)
+		}
+
+		var (
+			body any
+			err  error
+		)
+		if req.caller == nil {
+			err = &apiError{status: http.StatusUnauthorized, message: "The request requires a valid access token set in the Authorization request header."}
+		} else {
+			body, err = h(req)
+		}
+
+		// Encode while holding the lock, since body may point into the state.
+		var data []byte
+		if _, ok := body.(*content); err == nil && body != nil && !ok {
+			data, err = json.Marshal(body)
+		}
+
+		webhooks := s.webhooks.list()
+		for i, webhook := range webhooks {
+			copied := *webhook
+			webhooks[i] = &copied
+		}
+		s.mu.Unlock()
+
+		s.deliver(webhooks, req.events)
+
+		if err != nil {
+			writeError(w, trackingID, err)
+			return
+		}
+
+		if file, ok := body.(*content); ok {
+			file.serve(w)
+			return
+		}
+
+		if body == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+func writeError(w http.ResponseWriter, trackingID string, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":    apiErr.message,
+		"errors":     []map[string]string{{"description": apiErr.message}},
+		"trackingId": trackingID,
+	})
+}
+
+// decode unmarshals the JSON request body into v.
+func (r *request) decode(v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errBadRequest("Invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+// notify queues a webhook notification. audience lists the people whose
+// webhooks receive it.
+func (r *request) notify(resource, name string, data any, filter url.Values, audience ...string) {
+	r.events = append(r.events, &event{
+		resource: resource,
+		name:     name,
+		actorID:  r.caller.ID,
+		data:     data,
+		filter:   filter,
+		audience: audience,
+	})
+}
+
+// set copies the value of an optional request field to dst when the request
+// sets one.
+func set[T any](dst *T, o webexgosdk.Optional[T]) {
+	if v, ok := o.Get(); ok {
+		*dst = v
+	}
+}
+
+// merge copies the fields present in the JSON encoding of src onto dst.
+func merge(dst, src any) {
+	if data, err := json.Marshal(src); err == nil {
+		json.Unmarshal(data, dst)
+	}
+}
+
+// items is the body of list responses.
+type items[T any] struct {
+	Items []*T `json:"items"`
+}
+
+// page returns one page of list, honouring the max and cursor parameters and
+// setting a Link header to the next page.
+func page[T any](r *request, list []*T) (any, error) {
+	query := r.URL.Query()
+
+	size := r.s.options.PageSize
+	if max := query.Get("max"); max != "" {
+		n, err := strconv.Atoi(max)
+		if err != nil || n <= 0 {
+			return nil, errBadRequest("Invalid max parameter %q.", max)
+		}
+		size = n
+	}
+
+	offset := 0
+	if cursor := query.Get("cursor"); cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err == nil {
+			offset, err = strconv.Atoi(strings.TrimPrefix(string(decoded), "offset:"))
+		}
+		if err != nil || offset < 0 {
+			return nil, errBadRequest("Invalid cursor.")
+		}
+	}
+
+	if list == nil {
+		list = []*T{}
+	}
+
+	offset = min(offset, len(list))
+	end := min(offset+size, len(list))
+	if end < len(list) {
+		query.Set("cursor", base64.RawURLEncoding.EncodeToString([]byte("offset:"+strconv.Itoa(end))))
+		next := r.s.URL + r.URL.Path + "?" + query.Encode()
+		r.w.Header().Set("Link", `<`+next+`>; rel="next"`)
+	}
+
+	return &items[T]{Items: list[offset:end]}, nil
+}
+
+// table stores resources by ID in creation order.
+type table[T any] struct {
+	byID map[string]*T
+	ids  []string
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{byID: make(map[string]*T)}
+}
+
+func (t *table[T]) add(id string, v *T) {
+	if _, ok := t.byID[id]; !ok {
+		t.ids = append(t.ids, id)
+	}
+	t.byID[id] = v
+}
+
+func (t *table[T]) get(id string) *T {
+	return t.byID[id]
+}
+
+func (t *table[T]) remove(id string) {
+	if _, ok := t.byID[id]; !ok {
+		return
+	}
+
+	delete(t.byID, id)
+	for i, existing := range t.ids {
+		if existing == id {
+			t.ids = append(t.ids[:i], t.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the resources in creation order.
+func (t *table[T]) list() []*T {
+	list := make([]*T, len(t.ids))
+	for i, id := range t.ids {
+		list[i] = t.byID[id]
+	}
+	return list
+}
+
+// filter returns the resources accepted by keep in creation order.
+func (t *table[T]) filter(keep func(*T) bool) []*T {
+	var list []*T
+	for _, id := range t.ids {
+		if v := t.byID[id]; keep(v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// newID returns an ID in the format of the Webex messaging APIs: a base64
+// encoded Hydra URI such as ciscospark://us/ROOM/<uuid>.
+func newID(kind string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("ciscospark://us/" + kind + "/" + newUUID()))
+}
+
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// now returns the current time with the millisecond precision of the API.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// hasEmail reports whether p has the given email address.
+func hasEmail(p *messaging.Person, email string) bool {
+	for _, e := range p.Emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func primaryEmail(p *messaging.Person) string {
+	if len(p.Emails) > 0 {
+		return p.Emails[0]
+	}
+	return ""
+}
@@ -0,0 +1,301 @@
+package webextest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/calling"
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+func newTestServer(t *testing.T, opts *Options) (*Server, *webexgosdk.Client) {
+	t.Helper()
+
+	server := NewServer(opts)
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return server, client
+}
+
+func TestRoomsAndPagination(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestServer(t, &Options{PageSize: 2})
+
+	for _, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+		if _, err := client.Messaging.Rooms.Create(ctx, &messaging.RoomCreateRequest{Title: title}); err != nil {
+			t.Fatalf("failed to create room: %v", err)
+		}
+	}
+
+	first, err := client.Messaging.Rooms.List(ctx, nil)
+	if err != nil || len(first) != 2 {
+		t.Fatalf("expected a first page of 2 rooms, got %d: %v", len(first), err)
+	}
+
+	var titles []string
+	for room, err := range client.Messaging.Rooms.ListAll(ctx, nil) {
+		if err != nil {
+			t.Fatalf("failed to list rooms: %v", err)
+		}
+		titles = append(titles, room.Title)
+	}
+
+	if strings.Join(titles, ",") != "One,Two,Three,Four,Five" {
+		t.Errorf("unexpected rooms: %v", titles)
+	}
+
+	if !strings.HasPrefix(first[0].SipAddress, "25") {
+		t.Errorf("expected a meeting number SIP address, got %q", first[0].SipAddress)
+	}
+}
+
+func TestErrorsIncludeTrackingID(t *testing.T) {
+	_, client := newTestServer(t, nil)
+
+	_, err := client.Messaging.Rooms.Get(context.Background(), "missing")
+
+	var apiErr *webexgosdk.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an API error, got %v", err)
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound || !strings.HasPrefix(apiErr.TrackingID, "ROUTER_") {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestServer(t, nil)
+
+	server.RateLimit("people", 1, 0)
+
+	_, err := client.Messaging.People.Get(ctx, "me")
+	var rateErr *webexgosdk.RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+
+	if _, err := client.Messaging.People.Get(ctx, "me"); err != nil {
+		t.Fatalf("expected the limit to be used up, got %v", err)
+	}
+
+	server.RateLimit("", 2, 0)
+
+	retrying, err := server.NewClient(webexgosdk.ClientOptions{Retry: webexgosdk.DefaultRetryPolicy()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	me, err := retrying.Messaging.People.Get(ctx, "me")
+	if err != nil || me.ID != server.Me().ID {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+}
+
+func TestMessagesAndWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu     sync.Mutex
+		bodies [][]byte
+	)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !webhook.Verify(body, r.Header.Get(webhook.SignatureHeader), "s3cret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer target.Close()
+
+	server, client := newTestServer(t, nil)
+	bob := server.AddPerson(messaging.Person{DisplayName: "Bob", Emails: []string{"bob@example.com"}})
+
+	bobClient, err := server.NewClientAs(bob.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bobClient.Messaging.Webhooks.Create(ctx, &messaging.WebhookCreateRequest{
+		Name:      "mentions",
+		TargetURL: target.URL,
+		Resource:  webhook.ResourceMessages,
+		Event:     webhook.EventCreated,
+		Filter:    "mentionedPeople=me",
+		Secret:    "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("failed to create webhook: %v", err)
+	}
+
+	message, err := client.Messaging.Messages.Create(ctx, &messaging.MessageCreateRequest{
+		ToPersonEmail: "bob@example.com",
+		Markdown:      "Hi <@personEmail:bob@example.com|Bob>",
+	})
+	if err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+
+	if len(message.MentionedPeople) != 1 || message.MentionedPeople[0] != bob.ID || message.RoomType != "direct" {
+		t.Errorf("unexpected message: %+v", message)
+	}
+
+	if _, err := client.Messaging.Messages.Create(ctx, &messaging.MessageCreateRequest{RoomID: message.RoomID, Text: "no mention"}); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+
+	deliveries := server.Deliveries()
+	if len(deliveries) != 1 || deliveries[0].StatusCode != http.StatusOK {
+		t.Fatalf("expected one successful delivery, got %+v", deliveries)
+	}
+
+	var data webhook.MessageData
+	if err := deliveries[0].Envelope.DecodeData(&data); err != nil || data.ID != message.ID {
+		t.Errorf("unexpected notification data %+v: %v", data, err)
+	}
+
+	if deliveries[0].Envelope.ActorID != server.Me().ID || len(bodies) != 1 {
+		t.Errorf("unexpected envelope %+v", deliveries[0].Envelope)
+	}
+
+	direct, err := bobClient.Messaging.Messages.ListDirect(ctx, &messaging.MessageDirectListOptions{PersonEmail: "test.user@example.com"})
+	if err != nil || len(direct) != 2 || direct[0].Text != "no mention" {
+		t.Errorf("expected newest message first, got %d messages: %v", len(direct), err)
+	}
+}
+
+func TestFileUploadAndDownload(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestServer(t, nil)
+
+	room, err := client.Messaging.Rooms.Create(ctx, &messaging.RoomCreateRequest{Title: "Files"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := client.Messaging.Messages.CreateWithFile(ctx, &messaging.MessageCreateRequest{RoomID: room.ID, Text: "report"},
+		[]messaging.MessageFile{{Name: "report.txt", ContentType: "text/plain", Reader: strings.NewReader("quarterly numbers")}}, nil)
+	if err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	if len(message.Files) != 1 {
+		t.Fatalf("expected one file, got %v", message.Files)
+	}
+
+	var buf bytes.Buffer
+	info, err := client.Messaging.Files.Download(ctx, message.Files[0], &buf)
+	if err != nil {
+		t.Fatalf("failed to download: %v", err)
+	}
+
+	if buf.String() != "quarterly numbers" || info.Name != "report.txt" || info.ContentType != "text/plain" {
+		t.Errorf("unexpected download %q: %+v", buf.String(), info)
+	}
+}
+
+func TestCallsAndVoicemail(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestServer(t, nil)
+	me := server.Me()
+
+	call, err := server.IncomingCall(me.ID, calling.RemoteParty{Name: "Alice", Number: "+15551234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Calling.Calls.Hold(ctx, call.CallID); err == nil {
+		t.Error("expected holding a ringing call to fail")
+	}
+
+	for _, step := range []func(context.Context, string) error{
+		client.Calling.Calls.Answer,
+		client.Calling.Calls.Hold,
+		client.Calling.Calls.Resume,
+		client.Calling.Calls.Hangup,
+	} {
+		if err := step(ctx, call.CallID); err != nil {
+			t.Fatalf("call step failed: %v", err)
+		}
+	}
+
+	if err := client.Calling.Calls.Hangup(ctx, call.CallID); err == nil {
+		t.Error("expected hanging up an ended call to fail")
+	}
+
+	history, err := client.Calling.CallHistory.ListReceivedCalls(ctx, 0)
+	if err != nil || len(history) != 1 || history[0].Name != "Alice" {
+		t.Errorf("unexpected call history %+v: %v", history, err)
+	}
+
+	voicemail, err := server.AddVoicemail(me.ID, calling.VoiceMessage{Duration: 12, Urgent: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Calling.Voicemail.MarkAsRead(ctx, voicemail.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := client.Calling.Voicemail.GetSummary(ctx)
+	if err != nil || summary.OldUrgentMessages != 1 || summary.NewMessages != 0 {
+		t.Errorf("unexpected summary %+v: %v", summary, err)
+	}
+}
+
+func TestModifyRoomClearsFlags(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestServer(t, nil)
+
+	room, err := client.Messaging.Rooms.Create(ctx, &messaging.RoomCreateRequest{Title: "Locked", IsLocked: true, Description: "keep me"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	room, err = client.Messaging.Rooms.Modify(ctx, room.ID, func(req *messaging.RoomUpdateRequest) {
+		req.IsLocked = webexgosdk.Some(false)
+	})
+	if err != nil {
+		t.Fatalf("failed to modify room: %v", err)
+	}
+
+	if room.IsLocked || room.Title != "Locked" || room.Description != "keep me" {
+		t.Errorf("unexpected room %+v", room)
+	}
+}
+
+func TestModifyPersonClearsFields(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestServer(t, nil)
+
+	person := server.AddPerson(messaging.Person{Emails: []string{"bob@example.com"}, FirstName: "Bob", Department: "Sales", Title: "Rep"})
+
+	person, err := client.Messaging.People.Modify(ctx, person.ID, func(req *messaging.PersonUpdateRequest) {
+		req.Department = webexgosdk.Some("")
+	})
+	if err != nil {
+		t.Fatalf("failed to modify person: %v", err)
+	}
+
+	if person.Department != "" || person.FirstName != "Bob" || person.Title != "Rep" {
+		t.Errorf("unexpected person %+v", person)
+	}
+}
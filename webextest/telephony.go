@@ -0,0 +1,357 @@
+package webextest
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/rainuxhe/webexgosdk/calling"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+// call is an active call of one person.
+type call struct {
+	calling.Call
+	owner string
+}
+
+// historyRecord is a call history entry of one person.
+type historyRecord struct {
+	calling.CallHistoryRecord
+	owner string
+}
+
+// voiceMessage is a voicemail of one person.
+type voiceMessage struct {
+	calling.VoiceMessage
+	owner string
+}
+
+func (s *Server) telephonyRoutes(handle func(string, handlerFunc)) {
+	handle("GET telephony/calls", s.listCalls)
+	handle("GET telephony/calls/{id}", s.getCall)
+	handle("POST telephony/calls/dial", s.dial)
+	handle("POST telephony/calls/answer", s.callAction(calling.CallStateAlerting, calling.CallStateConnected))
+	handle("POST telephony/calls/reject", s.callAction(calling.CallStateAlerting, ""))
+	handle("POST telephony/calls/hold", s.callAction(calling.CallStateConnected, calling.CallStateHeld))
+	handle("POST telephony/calls/resume", s.callAction(calling.CallStateHeld, calling.CallStateConnected))
+	handle("POST telephony/calls/hangup", s.callAction("", ""))
+
+	handle("GET telephony/calls/history", s.listCallHistory)
+	handle("DELETE telephony/calls/history", s.deleteCallHistory)
+
+	handle("GET telephony/voiceMessages", s.listVoicemail)
+	handle("GET telephony/voiceMessages/summary", s.voicemailSummary)
+	handle("PUT telephony/voiceMessages/{id}", s.markVoicemail)
+	handle("DELETE telephony/voiceMessages/{id}", s.deleteVoicemail)
+}
+
+// IncomingCall rings the person with the given ID with a call from the
+// given party. The call can then be answered, rejected or hung up through
+// the API.
+func (s *Server) IncomingCall(personID string, from calling.RemoteParty) (*calling.Call, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.people.get(personID) == nil {
+		return nil, fmt.Errorf("webextest: unknown person %q", personID)
+	}
+
+	c := s.addCall(personID, calling.CallPersonalityTerminator, calling.CallStateAlerting, &from)
+	copied := c.Call
+	return &copied, nil
+}
+
+// AddVoicemail adds a voice message to the mailbox of the person with the
+// given ID and returns it with its ID and defaults filled in.
+func (s *Server) AddVoicemail(personID string, message calling.VoiceMessage) (*calling.VoiceMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.people.get(personID) == nil {
+		return nil, fmt.Errorf("webextest: unknown person %q", personID)
+	}
+
+	if message.ID == "" {
+		message.ID = newID("VOICEMAIL")
+	}
+	if message.Created.IsZero() {
+		message.Created = now()
+	}
+	if message.MediaType == "" {
+		message.MediaType = "WAV"
+	}
+	if message.MessageType == "" {
+		message.MessageType = "VOICE"
+	}
+
+	s.voicemail.add(message.ID, &voiceMessage{VoiceMessage: message, owner: personID})
+	copied := message
+	return &copied, nil
+}
+
+func (s *Server) addCall(owner string, personality calling.CallPersonality, state calling.CallState, remote *calling.RemoteParty) *call {
+	c := &call{
+		Call: calling.Call{
+			ID:            newID("CALL"),
+			CallSessionID: newUUID(),
+			Personality:   personality,
+			State:         state,
+			RemoteParty:   remote,
+			Appearance:    len(s.calls.filter(func(c *call) bool { return c.owner == owner })) + 1,
+			Created:       now(),
+		},
+		owner: owner,
+	}
+	c.CallID = c.ID
+	if state == calling.CallStateConnected {
+		c.Connected = c.Created
+	}
+
+	s.calls.add(c.ID, c)
+	return c
+}
+
+// callData returns the telephony_calls notification payload for c.
+func callData(c *call, eventType string) *webhook.TelephonyCallData {
+	data := &webhook.TelephonyCallData{
+		EventType:      eventType,
+		EventTimestamp: now(),
+		CallID:         c.CallID,
+		CallSessionID:  c.CallSessionID,
+		Personality:    string(c.Personality),
+		State:          string(c.State),
+		RemoteParty:    c.RemoteParty,
+		Appearance:     c.Appearance,
+		Created:        c.Created,
+		Answered:       c.Connected,
+	}
+	if eventType == "disconnected" {
+		data.Disconnected = data.EventTimestamp
+	}
+	return data
+}
+
+// notifyCall queues a telephony_calls notification for the owner of c.
+func (r *request) notifyCall(c *call, event, eventType string) {
+	r.notify(webhook.ResourceTelephonyCalls, event, callData(c, eventType),
+		url.Values{"personality": {string(c.Personality)}}, c.owner)
+}
+
+func (s *Server) listCalls(r *request) (any, error) {
+	calls := []*calling.Call{}
+	for _, c := range s.calls.filter(func(c *call) bool { return c.owner == r.caller.ID }) {
+		calls = append(calls, &c.Call)
+	}
+	return &items[calling.Call]{Items: calls}, nil
+}
+
+// callOf returns a call of the caller.
+func (s *Server) callOf(r *request, id string) (*call, error) {
+	c := s.calls.get(id)
+	if c == nil || c.owner != r.caller.ID {
+		return nil, errNotFound("Call not found.")
+	}
+	return c, nil
+}
+
+func (s *Server) getCall(r *request) (any, error) {
+	c, err := s.callOf(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return &c.Call, nil
+}
+
+// dial places a call. The far end answers immediately, so the call starts
+// connected.
+func (s *Server) dial(r *request) (any, error) {
+	var req calling.DialRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Destination == "" {
+		return nil, errBadRequest("destination is required.")
+	}
+
+	remote := &calling.RemoteParty{Number: req.Destination, CallType: "external"}
+	if person := s.personByEmail(req.Destination); person != nil {
+		remote = &calling.RemoteParty{Name: person.DisplayName, PersonID: person.ID, CallType: "location"}
+	}
+
+	c := s.addCall(r.caller.ID, calling.CallPersonalityOriginator, calling.CallStateConnected, remote)
+	r.notifyCall(c, webhook.EventCreated, "connected")
+
+	return &calling.DialResponse{CallID: c.CallID, CallSessionID: c.CallSessionID}, nil
+}
+
+// callAction returns a handler moving a call from one state to another. An
+// empty from accepts any state; an empty to ends the call.
+func (s *Server) callAction(from, to calling.CallState) handlerFunc {
+	return func(r *request) (any, error) {
+		var req calling.CallIDRequest
+		if err := r.decode(&req); err != nil {
+			return nil, err
+		}
+
+		c, err := s.callOf(r, req.CallID)
+		if err != nil {
+			return nil, err
+		}
+
+		if from != "" && c.State != from {
+			return nil, errBadRequest("Call is %s, not %s.", c.State, from)
+		}
+
+		if to == "" {
+			s.endCall(c)
+			r.notifyCall(c, webhook.EventDeleted, "disconnected")
+			return nil, nil
+		}
+
+		if to == calling.CallStateConnected && c.Connected.IsZero() {
+			c.Connected = now()
+		}
+		c.State = to
+		c.Held = to == calling.CallStateHeld
+
+		r.notifyCall(c, webhook.EventUpdated, string(to))
+		return nil, nil
+	}
+}
+
+// endCall removes c and records it in the owner's call history.
+func (s *Server) endCall(c *call) {
+	s.calls.remove(c.ID)
+
+	end := now()
+	record := &historyRecord{
+		CallHistoryRecord: calling.CallHistoryRecord{
+			ID:            newID("CALL_HISTORY"),
+			StartTime:     c.Created,
+			EndTime:       end,
+			CallSessionID: c.CallSessionID,
+			LocalCallID:   c.CallID,
+			UserID:        c.owner,
+			OrgID:         OrgID,
+		},
+		owner: c.owner,
+	}
+
+	if c.RemoteParty != nil {
+		record.Name = c.RemoteParty.Name
+		record.Number = c.RemoteParty.Number
+	}
+
+	switch {
+	case c.Personality == calling.CallPersonalityOriginator:
+		record.Type = calling.CallHistoryTypePlaced
+		record.Direction = calling.CallDirectionOutgoing
+	case c.Connected.IsZero():
+		record.Type = calling.CallHistoryTypeMissed
+		record.Direction = calling.CallDirectionIncoming
+	default:
+		record.Type = calling.CallHistoryTypeReceived
+		record.Direction = calling.CallDirectionIncoming
+	}
+
+	if !c.Connected.IsZero() {
+		record.IsAnswered = true
+		record.AnswerTime = c.Connected
+		record.Duration = int(end.Sub(c.Connected).Seconds())
+	}
+
+	s.callHistory.add(record.ID, record)
+}
+
+func (s *Server) listCallHistory(r *request) (any, error) {
+	callType := calling.CallHistoryType(r.URL.Query().Get("type"))
+	switch callType {
+	case "", calling.CallHistoryTypePlaced, calling.CallHistoryTypeReceived, calling.CallHistoryTypeMissed:
+	default:
+		return nil, errBadRequest("Invalid type %q.", callType)
+	}
+
+	var records []*calling.CallHistoryRecord
+	for _, record := range s.callHistory.filter(func(h *historyRecord) bool {
+		return h.owner == r.caller.ID && (callType == "" || h.Type == callType)
+	}) {
+		records = append(records, &record.CallHistoryRecord)
+	}
+	slices.Reverse(records)
+	return page(r, records)
+}
+
+func (s *Server) deleteCallHistory(r *request) (any, error) {
+	for _, record := range s.callHistory.filter(func(h *historyRecord) bool { return h.owner == r.caller.ID }) {
+		s.callHistory.remove(record.ID)
+	}
+	return nil, nil
+}
+
+func (s *Server) listVoicemail(r *request) (any, error) {
+	var messages []*calling.VoiceMessage
+	for _, m := range s.voicemail.filter(func(m *voiceMessage) bool { return m.owner == r.caller.ID }) {
+		messages = append(messages, &m.VoiceMessage)
+	}
+	slices.Reverse(messages)
+	return page(r, messages)
+}
+
+func (s *Server) voicemailSummary(r *request) (any, error) {
+	var summary calling.VoiceMessageSummary
+	for _, m := range s.voicemail.filter(func(m *voiceMessage) bool { return m.owner == r.caller.ID }) {
+		switch {
+		case !m.Read && m.Urgent:
+			summary.NewUrgentMessages++
+		case !m.Read:
+			summary.NewMessages++
+		case m.Urgent:
+			summary.OldUrgentMessages++
+		default:
+			summary.OldMessages++
+		}
+	}
+	return &summary, nil
+}
+
+// voicemailOf returns a voice message of the caller.
+func (s *Server) voicemailOf(r *request) (*voiceMessage, error) {
+	m := s.voicemail.get(r.PathValue("id"))
+	if m == nil || m.owner != r.caller.ID {
+		return nil, errNotFound("Voice message not found.")
+	}
+	return m, nil
+}
+
+func (s *Server) markVoicemail(r *request) (any, error) {
+	m, err := s.voicemailOf(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Read *bool `json:"read"`
+	}
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Read == nil {
+		return nil, errBadRequest("read is required.")
+	}
+
+	m.Read = *req.Read
+	return nil, nil
+}
+
+func (s *Server) deleteVoicemail(r *request) (any, error) {
+	m, err := s.voicemailOf(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.voicemail.remove(m.ID)
+	return nil, nil
+}
@@ -0,0 +1,256 @@
+package webextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/rainuxhe/webexgosdk/messaging"
+	"github.com/rainuxhe/webexgosdk/webhook"
+)
+
+func (s *Server) webhookRoutes(handle func(string, handlerFunc)) {
+	handle("GET webhooks", s.listWebhooks)
+	handle("POST webhooks", s.createWebhook)
+	handle("GET webhooks/{id}", s.getWebhook)
+	handle("PUT webhooks/{id}", s.updateWebhook)
+	handle("DELETE webhooks/{id}", s.deleteWebhook)
+}
+
+// event is a change that webhooks are notified of.
+type event struct {
+	resource string
+	name     string
+	actorID  string
+	data     any
+
+	// filter holds the values webhook filters are matched against.
+	filter url.Values
+
+	// audience lists the people whose webhooks are notified. Empty means
+	// everyone.
+	audience []string
+}
+
+// Delivery is an attempt to deliver a webhook notification.
+type Delivery struct {
+	Webhook  messaging.Webhook
+	Envelope webhook.Envelope
+
+	// StatusCode is the response status from the target URL, or zero when
+	// the request failed with Err.
+	StatusCode int
+	Err        error
+}
+
+// Deliveries returns the webhook notifications sent so far, oldest first.
+func (s *Server) Deliveries() []Delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.deliveries)
+}
+
+// webhookMatches reports whether w is notified of e.
+func webhookMatches(w *messaging.Webhook, e *event) bool {
+	switch {
+	case w.Status != messaging.WebhookStatusActive:
+		return false
+	case string(w.Resource) != e.resource && w.Resource != messaging.WebhookResourceAll:
+		return false
+	case string(w.Event) != e.name && w.Event != messaging.WebhookEventAll:
+		return false
+	case len(e.audience) > 0 && !slices.Contains(e.audience, w.CreatedBy):
+		return false
+	}
+
+	filter, _ := url.ParseQuery(w.Filter)
+	for key, wanted := range filter {
+		for _, value := range wanted {
+			if value == "me" {
+				value = w.CreatedBy
+			}
+			if !slices.Contains(e.filter[key], value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// deliver posts events to the target URLs of matching webhooks.
+func (s *Server) deliver(webhooks []*messaging.Webhook, events []*event) {
+	for _, e := range events {
+		data, err := json.Marshal(e.data)
+		if err != nil {
+			continue
+		}
+
+		for _, w := range webhooks {
+			if !webhookMatches(w, e) {
+				continue
+			}
+
+			envelope := webhook.Envelope{
+				ID:        w.ID,
+				Name:      w.Name,
+				TargetURL: w.TargetURL,
+				Resource:  e.resource,
+				Event:     e.name,
+				Filter:    w.Filter,
+				OrgID:     w.OrgID,
+				CreatedBy: w.CreatedBy,
+				AppID:     w.AppID,
+				OwnedBy:   w.OwnedBy,
+				Status:    string(w.Status),
+				Created:   w.Created,
+				ActorID:   e.actorID,
+				Data:      data,
+			}
+
+			delivery := Delivery{Webhook: *w, Envelope: envelope}
+			delivery.StatusCode, delivery.Err = s.post(w, &envelope)
+
+			s.mu.Lock()
+			s.deliveries = append(s.deliveries, delivery)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// post sends one notification, signing it when the webhook has a secret.
+func (s *Server) post(w *messaging.Webhook, envelope *webhook.Envelope) (int, error) {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(body, w.Secret))
+	}
+
+	resp, err := s.options.WebhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// webhook returns a webhook owned by the caller.
+func (s *Server) webhook(r *request) (*messaging.Webhook, error) {
+	w := s.webhooks.get(r.PathValue("id"))
+	if w == nil || w.CreatedBy != r.caller.ID {
+		return nil, errNotFound("Webhook not found.")
+	}
+	return w, nil
+}
+
+func (s *Server) listWebhooks(r *request) (any, error) {
+	webhooks := s.webhooks.filter(func(w *messaging.Webhook) bool { return w.CreatedBy == r.caller.ID })
+	return page(r, webhooks)
+}
+
+func (s *Server) createWebhook(r *request) (any, error) {
+	var req messaging.WebhookCreateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.Name == "":
+		return nil, errBadRequest("name is required.")
+	case req.TargetURL == "":
+		return nil, errBadRequest("targetUrl is required.")
+	case req.Resource == "":
+		return nil, errBadRequest("resource is required.")
+	case req.Event == "":
+		return nil, errBadRequest("event is required.")
+	}
+
+	if _, err := url.ParseRequestURI(req.TargetURL); err != nil {
+		return nil, errBadRequest("Invalid targetUrl %q.", req.TargetURL)
+	}
+
+	if _, err := url.ParseQuery(req.Filter); err != nil {
+		return nil, errBadRequest("Invalid filter %q.", req.Filter)
+	}
+
+	w := &messaging.Webhook{
+		ID:        newID("WEBHOOK"),
+		Name:      req.Name,
+		TargetURL: req.TargetURL,
+		Resource:  req.Resource,
+		Event:     req.Event,
+		OrgID:     r.caller.OrgID,
+		CreatedBy: r.caller.ID,
+		AppID:     newID("APPLICATION"),
+		OwnedBy:   req.OwnedBy,
+		Filter:    req.Filter,
+		Secret:    req.Secret,
+		Status:    messaging.WebhookStatusActive,
+		Created:   now(),
+	}
+	if w.OwnedBy == "" {
+		w.OwnedBy = "creator"
+	}
+
+	s.webhooks.add(w.ID, w)
+	return w, nil
+}
+
+func (s *Server) getWebhook(r *request) (any, error) {
+	return s.webhook(r)
+}
+
+func (s *Server) updateWebhook(r *request) (any, error) {
+	w, err := s.webhook(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req messaging.WebhookUpdateRequest
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" || req.TargetURL == "" {
+		return nil, errBadRequest("name and targetUrl are required.")
+	}
+
+	if req.Status != "" && req.Status != messaging.WebhookStatusActive && req.Status != messaging.WebhookStatusInactive {
+		return nil, errBadRequest("Invalid status %q.", req.Status)
+	}
+
+	w.Name = req.Name
+	w.TargetURL = req.TargetURL
+	if req.Secret != "" {
+		w.Secret = req.Secret
+	}
+	if req.OwnedBy != "" {
+		w.OwnedBy = req.OwnedBy
+	}
+	if req.Status != "" {
+		w.Status = req.Status
+	}
+	return w, nil
+}
+
+func (s *Server) deleteWebhook(r *request) (any, error) {
+	w, err := s.webhook(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.webhooks.remove(w.ID)
+	return nil, nil
+}
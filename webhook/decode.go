@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rainuxhe/webexgosdk"
+	"github.com/rainuxhe/webexgosdk/calling"
+	"github.com/rainuxhe/webexgosdk/meeting"
+	"github.com/rainuxhe/webexgosdk/messaging"
+)
+
+var (
+	ErrUnknownResource  = errors.New("unknown webhook resource")
+	ErrResourceMismatch = errors.New("webhook resource does not match the requested object")
+)
+
+// Event is a notification whose data has been decoded into the type that
+// matches its resource, for example *MessageData for "messages".
+type Event struct {
+	Envelope
+	Data any
+}
+
+// Decode parses a notification body and decodes its data.
+func Decode(body []byte) (*Event, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook envelope: %w", err)
+	}
+
+	return envelope.Decode()
+}
+
+// Decode decodes the envelope's data into the type registered for its
+// resource. Unknown resources return ErrUnknownResource together with an
+// Event whose Data is the raw JSON.
+func (e *Envelope) Decode() (*Event, error) {
+	event := &Event{Envelope: *e}
+
+	var data any
+	switch e.Resource {
+	case ResourceMessages:
+		data = &MessageData{}
+	case ResourceMemberships:
+		data = &MembershipData{}
+	case ResourceRooms:
+		data = &RoomData{}
+	case ResourceMeetings:
+		data = &MeetingData{}
+	case ResourceRecordings:
+		data = &RecordingData{}
+	case ResourceMeetingParticipants:
+		data = &MeetingParticipantData{}
+	case ResourceMeetingTranscripts:
+		data = &MeetingTranscriptData{}
+	case ResourceAttachmentActions:
+		data = &AttachmentActionData{}
+	case ResourceTelephonyCalls:
+		data = &TelephonyCallData{}
+	case ResourceConvergedRecordings:
+		data = &ConvergedRecordingData{}
+	default:
+		event.Data = e.Data
+		return event, fmt.Errorf("%w: %s", ErrUnknownResource, e.Resource)
+	}
+
+	if err := e.DecodeData(data); err != nil {
+		return nil, err
+	}
+
+	event.Data = data
+	return event, nil
+}
+
+// dataID returns the ID of the object the notification refers to.
+func (e *Envelope) dataID(resource string) (string, error) {
+	if e.Resource != resource {
+		return "", fmt.Errorf("%w: %s is not %s", ErrResourceMismatch, e.Resource, resource)
+	}
+
+	var data struct {
+		ID     string `json:"id"`
+		CallID string `json:"callId"`
+	}
+	if err := e.DecodeData(&data); err != nil {
+		return "", err
+	}
+
+	if resource == ResourceTelephonyCalls {
+		return data.CallID, nil
+	}
+	return data.ID, nil
+}
+
+// FetchMessage retrieves the full message, including its text, which message
+// notifications omit.
+func (e *Envelope) FetchMessage(ctx context.Context, client *webexgosdk.Client) (*messaging.Message, error) {
+	id, err := e.dataID(ResourceMessages)
+	if err != nil {
+		return nil, err
+	}
+	return client.Messaging.Messages.Get(ctx, id)
+}
+
+// FetchMembership retrieves the membership the notification refers to.
+func (e *Envelope) FetchMembership(ctx context.Context, client *webexgosdk.Client) (*messaging.Membership, error) {
+	id, err := e.dataID(ResourceMemberships)
+	if err != nil {
+		return nil, err
+	}
+	return client.Messaging.Memberships.Get(ctx, id)
+}
+
+// FetchRoom retrieves the room the notification refers to.
+func (e *Envelope) FetchRoom(ctx context.Context, client *webexgosdk.Client) (*messaging.Room, error) {
+	id, err := e.dataID(ResourceRooms)
+	if err != nil {
+		return nil, err
+	}
+	return client.Messaging.Rooms.Get(ctx, id)
+}
+
+// FetchMeeting retrieves the meeting the notification refers to.
+func (e *Envelope) FetchMeeting(ctx context.Context, client *webexgosdk.Client) (*meeting.Meeting, error) {
+	id, err := e.dataID(ResourceMeetings)
+	if err != nil {
+		return nil, err
+	}
+	return client.Meeting.Meetings.Get(ctx, id)
+}
+
+// FetchCall retrieves the details of the call the notification refers to.
+func (e *Envelope) FetchCall(ctx context.Context, client *webexgosdk.Client) (*calling.Call, error) {
+	id, err := e.dataID(ResourceTelephonyCalls)
+	if err != nil {
+		return nil, err
+	}
+	return client.Calling.Calls.GetCallDetails(ctx, id)
+}
+
+// FetchAttachmentAction retrieves the card submission the notification refers
+// to, including the submitted inputs.
+func (e *Envelope) FetchAttachmentAction(ctx context.Context, client *webexgosdk.Client) (*messaging.AttachmentAction, error) {
+	id, err := e.dataID(ResourceAttachmentActions)
+	if err != nil {
+		return nil, err
+	}
+	return client.Messaging.AttachmentActions.Get(ctx, id)
+}
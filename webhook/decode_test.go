@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rainuxhe/webexgosdk"
+)
+
+func TestDecodePicksDataTypeFromResource(t *testing.T) {
+	tests := []struct {
+		body  string
+		check func(t *testing.T, data any)
+	}{
+		{
+			body: `{"resource":"attachmentActions","event":"created","data":{"id":"action-1","messageId":"message-1"}}`,
+			check: func(t *testing.T, data any) {
+				action, ok := data.(*AttachmentActionData)
+				if !ok || action.MessageID != "message-1" {
+					t.Errorf("unexpected data: %#v", data)
+				}
+			},
+		},
+		{
+			body: `{"resource":"meetingParticipants","event":"joined","data":{"id":"p-1","devices":[{"deviceType":"mac"}]}}`,
+			check: func(t *testing.T, data any) {
+				participant, ok := data.(*MeetingParticipantData)
+				if !ok || len(participant.Devices) != 1 || participant.Devices[0].DeviceType != "mac" {
+					t.Errorf("unexpected data: %#v", data)
+				}
+			},
+		},
+		{
+			body: `{"resource":"telephony_calls","event":"updated","data":{"callId":"call-1","state":"connected","remoteParty":{"name":"Bob"}}}`,
+			check: func(t *testing.T, data any) {
+				call, ok := data.(*TelephonyCallData)
+				if !ok || call.CallID != "call-1" || call.RemoteParty.Name != "Bob" {
+					t.Errorf("unexpected data: %#v", data)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		event, err := Decode([]byte(tt.body))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tt.check(t, event.Data)
+	}
+
+	event, err := Decode([]byte(`{"resource":"futureThings","data":{"id":"x"}}`))
+	if !errors.Is(err, ErrUnknownResource) {
+		t.Errorf("expected ErrUnknownResource, got %v", err)
+	}
+	if raw, ok := event.Data.(json.RawMessage); !ok || string(raw) != `{"id":"x"}` {
+		t.Errorf("expected raw data for unknown resource, got %#v", event.Data)
+	}
+}
+
+func TestEnvelopeFetchMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/message-1" {
+			t.Errorf("expected /messages/message-1 path, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"message-1","text":"hello bot"}`))
+	}))
+	defer server.Close()
+
+	client, err := webexgosdk.NewClient("test-token", webexgosdk.ClientOptions{BaseURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(messageCreated), &envelope); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message, err := envelope.FetchMessage(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if message.Text != "hello bot" {
+		t.Errorf("expected message text, got %q", message.Text)
+	}
+
+	if _, err := envelope.FetchRoom(context.Background(), client); !errors.Is(err, ErrResourceMismatch) {
+		t.Errorf("expected ErrResourceMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+// Package webhook receives Webex webhook notifications.
+// It includes an http.Handler that verifies X-Spark-Signature headers, decodes
+// notification envelopes into typed events and dispatches them to registered
+// handler funcs by resource and event.
+
+package webhook
+
+// This file serves as the package documentation for the webhook package.
+// All types are defined in their respective files:
+// - handler.go: Handler, signature verification and dispatch.
+// - events.go: Envelope and typed event payloads.
+// - decode.go: Resource-aware decoding and helpers to fetch full objects.
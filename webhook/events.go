@@ -0,0 +1,263 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rainuxhe/webexgosdk/calling"
+)
+
+const (
+	ResourceMessages            = "messages"
+	ResourceMemberships         = "memberships"
+	ResourceRooms               = "rooms"
+	ResourceMeetings            = "meetings"
+	ResourceRecordings          = "recordings"
+	ResourceMeetingParticipants = "meetingParticipants"
+	ResourceMeetingTranscripts  = "meetingTranscripts"
+	ResourceAttachmentActions   = "attachmentActions"
+	ResourceTelephonyCalls      = "telephony_calls"
+	ResourceConvergedRecordings = "convergedRecordings"
+)
+
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+	EventStarted = "started"
+	EventEnded   = "ended"
+	EventJoined  = "joined"
+	EventLeft    = "left"
+)
+
+// Envelope is the notification Webex POSTs to a webhook's target URL. The
+// resource-specific payload is kept raw in Data until it is decoded.
+type Envelope struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	TargetURL string          `json:"targetUrl,omitempty"`
+	Resource  string          `json:"resource,omitempty"`
+	Event     string          `json:"event,omitempty"`
+	Filter    string          `json:"filter,omitempty"`
+	OrgID     string          `json:"orgId,omitempty"`
+	CreatedBy string          `json:"createdBy,omitempty"`
+	AppID     string          `json:"appId,omitempty"`
+	OwnedBy   string          `json:"ownedBy,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	Created   time.Time       `json:"created,omitempty"`
+	ActorID   string          `json:"actorId,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// DecodeData unmarshals the envelope's data into v.
+func (e *Envelope) DecodeData(v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s:%s data: %w", e.Resource, e.Event, err)
+	}
+	return nil
+}
+
+// MessageData is the payload of messages notifications. It omits the
+// message text, which has to be fetched with MessagesService.Get.
+type MessageData struct {
+	ID              string    `json:"id,omitempty"`
+	RoomID          string    `json:"roomId,omitempty"`
+	RoomType        string    `json:"roomType,omitempty"`
+	ParentID        string    `json:"parentId,omitempty"`
+	PersonID        string    `json:"personId,omitempty"`
+	PersonEmail     string    `json:"personEmail,omitempty"`
+	MentionedPeople []string  `json:"mentionedPeople,omitempty"`
+	MentionedGroups []string  `json:"mentionedGroups,omitempty"`
+	Files           []string  `json:"files,omitempty"`
+	Created         time.Time `json:"created,omitempty"`
+	Updated         time.Time `json:"updated,omitempty"`
+}
+
+// MembershipData is the payload of memberships notifications.
+type MembershipData struct {
+	ID                string    `json:"id,omitempty"`
+	RoomID            string    `json:"roomId,omitempty"`
+	RoomType          string    `json:"roomType,omitempty"`
+	PersonID          string    `json:"personId,omitempty"`
+	PersonEmail       string    `json:"personEmail,omitempty"`
+	PersonDisplayName string    `json:"personDisplayName,omitempty"`
+	PersonOrgID       string    `json:"personOrgId,omitempty"`
+	IsModerator       bool      `json:"isModerator,omitempty"`
+	IsMonitor         bool      `json:"isMonitor,omitempty"`
+	IsRoomHidden      bool      `json:"isRoomHidden,omitempty"`
+	Created           time.Time `json:"created,omitempty"`
+}
+
+// MeetingData is the payload of meetings notifications.
+type MeetingData struct {
+	ID                 string    `json:"id,omitempty"`
+	MeetingSeriesID    string    `json:"meetingSeriesId,omitempty"`
+	ScheduledMeetingID string    `json:"scheduledMeetingId,omitempty"`
+	MeetingNumber      string    `json:"meetingNumber,omitempty"`
+	Title              string    `json:"title,omitempty"`
+	MeetingType        string    `json:"meetingType,omitempty"`
+	ScheduledType      string    `json:"scheduledType,omitempty"`
+	State              string    `json:"state,omitempty"`
+	Timezone           string    `json:"timezone,omitempty"`
+	Start              time.Time `json:"start,omitempty"`
+	End                time.Time `json:"end,omitempty"`
+	HostUserID         string    `json:"hostUserId,omitempty"`
+	HostDisplayName    string    `json:"hostDisplayName,omitempty"`
+	HostEmail          string    `json:"hostEmail,omitempty"`
+	SiteURL            string    `json:"siteUrl,omitempty"`
+	WebLink            string    `json:"webLink,omitempty"`
+}
+
+// TelephonyCallData is the payload of telephony_calls notifications.
+type TelephonyCallData struct {
+	EventType      string               `json:"eventType,omitempty"`
+	EventTimestamp time.Time            `json:"eventTimestamp,omitempty"`
+	CallID         string               `json:"callId,omitempty"`
+	CallSessionID  string               `json:"callSessionId,omitempty"`
+	Personality    string               `json:"personality,omitempty"`
+	State          string               `json:"state,omitempty"`
+	RemoteParty    *calling.RemoteParty `json:"remoteParty,omitempty"`
+	Appearance     int                  `json:"appearance,omitempty"`
+	Created        time.Time            `json:"created,omitempty"`
+	Answered       time.Time            `json:"answered,omitempty"`
+	Disconnected   time.Time            `json:"disconnected,omitempty"`
+}
+
+// RoomData is the payload of rooms notifications.
+type RoomData struct {
+	ID           string    `json:"id,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Type         string    `json:"type,omitempty"`
+	IsLocked     bool      `json:"isLocked,omitempty"`
+	IsPublic     bool      `json:"isPublic,omitempty"`
+	TeamID       string    `json:"teamId,omitempty"`
+	CreatorID    string    `json:"creatorId,omitempty"`
+	OwnerID      string    `json:"ownerId,omitempty"`
+	LastActivity time.Time `json:"lastActivity,omitempty"`
+	Created      time.Time `json:"created,omitempty"`
+}
+
+// RecordingData is the payload of recordings notifications.
+type RecordingData struct {
+	ID                 string    `json:"id,omitempty"`
+	MeetingID          string    `json:"meetingId,omitempty"`
+	ScheduledMeetingID string    `json:"scheduledMeetingId,omitempty"`
+	MeetingSeriesID    string    `json:"meetingSeriesId,omitempty"`
+	Topic              string    `json:"topic,omitempty"`
+	CreateTime         time.Time `json:"createTime,omitempty"`
+	TimeRecorded       time.Time `json:"timeRecorded,omitempty"`
+	HostEmail          string    `json:"hostEmail,omitempty"`
+	SiteURL            string    `json:"siteUrl,omitempty"`
+	DownloadURL        string    `json:"downloadUrl,omitempty"`
+	PlaybackURL        string    `json:"playbackUrl,omitempty"`
+	Password           string    `json:"password,omitempty"`
+	Format             string    `json:"format,omitempty"`
+	ServiceType        string    `json:"serviceType,omitempty"`
+	DurationSeconds    int       `json:"durationSeconds,omitempty"`
+	SizeBytes          int64     `json:"sizeBytes,omitempty"`
+	ShareToMe          bool      `json:"shareToMe,omitempty"`
+	IntegrationTags    []string  `json:"integrationTags,omitempty"`
+	Status             string    `json:"status,omitempty"`
+}
+
+// MeetingParticipantData is the payload of meetingParticipants notifications.
+type MeetingParticipantData struct {
+	ID             string              `json:"id,omitempty"`
+	OrgID          string              `json:"orgId,omitempty"`
+	MeetingID      string              `json:"meetingId,omitempty"`
+	HostEmail      string              `json:"hostEmail,omitempty"`
+	Host           bool                `json:"host,omitempty"`
+	CoHost         bool                `json:"coHost,omitempty"`
+	SpaceModerator bool                `json:"spaceModerator,omitempty"`
+	Email          string              `json:"email,omitempty"`
+	DisplayName    string              `json:"displayName,omitempty"`
+	Invitee        bool                `json:"invitee,omitempty"`
+	Muted          bool                `json:"muted,omitempty"`
+	State          string              `json:"state,omitempty"`
+	SiteURL        string              `json:"siteUrl,omitempty"`
+	JoinedTime     time.Time           `json:"joinedTime,omitempty"`
+	LeftTime       time.Time           `json:"leftTime,omitempty"`
+	Devices        []ParticipantDevice `json:"devices,omitempty"`
+}
+
+type ParticipantDevice struct {
+	CorrelationID string    `json:"correlationId,omitempty"`
+	DeviceType    string    `json:"deviceType,omitempty"`
+	AudioType     string    `json:"audioType,omitempty"`
+	JoinedTime    time.Time `json:"joinedTime,omitempty"`
+	LeftTime      time.Time `json:"leftTime,omitempty"`
+}
+
+// MeetingTranscriptData is the payload of meetingTranscripts notifications.
+type MeetingTranscriptData struct {
+	ID                 string    `json:"id,omitempty"`
+	SiteURL            string    `json:"siteUrl,omitempty"`
+	StartTime          time.Time `json:"startTime,omitempty"`
+	MeetingTopic       string    `json:"meetingTopic,omitempty"`
+	MeetingID          string    `json:"meetingId,omitempty"`
+	MeetingSeriesID    string    `json:"meetingSeriesId,omitempty"`
+	ScheduledMeetingID string    `json:"scheduledMeetingId,omitempty"`
+	HostUserID         string    `json:"hostUserId,omitempty"`
+	VttDownloadLink    string    `json:"vttDownloadLink,omitempty"`
+	TxtDownloadLink    string    `json:"txtDownloadLink,omitempty"`
+	Status             string    `json:"status,omitempty"`
+}
+
+// AttachmentActionData is the payload of attachmentActions notifications,
+// sent when a user submits an Adaptive Card. The submitted inputs have to be
+// fetched separately.
+type AttachmentActionData struct {
+	ID        string    `json:"id,omitempty"`
+	Type      string    `json:"type,omitempty"`
+	MessageID string    `json:"messageId,omitempty"`
+	PersonID  string    `json:"personId,omitempty"`
+	RoomID    string    `json:"roomId,omitempty"`
+	Created   time.Time `json:"created,omitempty"`
+}
+
+// ConvergedRecordingData is the payload of convergedRecordings notifications
+// for Webex Calling recordings.
+type ConvergedRecordingData struct {
+	ID              string    `json:"id,omitempty"`
+	Topic           string    `json:"topic,omitempty"`
+	CreateTime      time.Time `json:"createTime,omitempty"`
+	TimeRecorded    time.Time `json:"timeRecorded,omitempty"`
+	OwnerID         string    `json:"ownerId,omitempty"`
+	OwnerType       string    `json:"ownerType,omitempty"`
+	OwnerEmail      string    `json:"ownerEmail,omitempty"`
+	Format          string    `json:"format,omitempty"`
+	DurationSeconds int       `json:"durationSeconds,omitempty"`
+	SizeBytes       int64     `json:"sizeBytes,omitempty"`
+	ServiceType     string    `json:"serviceType,omitempty"`
+	StorageRegion   string    `json:"storageRegion,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	CallSessionID   string    `json:"callSessionId,omitempty"`
+	LocationID      string    `json:"locationId,omitempty"`
+}
+
+type MessageEvent struct {
+	Envelope
+	Message MessageData
+}
+
+type MembershipEvent struct {
+	Envelope
+	Membership MembershipData
+}
+
+type MeetingEvent struct {
+	Envelope
+	Meeting MeetingData
+}
+
+type TelephonyCallEvent struct {
+	Envelope
+	Call TelephonyCallData
+}
+
+type (
+	MessageCreatedEvent    = MessageEvent
+	MembershipCreatedEvent = MembershipEvent
+	MeetingStartedEvent    = MeetingEvent
+)
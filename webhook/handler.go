@@ -0,0 +1,313 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Spark-Signature"
+
+	DefaultMaxBodySize  = 1 << 20
+	DefaultReplayWindow = 10 * time.Minute
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleDelivery    = errors.New("stale webhook delivery")
+)
+
+// HandlerFunc handles a decoded webhook notification. A returned error makes
+// the Handler answer 500 so that the failure is visible to Webex.
+type HandlerFunc func(ctx context.Context, envelope *Envelope) error
+
+type Options struct {
+	// Secrets are the webhook secrets accepted when verifying signatures.
+	// Configure more than one while rotating a secret. When empty, signatures
+	// are not verified.
+	Secrets []string
+
+	// MaxBodySize limits the size of a notification. Defaults to
+	// DefaultMaxBodySize.
+	MaxBodySize int64
+
+	// ReplayWindow is how long a delivered notification is remembered to
+	// reject replays. Notifications of "created" events whose resource was
+	// created more than ReplayWindow before or after now are rejected too,
+	// so such a delivery cannot be replayed once it has been forgotten.
+	// Defaults to DefaultReplayWindow; negative disables replay detection.
+	ReplayWindow time.Duration
+
+	// ErrorLog receives handler errors. Optional.
+	ErrorLog func(envelope *Envelope, err error)
+}
+
+type route struct {
+	resource string
+	event    string
+	fn       HandlerFunc
+}
+
+// Handler is an http.Handler that receives Webex webhook notifications.
+type Handler struct {
+	options Options
+	routes  []route
+
+	seen map[string]time.Time
+	mu   sync.Mutex
+}
+
+func NewHandler(opts *Options) *Handler {
+	h := &Handler{
+		seen: make(map[string]time.Time),
+	}
+
+	if opts != nil {
+		h.options = *opts
+	}
+
+	if h.options.MaxBodySize <= 0 {
+		h.options.MaxBodySize = DefaultMaxBodySize
+	}
+
+	if h.options.ReplayWindow == 0 {
+		h.options.ReplayWindow = DefaultReplayWindow
+	}
+
+	return h
+}
+
+// Handle registers fn for notifications matching resource and event. An
+// empty string or "*" matches any resource or event. Handlers run in
+// registration order.
+func (h *Handler) Handle(resource, event string, fn HandlerFunc) {
+	h.routes = append(h.routes, route{resource: resource, event: event, fn: fn})
+}
+
+// HandleEvent registers fn like Handle, but passes the notification with its
+// data decoded by Envelope.Decode. Data of unknown resources is passed as raw
+// JSON.
+func (h *Handler) HandleEvent(resource, event string, fn func(ctx context.Context, event *Event) error) {
+	h.Handle(resource, event, func(ctx context.Context, envelope *Envelope) error {
+		decoded, err := envelope.Decode()
+		if err != nil && !errors.Is(err, ErrUnknownResource) {
+			return err
+		}
+		return fn(ctx, decoded)
+	})
+}
+
+func (h *Handler) OnMessageCreated(fn func(ctx context.Context, event *MessageCreatedEvent) error) {
+	h.Handle(ResourceMessages, EventCreated, func(ctx context.Context, envelope *Envelope) error {
+		event := &MessageEvent{Envelope: *envelope}
+		if err := envelope.DecodeData(&event.Message); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	})
+}
+
+func (h *Handler) OnMembershipCreated(fn func(ctx context.Context, event *MembershipCreatedEvent) error) {
+	h.Handle(ResourceMemberships, EventCreated, func(ctx context.Context, envelope *Envelope) error {
+		event := &MembershipEvent{Envelope: *envelope}
+		if err := envelope.DecodeData(&event.Membership); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	})
+}
+
+func (h *Handler) OnMeetingStarted(fn func(ctx context.Context, event *MeetingStartedEvent) error) {
+	h.Handle(ResourceMeetings, EventStarted, func(ctx context.Context, envelope *Envelope) error {
+		event := &MeetingEvent{Envelope: *envelope}
+		if err := envelope.DecodeData(&event.Meeting); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	})
+}
+
+// OnTelephonyCall registers fn for every telephony_calls notification.
+func (h *Handler) OnTelephonyCall(fn func(ctx context.Context, event *TelephonyCallEvent) error) {
+	h.Handle(ResourceTelephonyCalls, "", func(ctx context.Context, envelope *Envelope) error {
+		event := &TelephonyCallEvent{Envelope: *envelope}
+		if err := envelope.DecodeData(&event.Call); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	})
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.options.MaxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	signature := r.Header.Get(SignatureHeader)
+	if len(h.options.Secrets) > 0 && !Verify(body, signature, h.options.Secrets...) {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	if h.isStale(&envelope) {
+		http.Error(w, ErrStaleDelivery.Error(), http.StatusConflict)
+		return
+	}
+
+	key := replayKey(body, signature)
+	if h.isReplay(key) {
+		http.Error(w, "duplicate webhook delivery", http.StatusConflict)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), &envelope); err != nil {
+		// Allow Webex to redeliver a notification that failed to process.
+		h.forget(key)
+
+		if h.options.ErrorLog != nil {
+			h.options.ErrorLog(&envelope, err)
+		}
+		http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Dispatch runs every handler registered for the envelope's resource and
+// event. It stops at the first error.
+func (h *Handler) Dispatch(ctx context.Context, envelope *Envelope) error {
+	for _, route := range h.routes {
+		if !matches(route.resource, envelope.Resource) || !matches(route.event, envelope.Event) {
+			continue
+		}
+
+		if err := route.fn(ctx, envelope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matches(pattern, value string) bool {
+	return pattern == "" || pattern == "*" || pattern == value
+}
+
+// replayKey identifies a delivery. Signed deliveries are keyed by their
+// signature, unsigned ones by a hash of the body.
+func replayKey(body []byte, signature string) string {
+	if signature != "" {
+		return strings.ToLower(signature)
+	}
+
+	sum := sha1.Sum(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// isStale reports whether the resource of a "created" notification was
+// created outside the replay window around now. The envelope's own Created
+// is when the webhook was registered, so it says nothing about the delivery.
+// Other notifications, and those without a creation time, are left to the
+// duplicate check.
+func (h *Handler) isStale(envelope *Envelope) bool {
+	if h.options.ReplayWindow < 0 || envelope.Event != EventCreated {
+		return false
+	}
+
+	var data struct {
+		Created time.Time `json:"created"`
+	}
+	if json.Unmarshal(envelope.Data, &data) != nil || data.Created.IsZero() {
+		return false
+	}
+
+	age := time.Since(data.Created)
+	return age > h.options.ReplayWindow || age < -h.options.ReplayWindow
+}
+
+// isReplay reports whether the delivery identified by key was already
+// received within the replay window, and records it otherwise.
+func (h *Handler) isReplay(key string) bool {
+	if h.options.ReplayWindow < 0 {
+		return false
+	}
+
+	now := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for k, seenAt := range h.seen {
+		if now.Sub(seenAt) > h.options.ReplayWindow {
+			delete(h.seen, k)
+		}
+	}
+
+	if _, ok := h.seen[key]; ok {
+		return true
+	}
+
+	h.seen[key] = now
+	return false
+}
+
+func (h *Handler) forget(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, key)
+}
+
+// Sign returns the X-Spark-Signature value for body: the hex encoded
+// HMAC-SHA1 of body keyed with secret.
+func Sign(body []byte, secret string) string {
+	return hex.EncodeToString(computeSignature(body, secret))
+}
+
+func computeSignature(body []byte, secret string) []byte {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// Verify reports whether signature matches body for any of the secrets.
+func Verify(body []byte, signature string, secrets ...string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	for _, secret := range secrets {
+		if hmac.Equal(got, computeSignature(body, secret)) {
+			return true
+		}
+	}
+	return false
+}
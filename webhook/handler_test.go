@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const messageCreated = `{
+	"id": "webhook-1",
+	"name": "bot",
+	"resource": "messages",
+	"event": "created",
+	"actorId": "person-1",
+	"data": {
+		"id": "message-1",
+		"roomId": "room-1",
+		"personEmail": "alice@example.com"
+	}
+}`
+
+func deliver(t *testing.T, handler http.Handler, body, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerVerifiesSignatureAndDispatches(t *testing.T) {
+	handler := NewHandler(&Options{Secrets: []string{"new-secret", "old-secret"}})
+
+	var got *MessageCreatedEvent
+	handler.OnMessageCreated(func(ctx context.Context, event *MessageCreatedEvent) error {
+		got = event
+		return nil
+	})
+	handler.OnMembershipCreated(func(ctx context.Context, event *MembershipCreatedEvent) error {
+		t.Error("membership handler must not receive message events")
+		return nil
+	})
+
+	if rec := deliver(t, handler, messageCreated, Sign([]byte(messageCreated), "wrong")); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad signature, got %d", rec.Code)
+	}
+
+	if rec := deliver(t, handler, messageCreated, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a missing signature, got %d", rec.Code)
+	}
+
+	signature := Sign([]byte(messageCreated), "old-secret")
+	if rec := deliver(t, handler, messageCreated, signature); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if got == nil || got.Message.ID != "message-1" || got.ActorID != "person-1" {
+		t.Fatalf("unexpected event: %+v", got)
+	}
+
+	if rec := deliver(t, handler, messageCreated, signature); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a replayed delivery, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsOversizedBodies(t *testing.T) {
+	handler := NewHandler(&Options{MaxBodySize: 16})
+
+	if rec := deliver(t, handler, messageCreated, ""); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", rec.Code)
+	}
+}
+
+func TestHandlerAllowsRedeliveryAfterFailure(t *testing.T) {
+	handler := NewHandler(nil)
+
+	calls := 0
+	handler.Handle(ResourceMessages, "*", func(ctx context.Context, envelope *Envelope) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	if rec := deliver(t, handler, messageCreated, ""); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+
+	if rec := deliver(t, handler, messageCreated, ""); rec.Code != http.StatusNoContent {
+		t.Errorf("expected redelivery to succeed, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsStaleDeliveries(t *testing.T) {
+	handler := NewHandler(&Options{Secrets: []string{"secret"}})
+
+	calls := 0
+	handler.Handle(ResourceMessages, "*", func(ctx context.Context, envelope *Envelope) error {
+		calls++
+		return nil
+	})
+
+	for _, created := range []time.Time{time.Now().Add(-time.Hour), time.Now().Add(time.Hour)} {
+		body := fmt.Sprintf(`{"resource":"messages","event":"created","created":"2020-01-01T00:00:00Z","data":{"id":"message-1","created":%q}}`, created.Format(time.RFC3339))
+		if rec := deliver(t, handler, body, Sign([]byte(body), "secret")); rec.Code != http.StatusConflict {
+			t.Errorf("created %v: expected 409, got %d", created, rec.Code)
+		}
+	}
+
+	body := fmt.Sprintf(`{"resource":"messages","event":"created","created":"2020-01-01T00:00:00Z","data":{"id":"message-1","created":%q}}`, time.Now().Format(time.RFC3339))
+	if rec := deliver(t, handler, body, Sign([]byte(body), "secret")); rec.Code != http.StatusNoContent {
+		t.Errorf("expected a fresh delivery to succeed, got %d", rec.Code)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 handler call, got %d", calls)
+	}
+}